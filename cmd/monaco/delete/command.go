@@ -30,6 +30,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 )
 
@@ -37,6 +38,7 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 	var environments, groups []string
 	var manifestName string
 	var deleteFile string
+	var labelSelector string
 
 	deleteCmd = &cobra.Command{
 		Use:     "delete --manifest <manifest.yaml> --file <delete.yaml>",
@@ -55,6 +57,11 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 				return err
 			}
 
+			selector, err := manifest.ParseLabelSelector(labelSelector)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--selector': %w", err)
+			}
+
 			// Sanitize manifest file path to manifest yaml file
 			manifestName = filepath.Clean(manifestName)
 			absManifestFilePath, err := filepath.Abs(manifestName)
@@ -63,11 +70,12 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 			}
 
 			// Try to load the manifest file
-			manifest, errs := manifestloader.Load(&manifestloader.Context{
+			m, errs := manifestloader.Load(&manifestloader.Context{
 				Fs:           fs,
 				ManifestPath: absManifestFilePath,
 				Environments: environments,
 				Groups:       groups,
+				Selector:     selector,
 				Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
 			})
 			if len(errs) > 0 {
@@ -81,7 +89,7 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 				return fmt.Errorf("encountered errors while parsing %s: %w", deleteFile, err)
 			}
 
-			return Delete(cmd.Context(), manifest.Environments.SelectedEnvironments, entriesToDelete)
+			return Delete(cmd.Context(), m.Environments.SelectedEnvironments, entriesToDelete)
 		},
 		ValidArgsFunction: completion.DeleteCompletion,
 	}
@@ -102,6 +110,11 @@ func GetDeleteCommand(fs afero.Fs) (deleteCmd *cobra.Command) {
 			"If this flag is specified, configuration will be deleted from all specified environments. "+
 			"If neither --groups nor --environment is present, all environments will be used for deletion")
 
+	deleteCmd.Flags().StringVar(&labelSelector, "selector", "",
+		"Delete only from environments whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"Labels of the manifest's projects are taken into account as well: an environment is used if the selector matches its labels merged with the labels of any project. "+
+			"If combined with '--environment' or '--group', only environments matching both are used for deletion.")
+
 	if err := deleteCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
 		log.Fatal("failed to setup CLI %v", err)
 	}
@@ -43,7 +43,7 @@ func Delete(ctx context.Context, environments manifest.EnvironmentDefinitionsByN
 			log.WarnContext(ctx, "Delete file contains Dynatrace Platform specific types, but no platform credentials are defined for environment %q - Dynatrace Platform configurations won't be deleted.", env.Name)
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Connection: env.Connection})
 		if err != nil {
 			return fmt.Errorf("failed to create API client for environment %q due to the following error: %w", env.Name, err)
 		}
@@ -0,0 +1,45 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/git"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+)
+
+// changedSinceSelector returns a selector for all configurations whose files changed since the given git revision in
+// the local repository containing the manifest.
+func changedSinceSelector(ctx context.Context, absManifestPath string, revision string) (deploy.ConfigSelector, error) {
+	// git reports paths with symlinks resolved, so the paths of configurations need to be resolved as well
+	baseDir, err := filepath.EvalSymlinks(filepath.Dir(absManifestPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory of manifest %q: %w", absManifestPath, err)
+	}
+
+	changedFiles, err := git.ChangedFiles(ctx, baseDir, revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files changed since %q: %w", revision, err)
+	}
+
+	log.InfoContext(ctx, "Found %d files changed since %q. Only configurations using these files and configurations depending on them are deployed.", len(changedFiles), revision)
+	return deploy.NewChangedFilesSelector(baseDir, changedFiles), nil
+}
@@ -29,14 +29,18 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 	monacoVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
-	var dryRun, continueOnError bool
-	var manifestName string
-	var environment, project, groups []string
+	var dryRun, continueOnError, planMode, prune bool
+	var manifestName, outPlan, applyPlan, resumeFrom, snapshotDir, changedSince, labelSelector string
+	var environment, project, groups, configPatterns []string
+	var parallelEnvironments int
 
 	deployCmd = &cobra.Command{
 		Use:               "deploy <manifest.yaml>",
@@ -47,6 +51,35 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 		PreRun:            cmdutils.SilenceUsageCommand(),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			manifestName = args[0]
+
+			var coordinateSelector deploy.ConfigSelector
+			if len(configPatterns) > 0 {
+				patterns := make([]coordinate.Pattern, 0, len(configPatterns))
+				for _, s := range configPatterns {
+					p, err := coordinate.ParsePattern(s)
+					if err != nil {
+						return fmt.Errorf("invalid value for '--config': %w", err)
+					}
+					patterns = append(patterns, p)
+				}
+				coordinateSelector = deploy.NewCoordinateSelector(patterns)
+			}
+
+			selector, err := manifest.ParseLabelSelector(labelSelector)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--selector': %w", err)
+			}
+
+			// the previous report must be read before creating the deployment context, as the new report may overwrite it
+			var resumeSelector deploy.ConfigSelector
+			if resumeFrom != "" {
+				records, err := report.ReadReportFile(fs, resumeFrom)
+				if err != nil {
+					return fmt.Errorf("failed to read deployment report %q to resume from: %w", resumeFrom, err)
+				}
+				resumeSelector = deploy.NewResumeSelector(records)
+			}
+
 			ctx := createDeploymentContext(cmd.Context(), fs)
 			defer finishReport(ctx)
 
@@ -56,7 +89,22 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 				return err
 			}
 
-			return deployConfigs(ctx, fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			return deployConfigs(ctx, fs, manifestName, deployOptions{
+				environmentGroups:    groups,
+				specificEnvironments: environment,
+				specificProjects:     project,
+				continueOnErr:        continueOnError,
+				dryRun:               dryRun,
+				plan:                 planMode,
+				outPlan:              outPlan,
+				applyPlan:            applyPlan,
+				selector:             deploy.AllOf(coordinateSelector, resumeSelector),
+				parallelEnvironments: parallelEnvironments,
+				prune:                prune,
+				snapshotDir:          snapshotDir,
+				changedSince:         changedSince,
+				labelSelector:        selector,
+			})
 		},
 	}
 
@@ -69,9 +117,36 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
+	deployCmd.Flags().StringVar(&labelSelector, "selector", "", "Deploy only to environments and projects whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+		"A configuration is deployed to an environment if the selector matches the labels of the environment merged with the labels of the configuration's project, which take precedence. "+
+		"Supported requirements are 'key', '!key', 'key=value', 'key!=value', 'key in (a,b)' and 'key notin (a,b)', separated by a comma (,). "+
+		"If combined with '--environment' or '--group', only environments matching both are deployed to.")
 	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
 	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
+	deployCmd.Flags().BoolVar(&planMode, "plan", false, "Show what a deployment would change without changing anything. For each configuration, the existing object in the environment is looked up and compared to the rendered configuration, to print whether it would be created, updated (including the differences), or stay unchanged. "+
+		"This flag is mutually exclusive with '--dry-run'.")
+	deployCmd.Flags().StringVar(&outPlan, "out-plan", "", "Write the plan to the given file, including the rendered payloads and a hash of each remote object the plan was computed against. Implies '--plan'. "+
+		"The written file can be deployed using '--apply-plan'.")
+	deployCmd.Flags().StringVar(&applyPlan, "apply-plan", "", "Deploy exactly the payloads of a plan file written by '--out-plan', instead of the rendered configurations. "+
+		"The deployment fails before anything is deployed if the plan does not contain exactly the selected environments and configurations. "+
+		"Configurations whose remote object changed since the plan was computed are not deployed and reported as errors.")
+
+	deployCmd.Flags().StringVar(&snapshotDir, "snapshot", "", "Save the previous payload of each object before it is updated, and the ID of each newly created object, to the given directory. "+
+		"The deployment can be rolled back using 'monaco rollback --snapshot'. If the directory already contains a snapshot of an object, the existing one is kept.")
+	deployCmd.Flags().BoolVar(&prune, "prune", false, "After a successful deployment, delete all objects that were deployed as part of the deployed projects, but whose configuration was removed from the project since. "+
+		"The objects are always listed first. Combined with '--plan' they are only listed, and '--out-plan' adds their deletion to the plan file. "+
+		"Combined with '--apply-plan' only the objects the plan file deletes are deleted, otherwise the deletion must be confirmed for each environment. "+
+		"Only Settings 2.0 objects are pruned, orphaned documents and automations are not deleted.")
+	deployCmd.Flags().IntVar(&parallelEnvironments, "parallel-environments", 0, "Maximum number of environments to deploy to in parallel. "+
+		"If not set, the value of the 'MONACO_PARALLEL_ENVIRONMENTS' environment variable is used, which defaults to deploying to one environment at a time.")
+	deployCmd.Flags().StringVar(&resumeFrom, "resume-from", "", "Resume a previous deployment using its deployment report. Only configurations that were not deployed successfully according to the report are deployed, "+
+		"along with the configurations they depend on.")
+	deployCmd.Flags().StringSliceVar(&configPatterns, "config", []string{}, "Deploy only configurations whose coordinate matches one of the given patterns '<project>:<type>:<configId>', along with the configurations they depend on. "+
+		"Each part may contain the wildcards '*' and '?', e.g. 'infra:builtin:alerting.profile:*' or '*:dashboard:team-a-*'. "+
+		"To set multiple patterns either repeat this flag, or separate them using a comma (,).")
+	deployCmd.Flags().StringVar(&changedSince, "changed-since", "", "Deploy only configurations whose YAML file, template or 'file' parameters changed since the given git revision, along with the configurations depending on them. "+
+		"Uncommitted and untracked files count as changed. Only the local git repository containing the manifest is used.")
 
 	err := deployCmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByManifestFlag)
 	if err != nil {
@@ -84,6 +159,14 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 	}
 
 	deployCmd.MarkFlagsMutuallyExclusive("environment", "group")
+	deployCmd.MarkFlagsMutuallyExclusive("dry-run", "plan")
+	deployCmd.MarkFlagsMutuallyExclusive("dry-run", "out-plan")
+	deployCmd.MarkFlagsMutuallyExclusive("dry-run", "apply-plan")
+	deployCmd.MarkFlagsMutuallyExclusive("dry-run", "snapshot")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "snapshot")
+	deployCmd.MarkFlagsMutuallyExclusive("out-plan", "snapshot")
+	deployCmd.MarkFlagsMutuallyExclusive("plan", "apply-plan")
+	deployCmd.MarkFlagsMutuallyExclusive("out-plan", "apply-plan")
 
 	return deployCmd
 }
@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/afero"
@@ -33,13 +35,43 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
-func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
+// deployOptions holds the options of a deployment as given on the command line.
+type deployOptions struct {
+	environmentGroups    []string
+	specificEnvironments []string
+	specificProjects     []string
+	continueOnErr        bool
+	dryRun               bool
+	// plan states that the deployment only computes and prints what it would change, without changing anything
+	plan bool
+	// outPlan is the path of the file the computed plan is written to. Implies plan.
+	outPlan string
+	// applyPlan is the path of a plan file previously written in plan mode, whose payloads are deployed.
+	applyPlan string
+	// selector restricts the deployment to the selected configurations and their dependencies, if set
+	selector deploy.ConfigSelector
+	// parallelEnvironments is the maximum number of environments deployed to in parallel
+	parallelEnvironments int
+	// prune states that objects whose configuration was removed from the deployed projects are deleted after a successful deployment
+	prune bool
+	// snapshotDir is the directory the state of each remote object is saved to before it is changed, if set
+	snapshotDir string
+	// changedSince is a git revision. If set, only configurations whose files changed since then, and configurations depending on them, are deployed
+	changedSince string
+	// labelSelector restricts the deployment to the environments and projects whose labels it matches, if set
+	labelSelector manifest.LabelSelector
+}
+
+func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, opts deployOptions) error {
 	absManifestPath, err := absPath(manifestPath)
 	if err != nil {
 		formattedErr := fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
@@ -47,19 +79,33 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return formattedErr
 	}
 
-	loadedManifest, err := loadManifest(ctx, fs, absManifestPath, environmentGroups, specificEnvironments)
+	loadedManifest, err := loadManifest(ctx, fs, absManifestPath, opts.environmentGroups, opts.specificEnvironments, opts.labelSelector)
 	if err != nil {
 		return err
 	}
 
-	if !dryRun && featureflags.VerifyEnvironmentType.Enabled() {
+	selector := opts.selector
+	if !opts.labelSelector.IsEmpty() {
+		selector = deploy.AllOf(selector, deploy.NewLabelSelector(opts.labelSelector, loadedManifest.Environments.SelectedEnvironments, loadedManifest.Projects))
+	}
+
+	var changedSelector deploy.ConfigSelector
+	if opts.changedSince != "" {
+		changedSelector, err = changedSinceSelector(ctx, absManifestPath, opts.changedSince)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
+	if !opts.dryRun && featureflags.VerifyEnvironmentType.Enabled() {
 		if err := dynatrace.VerifyEnvironmentsAuthentication(ctx, loadedManifest.Environments.SelectedEnvironments); err != nil {
 			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
 			return err
 		}
 	}
 
-	loadedProjects, err := loadProjects(ctx, fs, absManifestPath, loadedManifest, specificProjects)
+	loadedProjects, err := loadProjects(ctx, fs, absManifestPath, loadedManifest, opts.specificProjects)
 	if err != nil {
 		return err
 	}
@@ -68,6 +114,10 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return err
 	}
 
+	if opts.prune {
+		warnPruneUnsupportedTypes(ctx, loadedProjects)
+	}
+
 	logging.LogProjectsInfo(loadedProjects)
 	logging.LogEnvironmentsInfo(loadedManifest.Environments.SelectedEnvironments)
 
@@ -78,19 +128,59 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		return formattedErr
 	}
 
-	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments.SelectedEnvironments, dryRun)
+	clientSets, err := dynatrace.CreateEnvironmentClients(ctx, loadedManifest.Environments.SelectedEnvironments, opts.dryRun)
 	if err != nil {
 		formattedErr := fmt.Errorf("failed to create API clients: %w", err)
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
 		return formattedErr
 	}
 
-	err = deploy.DeployForAllEnvironments(ctx, loadedProjects, clientSets, deploy.DeployConfigsOptions{ContinueOnErr: continueOnErr, DryRun: dryRun})
+	planMode := opts.plan || opts.outPlan != ""
+	deployOpts := deploy.DeployConfigsOptions{ContinueOnErr: opts.continueOnErr, DryRun: opts.dryRun, Selector: selector, Changed: changedSelector, MaxParallelEnvironments: opts.parallelEnvironments}
+	if planMode {
+		deployOpts.Plan = plan.New()
+		defer logging.LogPlan(deployOpts.Plan)
+	}
+	if opts.snapshotDir != "" {
+		deployOpts.SnapshotStore = snapshot.NewStore(fs, opts.snapshotDir)
+	}
+	if hooks := hooksPerEnvironment(loadedManifest, loadedProjects); len(hooks) > 0 {
+		if opts.dryRun || planMode {
+			log.InfoContext(ctx, "Deployment hooks are not run in dry-run or plan mode")
+		} else {
+			deployOpts.Hooks = &hook.Runner{WorkingDir: filepath.Dir(absManifestPath), Hooks: hooks}
+		}
+	}
+	if opts.applyPlan != "" {
+		deployOpts.AppliedPlan, err = plan.Load(fs, opts.applyPlan)
+		if err != nil {
+			report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
+
+	operation := logging.GetOperationNounForLogging(opts.dryRun, planMode)
+	err = deploy.DeployForAllEnvironments(ctx, loadedProjects, clientSets, deployOpts)
 	if err != nil {
-		return fmt.Errorf("%v failed - check logs for details: %w", logging.GetOperationNounForLogging(dryRun), err)
+		return fmt.Errorf("%v failed - check logs for details: %w", operation, err)
+	}
+
+	if opts.prune {
+		if opts.dryRun {
+			log.InfoContext(ctx, "Orphaned configurations can not be determined in dry-run mode. Use '--plan' to list them without deleting them.")
+		} else if err := pruneOrphans(ctx, loadedProjects, clientSets, pruneOptions{plan: deployOpts.Plan, appliedPlan: deployOpts.AppliedPlan, confirm: confirmOnPrompt(os.Stdin, os.Stderr)}); err != nil {
+			return fmt.Errorf("pruning failed - check logs for details: %w", err)
+		}
+	}
+
+	if opts.outPlan != "" {
+		if err := plan.Write(fs, opts.outPlan, deployOpts.Plan); err != nil {
+			return err
+		}
+		log.InfoContext(ctx, "Plan written to %q", opts.outPlan)
 	}
 
-	log.InfoContext(ctx, "%s finished without errors", logging.GetOperationNounForLogging(dryRun))
+	log.InfoContext(ctx, "%s finished without errors", operation)
 	return nil
 }
 
@@ -99,12 +189,13 @@ func absPath(manifestPath string) (string, error) {
 	return filepath.Abs(manifestPath)
 }
 
-func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string) (*manifest.Manifest, error) {
+func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string, selector manifest.LabelSelector) (*manifest.Manifest, error) {
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: manifestPath,
 		Groups:       groups,
 		Environments: environments,
+		Selector:     selector,
 		Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
 	})
 
@@ -295,3 +386,27 @@ func validateAuthenticationWithProjectConfigs(projects []project.Project, enviro
 	}
 	return nil
 }
+
+// hooksPerEnvironment returns the hooks to run for each selected environment. The hooks of an environment run
+// before the hooks of the loaded projects applying to it, which run in the order of their names.
+func hooksPerEnvironment(m *manifest.Manifest, projects []project.Project) map[string]manifest.Hooks {
+	projectNames := make([]string, 0, len(projects))
+	for _, p := range projects {
+		projectNames = append(projectNames, p.Id)
+	}
+	slices.Sort(projectNames)
+
+	result := make(map[string]manifest.Hooks)
+	for name, env := range m.Environments.SelectedEnvironments {
+		hooks := env.Hooks
+		for _, p := range projectNames {
+			if m.Projects[p].AppliesTo(name, env.Group) {
+				hooks = hooks.Append(m.Projects[p].Hooks)
+			}
+		}
+		if !hooks.IsEmpty() {
+			result[name] = hooks
+		}
+	}
+	return result
+}
@@ -56,7 +56,7 @@ func Test_DoDeploy_InvalidManifest(t *testing.T) {
 	manifestPath, _ := filepath.Abs("manifest.yaml")
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
-	err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+	err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{continueOnErr: true, dryRun: true})
 	assert.Error(t, err)
 }
 
@@ -97,26 +97,26 @@ environmentGroups:
 	_ = afero.WriteFile(testFs, manifestPath, []byte(manifestYaml), 0644)
 
 	t.Run("Wrong environment group", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"NOT_EXISTING_GROUP"}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{environmentGroups: []string{"NOT_EXISTING_GROUP"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 	t.Run("Wrong environment name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"NOT_EXISTING_ENV"}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"NOT_EXISTING_ENV"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("Wrong project name", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"NON_EXISTING_PROJECT"}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"project"}, specificProjects: []string{"NON_EXISTING_PROJECT"}, continueOnErr: true, dryRun: true})
 		assert.Error(t, err)
 	})
 
 	t.Run("no parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{}, []string{}, []string{}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{continueOnErr: true, dryRun: true})
 		assert.NoError(t, err)
 	})
 
 	t.Run("correct parameters", func(t *testing.T) {
-		err := deployConfigs(t.Context(), testFs, manifestPath, []string{"default"}, []string{"project"}, []string{"project"}, true, true)
+		err := deployConfigs(t.Context(), testFs, manifestPath, deployOptions{environmentGroups: []string{"default"}, specificEnvironments: []string{"project"}, specificProjects: []string{"project"}, continueOnErr: true, dryRun: true})
 		assert.NoError(t, err)
 	})
 
@@ -575,3 +575,57 @@ func Test_ValidateAuthenticationWithProjectConfigs(t *testing.T) {
 		})
 	}
 }
+
+func Test_hooksPerEnvironment(t *testing.T) {
+	envHook := manifest.Hook{Command: []string{"env-hook"}}
+	projectAHook := manifest.Hook{Command: []string{"project-a-hook"}}
+	projectBHook := manifest.Hook{Command: []string{"project-b-hook"}}
+
+	m := &manifest.Manifest{
+		Projects: manifest.ProjectDefinitionByProjectID{
+			"b":        {Name: "b", Hooks: manifest.Hooks{PostDeploy: []manifest.Hook{projectBHook}}},
+			"a":        {Name: "a", Hooks: manifest.Hooks{PostDeploy: []manifest.Hook{projectAHook}}},
+			"unloaded": {Name: "unloaded", Hooks: manifest.Hooks{PreDeploy: []manifest.Hook{{Command: []string{"unloaded"}}}}},
+		},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"with-hooks":    {Name: "with-hooks", Hooks: manifest.Hooks{PreDeploy: []manifest.Hook{envHook}, PostDeploy: []manifest.Hook{envHook}}},
+				"without-hooks": {Name: "without-hooks"},
+			},
+		},
+	}
+
+	got := hooksPerEnvironment(m, []project.Project{{Id: "b"}, {Id: "a"}})
+
+	assert.Equal(t, map[string]manifest.Hooks{
+		"with-hooks": {
+			PreDeploy:  []manifest.Hook{envHook},
+			PostDeploy: []manifest.Hook{envHook, projectAHook, projectBHook},
+		},
+		"without-hooks": {
+			PostDeploy: []manifest.Hook{projectAHook, projectBHook},
+		},
+	}, got)
+}
+
+func Test_hooksPerEnvironment_SkipsProjectsNotApplyingToEnvironment(t *testing.T) {
+	projectHook := manifest.Hook{Command: []string{"project-hook"}}
+
+	m := &manifest.Manifest{
+		Projects: manifest.ProjectDefinitionByProjectID{
+			"prod-only": {Name: "prod-only", EnvironmentGroups: []string{"prod"}, Hooks: manifest.Hooks{PostDeploy: []manifest.Hook{projectHook}}},
+		},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"prod-1": {Name: "prod-1", Group: "prod"},
+				"dev-1":  {Name: "dev-1", Group: "dev"},
+			},
+		},
+	}
+
+	got := hooksPerEnvironment(m, []project.Project{{Id: "prod-only"}})
+
+	assert.Equal(t, map[string]manifest.Hooks{
+		"prod-1": {PostDeploy: []manifest.Hook{projectHook}},
+	}, got)
+}
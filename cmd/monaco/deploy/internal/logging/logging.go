@@ -20,6 +20,7 @@ import (
 	"log/slog"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
@@ -64,9 +65,36 @@ func LogDeploymentInfo(dryRun bool, envName string) {
 	}
 }
 
-func GetOperationNounForLogging(dryRun bool) string {
+func GetOperationNounForLogging(dryRun bool, planMode bool) string {
 	if dryRun {
 		return "Validation"
 	}
+	if planMode {
+		return "Planning"
+	}
 	return "Deployment"
 }
+
+// LogPlan logs the entries of the given plan, grouped by environment, followed by a summary of all planned actions.
+func LogPlan(p *plan.Plan) {
+	counts := make(map[plan.Action]int)
+	environment := ""
+	for i, e := range p.Entries() {
+		if i == 0 || e.Environment != environment {
+			environment = e.Environment
+			log.Info("Plan for environment %q:", environment)
+		}
+
+		counts[e.Action]++
+		if e.RemoteID != "" {
+			log.Info("  %-9s %s (%s)", e.Action, e.Coordinate, e.RemoteID)
+		} else {
+			log.Info("  %-9s %s", e.Action, e.Coordinate)
+		}
+		for _, d := range e.Diff {
+			log.Info("      %s", d)
+		}
+	}
+
+	log.Info("Plan: %d to create, %d to update, %d unchanged, %d to delete", counts[plan.ActionCreate], counts[plan.ActionUpdate], counts[plan.ActionUnchanged], counts[plan.ActionDelete])
+}
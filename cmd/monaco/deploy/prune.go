@@ -0,0 +1,194 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// pruneOptions define what pruneOrphans does with the orphans it found.
+type pruneOptions struct {
+	// plan is the plan the found orphans are added to as deletions, instead of deleting them.
+	plan *plan.Plan
+	// appliedPlan is the plan approving the deletions. If it is set, only orphans it plans to delete are deleted.
+	appliedPlan *plan.Plan
+	// confirm is asked before the orphans of an environment are deleted, if neither plan nor appliedPlan is set.
+	confirm func(environment string, count int) bool
+}
+
+// pruneUnsupportedTypes returns the types of all documents and automations of the given projects. Their
+// external IDs are derived from a hash of the coordinate, so it is not possible to tell which project, and thus whether
+// a removed config, they belong to. Orphans of these types are not pruned.
+func pruneUnsupportedTypes(projects []project.Project) []string {
+	var unsupported []string
+	for _, p := range projects {
+		p.ForEveryConfigDo(func(c config.Config) {
+			if t := c.Type.ID(); t == config.DocumentTypeID || t == config.AutomationTypeID {
+				unsupported = append(unsupported, c.Coordinate.Type)
+			}
+		})
+	}
+
+	slices.Sort(unsupported)
+	return slices.Compact(unsupported)
+}
+
+// warnPruneUnsupportedTypes logs a warning if any of the given projects contains configs whose orphans are not pruned.
+func warnPruneUnsupportedTypes(ctx context.Context, projects []project.Project) {
+	if unsupported := pruneUnsupportedTypes(projects); len(unsupported) > 0 {
+		log.WarnContext(ctx, "Pruning is only supported for settings, orphaned objects of the following types are not deleted: %s", strings.Join(unsupported, ", "))
+	}
+}
+
+// pruneOrphans finds all objects in the given environments, which were deployed as part of one of the given
+// projects, but whose configuration was removed from the project since. The found objects are always listed first.
+// They are added to opts.plan if set, deleted if opts.appliedPlan plans to delete them, or deleted after opts.confirm
+// confirmed it otherwise.
+func pruneOrphans(ctx context.Context, projects []project.Project, clientSets dynatrace.EnvironmentClients, opts pruneOptions) error {
+	projectIDs := make([]string, len(projects))
+	for i, p := range projects {
+		projectIDs[i] = p.Id
+	}
+
+	var envsWithPruneErrs []string
+	for env, clientSet := range clientSets {
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+
+		var existing []coordinate.Coordinate
+		for _, p := range projects {
+			for _, cfgs := range p.Configs[env.Name] {
+				for _, c := range cfgs {
+					existing = append(existing, c.Coordinate)
+				}
+			}
+		}
+
+		orphans, err := delete.Orphans(ctx, *clientSet, projectIDs, existing)
+		if err != nil {
+			log.ErrorContext(ctx, "Failed to find orphaned configurations in environment %q: %v", env.Name, err)
+			envsWithPruneErrs = append(envsWithPruneErrs, env.Name)
+			continue
+		}
+
+		logOrphans(ctx, env.Name, orphans)
+
+		if opts.plan != nil {
+			planDeletions(opts.plan, env.Name, orphans)
+			continue
+		}
+
+		if opts.appliedPlan != nil {
+			orphans = approvedDeletions(ctx, opts.appliedPlan, env.Name, orphans)
+		} else if count := countOrphans(orphans); count > 0 && !opts.confirm(env.Name, count) {
+			log.InfoContext(ctx, "Orphaned configurations are not deleted from environment %q", env.Name)
+			continue
+		}
+
+		if countOrphans(orphans) == 0 {
+			continue
+		}
+
+		log.InfoContext(ctx, "Deleting orphaned configurations from environment %q...", env.Name)
+		if err := delete.Configs(ctx, *clientSet, orphans); err != nil {
+			log.ErrorContext(ctx, "Failed to delete all orphaned configurations from environment %q - check log for details", env.Name)
+			envsWithPruneErrs = append(envsWithPruneErrs, env.Name)
+		}
+	}
+
+	if len(envsWithPruneErrs) > 0 {
+		slices.Sort(envsWithPruneErrs)
+		return fmt.Errorf("encountered pruning errors for the following environments: %v", strings.Join(envsWithPruneErrs, ", "))
+	}
+	return nil
+}
+
+// planDeletions adds an ActionDelete entry for each of the given orphans to the given plan.
+func planDeletions(p *plan.Plan, environment string, orphans delete.DeleteEntries) {
+	for _, entries := range orphans {
+		for _, e := range entries {
+			p.Add(plan.Entry{Environment: environment, Coordinate: e.AsCoordinate(), Action: plan.ActionDelete})
+		}
+	}
+}
+
+// approvedDeletions returns the orphans the given plan plans to delete. All other orphans are logged and kept.
+func approvedDeletions(ctx context.Context, p *plan.Plan, environment string, orphans delete.DeleteEntries) delete.DeleteEntries {
+	approved := make(delete.DeleteEntries)
+	for t, entries := range orphans {
+		for _, e := range entries {
+			if planned, found := p.Entry(environment, e.AsCoordinate()); found && planned.Action == plan.ActionDelete {
+				approved[t] = append(approved[t], e)
+				continue
+			}
+			log.WarnContext(ctx, "Orphaned configuration %s is not deleted, as the applied plan does not delete it", e)
+		}
+	}
+	return approved
+}
+
+// confirmOnPrompt returns a confirmation function for pruneOrphans, which asks for confirmation on out and reads the
+// answer from in. Only 'y' or 'yes' confirm the deletion.
+func confirmOnPrompt(in io.Reader, out io.Writer) func(environment string, count int) bool {
+	reader := bufio.NewReader(in)
+	return func(environment string, count int) bool {
+		_, _ = fmt.Fprintf(out, "Delete %d orphaned configuration(s) from environment %q? [y/N]: ", count, environment)
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			_, _ = fmt.Fprintln(out)
+			return false
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "y" || answer == "yes"
+	}
+}
+
+func countOrphans(orphans delete.DeleteEntries) int {
+	count := 0
+	for _, entries := range orphans {
+		count += len(entries)
+	}
+	return count
+}
+
+func logOrphans(ctx context.Context, environment string, orphans delete.DeleteEntries) {
+	count := countOrphans(orphans)
+	if count == 0 {
+		log.InfoContext(ctx, "No orphaned configurations found in environment %q", environment)
+		return
+	}
+
+	log.InfoContext(ctx, "Found %d orphaned configuration(s) in environment %q:", count, environment)
+	for _, t := range slices.Sorted(maps.Keys(orphans)) {
+		for _, e := range orphans[t] {
+			log.InfoContext(ctx, "  - %s", e)
+		}
+	}
+}
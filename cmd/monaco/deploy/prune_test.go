@@ -0,0 +1,104 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func TestPruneUnsupportedTypes(t *testing.T) {
+	settingsProject := project.Project{Id: "p", Configs: project.ConfigsPerTypePerEnvironments{
+		"env": {"builtin:test": {{Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "s"}, Type: config.SettingsType{SchemaId: "builtin:test"}}}},
+	}}
+	assert.Empty(t, pruneUnsupportedTypes([]project.Project{settingsProject}))
+
+	mixedProject := project.Project{Id: "q", Configs: project.ConfigsPerTypePerEnvironments{
+		"env1": {
+			"document":     {{Coordinate: coordinate.Coordinate{Project: "q", Type: "document", ConfigId: "d"}, Type: config.DocumentType{}}},
+			"workflow":     {{Coordinate: coordinate.Coordinate{Project: "q", Type: "workflow", ConfigId: "w"}, Type: config.AutomationType{Resource: config.Workflow}}},
+			"builtin:test": {{Coordinate: coordinate.Coordinate{Project: "q", Type: "builtin:test", ConfigId: "s"}, Type: config.SettingsType{SchemaId: "builtin:test"}}},
+		},
+		"env2": {
+			"document": {{Coordinate: coordinate.Coordinate{Project: "q", Type: "document", ConfigId: "d"}, Type: config.DocumentType{}}},
+		},
+	}}
+	assert.Equal(t, []string{"document", "workflow"}, pruneUnsupportedTypes([]project.Project{settingsProject, mixedProject}))
+}
+
+func TestPlanDeletions(t *testing.T) {
+	p := plan.New()
+	planDeletions(p, "env", delete.DeleteEntries{
+		"builtin:test": {{Project: "p", Type: "builtin:test", Identifier: "removed"}},
+	})
+
+	assert.Equal(t, []plan.Entry{
+		{Environment: "env", Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "removed"}, Action: plan.ActionDelete},
+	}, p.Entries())
+}
+
+func TestApprovedDeletions(t *testing.T) {
+	approved := pointer.DeletePointer{Project: "p", Type: "builtin:test", Identifier: "approved"}
+	notPlanned := pointer.DeletePointer{Project: "p", Type: "builtin:test", Identifier: "not-planned"}
+	deployed := pointer.DeletePointer{Project: "p", Type: "builtin:test", Identifier: "deployed"}
+	otherEnvironment := pointer.DeletePointer{Project: "p", Type: "builtin:test", Identifier: "other-environment"}
+
+	p := plan.New()
+	p.Add(plan.Entry{Environment: "env", Coordinate: approved.AsCoordinate(), Action: plan.ActionDelete})
+	p.Add(plan.Entry{Environment: "env", Coordinate: deployed.AsCoordinate(), Action: plan.ActionUpdate})
+	p.Add(plan.Entry{Environment: "other", Coordinate: otherEnvironment.AsCoordinate(), Action: plan.ActionDelete})
+
+	result := approvedDeletions(t.Context(), p, "env", delete.DeleteEntries{
+		"builtin:test": {approved, notPlanned, deployed, otherEnvironment},
+	})
+
+	assert.Equal(t, delete.DeleteEntries{"builtin:test": {approved}}, result, "only orphans the plan deletes in the environment are deleted")
+}
+
+func TestConfirmOnPrompt(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []bool
+	}{
+		{input: "y\n", expected: []bool{true, false}},
+		{input: "Yes\nno\n", expected: []bool{true, false}},
+		{input: "\ny", expected: []bool{false, true}},
+		{input: "", expected: []bool{false, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			out := &strings.Builder{}
+			confirm := confirmOnPrompt(strings.NewReader(tt.input), out)
+
+			assert.Equal(t, tt.expected[0], confirm("env1", 2))
+			assert.Equal(t, tt.expected[1], confirm("env2", 1))
+			assert.Contains(t, out.String(), `Delete 2 orphaned configuration(s) from environment "env1"? [y/N]: `)
+			assert.Contains(t, out.String(), `Delete 1 orphaned configuration(s) from environment "env2"? [y/N]: `)
+		})
+	}
+}
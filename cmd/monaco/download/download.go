@@ -51,11 +51,12 @@ var (
 )
 
 type downloadOptionsShared struct {
-	environmentURL         manifest.URLDefinition
-	auth                   manifest.Auth
-	outputFolder           string
-	projectName            string
-	forceOverwriteManifest bool
+	environmentURL          manifest.URLDefinition
+	auth                    manifest.Auth
+	outputFolder            string
+	projectName             string
+	forceOverwriteManifest  bool
+	extractSharedParameters bool
 }
 
 func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptionsShared, fs afero.Fs) error {
@@ -67,6 +68,8 @@ func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptions
 		Auth:           opts.auth,
 		OutputFolder:   opts.outputFolder,
 		ForceOverwrite: opts.forceOverwriteManifest,
+
+		ExtractSharedParameters: opts.extractSharedParameters,
 	}
 	err := download.WriteToDisk(fs, downloadWriterContext)
 	if err != nil {
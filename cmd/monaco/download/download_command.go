@@ -30,26 +30,27 @@ import (
 type OnlyFlag = string
 
 const (
-	EnvironmentFlag               = "environment"
-	UrlFlag                       = "url"
-	ManifestFlag                  = "manifest"
-	ApiTokenFlag                  = "token"
-	PlatformTokenFlag             = "platform-token"
-	OAuthIdFlag                   = "oauth-client-id"
-	OAuthSecretFlag               = "oauth-client-secret"
-	ApiFlag                       = "api"
-	SettingsSchemaFlag            = "settings-schema"
-	ProjectFlag                   = "project"
-	OutputFolderFlag              = "output-folder"
-	ForceFlag                     = "force"
-	OnlyApisFlag         OnlyFlag = "only-apis"
-	OnlySettingsFlag     OnlyFlag = "only-settings"
-	OnlyAutomationFlag   OnlyFlag = "only-automation"
-	OnlyDocumentsFlag    OnlyFlag = "only-documents"
-	OnlyBucketsFlag      OnlyFlag = "only-buckets"
-	OnlyOpenPipelineFlag OnlyFlag = "only-openpipeline"
-	OnlySloV2Flag        OnlyFlag = "only-slo-v2"
-	OnlySegmentsFlag     OnlyFlag = "only-segments"
+	EnvironmentFlag                      = "environment"
+	UrlFlag                              = "url"
+	ManifestFlag                         = "manifest"
+	ApiTokenFlag                         = "token"
+	PlatformTokenFlag                    = "platform-token"
+	OAuthIdFlag                          = "oauth-client-id"
+	OAuthSecretFlag                      = "oauth-client-secret"
+	ApiFlag                              = "api"
+	SettingsSchemaFlag                   = "settings-schema"
+	ProjectFlag                          = "project"
+	OutputFolderFlag                     = "output-folder"
+	ForceFlag                            = "force"
+	ExtractSharedParametersFlag          = "extract-shared-parameters"
+	OnlyApisFlag                OnlyFlag = "only-apis"
+	OnlySettingsFlag            OnlyFlag = "only-settings"
+	OnlyAutomationFlag          OnlyFlag = "only-automation"
+	OnlyDocumentsFlag           OnlyFlag = "only-documents"
+	OnlyBucketsFlag             OnlyFlag = "only-buckets"
+	OnlyOpenPipelineFlag        OnlyFlag = "only-openpipeline"
+	OnlySloV2Flag               OnlyFlag = "only-slo-v2"
+	OnlySegmentsFlag            OnlyFlag = "only-segments"
 )
 
 func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
@@ -123,6 +124,7 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	}
 
 	// download options
+	cmd.Flags().BoolVar(&f.extractSharedParameters, ExtractSharedParametersFlag, false, "Write parameters which all downloaded configurations define with identical values to the project's '_parameters.yaml' instead of repeating them in every configuration.")
 	cmd.Flags().StringSliceVarP(&f.specificAPIs, ApiFlag, "a", nil, "Download one or more classic configuration APIs, including deprecated ones. (Repeat flag or use comma-separated values)")
 	cmd.Flags().StringSliceVarP(&f.specificSchemas, SettingsSchemaFlag, "s", nil, "Download settings 2.0 objects of one or more settings 2.0 schemas. (Repeat flag or use comma-separated values)")
 	cmd.Flags().BoolVar(&onlyApis, OnlyApisFlag, false, "Download only classic configuration APIs. Deprecated configuration APIs will not be included.")
@@ -56,6 +56,7 @@ type downloadCmdOptions struct {
 	specificAPIs            []string
 	specificSchemas         []string
 	onlyOptions             OnlyOptions
+	extractSharedParameters bool
 }
 
 func (d downloadCmdOptions) toDownloadConfigsOptions(url manifest.URLDefinition, auth manifest.Auth) downloadConfigsOptions {
@@ -66,6 +67,8 @@ func (d downloadCmdOptions) toDownloadConfigsOptions(url manifest.URLDefinition,
 			outputFolder:           d.outputFolder,
 			projectName:            d.projectName,
 			forceOverwriteManifest: d.forceOverwrite,
+
+			extractSharedParameters: d.extractSharedParameters,
 		},
 		specificAPIs:    d.specificAPIs,
 		specificSchemas: d.specificSchemas,
@@ -107,7 +110,7 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 		return err
 	}
 
-	clientSet, err := client.CreateClientSet(ctx, options.environmentURL.Value, options.auth)
+	clientSet, err := client.CreateClientSetWithOptions(ctx, options.environmentURL.Value, options.auth, client.ClientOptions{Connection: env.Connection})
 	if err != nil {
 		return err
 	}
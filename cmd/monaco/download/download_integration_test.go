@@ -48,7 +48,7 @@ import (
 )
 
 // compareOptions holds all options we require for the tests to not be flaky.
-// E.g. slices may be in any order, template may have any implementation.
+// E.g. slices may be in any order, template may have any implementation, and configs may be written to any file.
 // We want to be pragmatic in comparing them - so we define these options to make it very simple.
 var compareOptions = []cmp.Option{
 	cmp.Comparer(func(a, b template.Template) bool {
@@ -62,6 +62,7 @@ var compareOptions = []cmp.Option{
 	cmpopts.SortSlices(func(a, b coordinate.Coordinate) bool {
 		return strings.Compare(a.String(), b.String()) < 0
 	}),
+	cmpopts.IgnoreFields(config.Config{}, "SourceFile"),
 }
 
 func TestDownloadIntegrationSimple(t *testing.T) {
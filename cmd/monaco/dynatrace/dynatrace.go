@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api/clients/accounts"
@@ -34,6 +35,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/apitoken"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/classicheartbeat"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/connection"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 
@@ -59,18 +61,22 @@ func VerifyEnvironmentAuthentication(ctx context.Context, env manifest.Environme
 		return ErrorMissingAuth
 	}
 
+	httpClient, err := connection.NewHTTPClient(env.Connection)
+	if err != nil {
+		return fmt.Errorf("could not connect to environment '%s' (%s): %w", env.Name, env.URL.Value, err)
+	}
+
 	classicUrl := env.URL.Value
 
 	// check if the platform connection works and get the classicURL in order to check the API token authentication next if given
 	if env.HasPlatformCredentials() {
-		var err error
-		if classicUrl, err = getDynatraceClassicURL(ctx, env.URL.Value, env.Auth.OAuth, env.Auth.PlatformToken); err != nil {
+		if classicUrl, err = getDynatraceClassicURL(ctx, env.URL.Value, env.Auth.OAuth, env.Auth.PlatformToken, env.Connection, httpClient); err != nil {
 			return fmt.Errorf("could not authorize against environment '%s' (%s) using platform credentials: %w", env.Name, env.URL.Value, err)
 		}
 	}
 
 	if env.Auth.ApiToken != nil {
-		if err := checkClassicConnection(ctx, classicUrl, env.Auth.ApiToken.Value.Value()); err != nil {
+		if err := checkClassicConnection(ctx, classicUrl, env.Auth.ApiToken.Value.Value(), env.Connection, httpClient); err != nil {
 			return fmt.Errorf("could not authorize against environment '%s' (%s) using API token authorization: %w", env.Name, classicUrl, err)
 		}
 	}
@@ -78,25 +84,17 @@ func VerifyEnvironmentAuthentication(ctx context.Context, env manifest.Environme
 }
 
 // checkClassicConnection checks if a classic connection (via API token) can be established. Scopes are not validated.
-func checkClassicConnection(ctx context.Context, classicURL string, apiToken string) error {
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
-	factory := clients.Factory().
-		WithClassicURL(classicURL).
-		WithAccessToken(apiToken).
-		WithRateLimiter(true).
-		WithRetryOptions(&client.DefaultRetryOptions).
-		WithCustomHeaders(additionalHeaders)
-
-	if supportarchive.IsEnabled(ctx) {
-		factory = factory.WithHTTPListener(&corerest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
-	}
-
-	client, err := factory.CreateClassicClient()
+func checkClassicConnection(ctx context.Context, classicURL string, apiToken string, c manifest.Connection, httpClient *http.Client) error {
+	classicClient, err := client.NewClassicClient(ctx, classicURL, apiToken, httpClient, client.ClassicClientOptions{
+		Headers:      connection.Headers(c),
+		RetryOptions: &client.DefaultRetryOptions,
+		RateLimiter:  true,
+	})
 	if err != nil {
 		return fmt.Errorf("could not create client: %w", err)
 	}
 
-	_, err = apitoken.GetApiTokenMetadata(ctx, client, apiToken)
+	_, err = apitoken.GetApiTokenMetadata(ctx, classicClient, apiToken)
 	return err
 }
 
@@ -178,7 +176,7 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 			continue
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Connection: env.Connection})
 		if err != nil {
 			return EnvironmentClients{}, err
 		}
@@ -193,17 +191,16 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 }
 
 // getDynatraceClassicURL transforms the platformURL to a classic URL either via string replacing or API call, depending on if the BuildSimpleClassicURL FF is enabled (default) or not
-func getDynatraceClassicURL(ctx context.Context, platformURL string, oauth *manifest.OAuth, platformToken *manifest.AuthSecret) (string, error) {
+func getDynatraceClassicURL(ctx context.Context, platformURL string, oauth *manifest.OAuth, platformToken *manifest.AuthSecret, c manifest.Connection, httpClient *http.Client) (string, error) {
 	if featureflags.BuildSimpleClassicURL.Enabled() {
-		if classicURL, ok := findSimpleClassicURL(ctx, platformURL); ok {
+		if classicURL, ok := findSimpleClassicURL(ctx, platformURL, c, httpClient); ok {
 			return classicURL, nil
 		}
 	}
 
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
 	factory := clients.Factory().
 		WithPlatformURL(platformURL).
-		WithCustomHeaders(additionalHeaders)
+		WithCustomHeaders(connection.Headers(c))
 	if platformToken != nil {
 		factory = factory.WithPlatformToken(platformToken.Value.Value())
 	}
@@ -217,28 +214,27 @@ func getDynatraceClassicURL(ctx context.Context, platformURL string, oauth *mani
 	if supportarchive.IsEnabled(ctx) {
 		factory = factory.WithHTTPListener(&corerest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
 	}
-	client, err := factory.CreatePlatformClient(ctx)
+	client, err := factory.CreatePlatformClient(connection.ContextWithHTTPClient(ctx, httpClient))
 	if err != nil {
 		return "", fmt.Errorf("could not create client: %w", err)
 	}
 	return metadata.GetDynatraceClassicURL(ctx, *client)
 }
 
-func findSimpleClassicURL(ctx context.Context, platformURL string) (classicUrl string, ok bool) {
+func findSimpleClassicURL(ctx context.Context, platformURL string, c manifest.Connection, httpClient *http.Client) (classicUrl string, ok bool) {
 	if !strings.Contains(platformURL, ".apps.") {
 		log.DebugContext(ctx, "Environment URL not matching expected Platform URL pattern, unable to build Classic environment URL directly.")
 		return "", false
 	}
 
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
 	classicUrl = strings.Replace(platformURL, ".apps.", ".live.", 1)
 
-	client, err := clients.Factory().WithClassicURL(classicUrl).WithCustomHeaders(additionalHeaders).CreateClassicClient()
+	classicClient, err := client.NewClassicClient(ctx, classicUrl, "", httpClient, client.ClassicClientOptions{Headers: connection.Headers(c)})
 	if err != nil {
 		return "", false
 	}
 
-	if classicheartbeat.TestClassic(ctx, *client) {
+	if classicheartbeat.TestClassic(ctx, *classicClient) {
 		log.DebugContext(ctx, "Found classic environment URL based on Platform URL: %s", classicUrl)
 		return classicUrl, true
 	}
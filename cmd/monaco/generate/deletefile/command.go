@@ -19,6 +19,7 @@ package deletefile
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -30,13 +31,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
 func Command(fs afero.Fs) (cmd *cobra.Command) {
 
-	var fileName, outputFolder string
+	var fileName, outputFolder, labelSelector string
 	var projects, environments []string
 	var includeTypes, excludeTypes []string
 
@@ -56,9 +58,15 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 				return err
 			}
 
+			selector, err := manifest.ParseLabelSelector(labelSelector)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--selector': %w", err)
+			}
+
 			m, errs := manifestloader.Load(&manifestloader.Context{
 				Fs:           fs,
 				ManifestPath: manifestName,
+				Selector:     selector,
 				Opts: manifestloader.Options{
 					DoNotResolveEnvVars:      true,
 					RequireEnvironmentGroups: true,
@@ -69,6 +77,13 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 				return fmt.Errorf("failed to load manifest %q", manifestName)
 			}
 
+			if !selector.IsEmpty() {
+				projects = selectedProjects(m.Projects, projects, selector.SelectedProjects(m))
+				if len(projects) == 0 {
+					return fmt.Errorf("no project matches selector %q", selector)
+				}
+			}
+
 			apis := api.NewAPIs().Filter(api.RemoveDisabled)
 			loadedProjects, errs := project.LoadProjects(cmd.Context(), fs, project.ProjectLoaderContext{
 				KnownApis:       apis.GetApiNameLookup(),
@@ -102,6 +117,9 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&fileName, "file", "", "delete.yaml", "The name of the generated delete file. If a file of this name already exists, a timestamp will be appended.")
 
 	cmd.Flags().StringSliceVarP(&projects, "project", "p", nil, "Projects to generate delete file entries for. If not defined, all projects in the manifest will be used.")
+	cmd.Flags().StringVar(&labelSelector, "selector", "", "Generate delete file entries only for projects whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+		"The selector is matched against the labels of a project merged with the labels of any environment, where project labels take precedence. "+
+		"If combined with '--project', only projects matching both are used.")
 	cmd.Flags().StringSliceVar(&excludeTypes, "exclude-types", nil, "Comma-separated list of config types to be excluded from the generation process.")
 	cmd.Flags().StringSliceVar(&includeTypes, "types", nil, "Comma-separated list of config types to be included in the generation process.")
 
@@ -114,3 +132,18 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 
 	return cmd
 }
+
+// selectedProjects returns the projects selected by a label selector, restricted to the requested projects or
+// grouping projects if any.
+func selectedProjects(definitions manifest.ProjectDefinitionByProjectID, requested []string, selected []string) []string {
+	if len(requested) == 0 {
+		return selected
+	}
+	var result []string
+	for _, p := range selected {
+		if slices.Contains(requested, p) || slices.Contains(requested, definitions[p].Group) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
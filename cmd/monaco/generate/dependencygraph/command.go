@@ -27,6 +27,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
 const jsonEncoding = "json"
@@ -36,6 +37,7 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 	var environments, groups []string
 	var outputFolder string
 	var idEncoding string
+	var labelSelector string
 
 	cmd = &cobra.Command{
 		Use:               "graph <manifest.yaml>",
@@ -53,9 +55,14 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 				return err
 			}
 
+			selector, err := manifest.ParseLabelSelector(labelSelector)
+			if err != nil {
+				return fmt.Errorf("invalid value for '--selector': %w", err)
+			}
+
 			writeJSONIDs := idEncoding == jsonEncoding
 
-			err := writeGraphFiles(cmd.Context(), fs, manifestName, environments, groups, outputFolder, writeJSONIDs)
+			err = writeGraphFiles(cmd.Context(), fs, manifestName, environments, groups, selector, outputFolder, writeJSONIDs)
 			if err != nil {
 				log.WithFields(field.Error(err), field.F("manifestFile", manifestName), field.F("outputFolder", outputFolder)).Error("Failed to create dependency graph files: %v", err)
 			}
@@ -76,6 +83,11 @@ func Command(fs afero.Fs) (cmd *cobra.Command) {
 			"If this flag is specified, a dependency graph will be generated for each specified environment. "+
 			"If neither --groups nor --environment is present, all environments are used.")
 
+	cmd.Flags().StringVar(&labelSelector, "selector", "",
+		"Generate dependency graphs only for environments and projects whose labels match the given selector, e.g. 'tier=prod,region in (eu,us)'. "+
+			"The selector is matched against the labels of an environment merged with the labels of a project, which take precedence. "+
+			"If combined with '--environment' or '--group', only environments matching both are used.")
+
 	cmd.Flags().StringVarP(&outputFolder, "output-folder", "o", "", "The folder generated dependency graph DOT files should be written to. If not set, files will be created in the current directory.")
 
 	cmd.Flags().StringVar(&idEncoding, "id-encoding", "default", "Set to 'json' to generate a DOT file encoding each node's coordinate as JSON, instead of the 'default' string representation. JSON encoding can be useful when processing generated DOT files automatically.")
@@ -31,7 +31,9 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
@@ -53,13 +55,14 @@ func (e ExportError) Error() string {
 	return fmt.Sprintf("%s: %v", e.message, e.Reason)
 }
 
-func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, outputFolder string, writeJSONIDs bool) error {
+func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, environmentNames []string, environmentGroups []string, selector manifest.LabelSelector, outputFolder string, writeJSONIDs bool) error {
 
 	m, errs := manifestloader.Load(&manifestloader.Context{
 		Fs:           fs,
 		ManifestPath: manifestPath,
 		Environments: environmentNames,
 		Groups:       environmentGroups,
+		Selector:     selector,
 		Opts: manifestloader.Options{
 			DoNotResolveEnvVars:      true,
 			RequireEnvironmentGroups: true,
@@ -74,12 +77,18 @@ func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, envi
 		}
 	}
 
+	// projects depended on by the selected projects are loaded as well
+	var specificProjects []string
+	if !selector.IsEmpty() {
+		specificProjects = selector.SelectedProjects(m)
+	}
+
 	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
 		KnownApis:       api.NewAPIs().GetApiNameLookup(),
 		WorkingDir:      filepath.Dir(manifestPath),
 		Manifest:        m,
 		ParametersSerde: config.DefaultParameterParsers,
-	}, nil)
+	}, specificProjects)
 
 	if len(errs) > 0 {
 		errutils.PrintErrors(errs)
@@ -106,6 +115,21 @@ func writeGraphFiles(ctx context.Context, fs afero.Fs, manifestPath string, envi
 
 	graphs := graph.New(projects, m.Environments.SelectedEnvironments.Names(), opts...)
 
+	// like when deploying, only configs matching the selector in their environment, and their dependencies, are included
+	if !selector.IsEmpty() {
+		labelSelector := deploy.NewLabelSelector(selector, m.Environments.SelectedEnvironments, m.Projects)
+		for _, e := range m.Environments.SelectedEnvironments.Names() {
+			if err := graphs.SelectWithDependencies(e, func(c config.Config) bool { return labelSelector(e, c) }); err != nil {
+				return ExportError{
+					ManifestFile: manifestPath,
+					Environment:  e,
+					message:      fmt.Sprintf("failed to select configs for environment %q", e),
+					Reason:       err,
+				}
+			}
+		}
+	}
+
 	folderPath, err := filepath.Abs(outputFolder)
 	if err != nil {
 		return ExportError{
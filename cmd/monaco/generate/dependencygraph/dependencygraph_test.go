@@ -238,6 +238,39 @@ func TestGeneratesDOTFileForProjectsWithCyclicDependencies(t *testing.T) {
 	assertCreatedDOTGraph(t, fs, f1, expectedGraph)
 }
 
+func TestGeneratesDOTFilesOfConfigsMatchingSelectorPerEnvironment(t *testing.T) {
+
+	t.Setenv("TOKEN", "some-value")
+
+	fs := testutils.CreateTestFileSystem()
+
+	outputFolder := "output-folder"
+
+	cmd := dependencygraph.Command(fs)
+
+	cmd.SetArgs([]string{
+		"./test-resources/manifest_with_labels.yaml",
+		"--selector", "tier=prod",
+		"-o",
+		outputFolder,
+	})
+	err := cmd.Execute()
+	assert.NoError(t, err)
+
+	// in env1, only the labels of the cycles project match the selector
+	f1 := filepath.Join(outputFolder, "dependency_graph_env1.dot")
+	assertFileExists(t, fs, f1)
+	assertCreatedDOTGraph(t, fs, f1, map[string][]string{"cycles:dashboard:dashboard": {"cycles:reports:report"}})
+	assertDOTGraphDoesNotContain(t, fs, f1, "project:reports:report")
+
+	f2 := filepath.Join(outputFolder, "dependency_graph_env2.dot")
+	assertFileExists(t, fs, f2)
+	assertCreatedDOTGraph(t, fs, f2, map[string][]string{
+		"cycles:dashboard:dashboard":  {"cycles:reports:report"},
+		"project:dashboard:dashboard": {"project:reports:report"},
+	})
+}
+
 func assertFileExists(t *testing.T, fs afero.Fs, file string) {
 	path, err := filepath.Abs(file)
 	require.NoError(t, err)
@@ -267,3 +300,12 @@ func assertCreatedDOTGraph(t *testing.T, fs afero.Fs, file string, expectedGraph
 		}
 	}
 }
+
+func assertDOTGraphDoesNotContain(t *testing.T, fs afero.Fs, file string, node string) {
+	path, err := filepath.Abs(file)
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), fmt.Sprintf("%q", node))
+}
@@ -0,0 +1,92 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+)
+
+func GetRollbackCommand(fs afero.Fs) (rollbackCmd *cobra.Command) {
+	var environments, groups []string
+	var manifestName string
+	var snapshotDir string
+
+	rollbackCmd = &cobra.Command{
+		Use:     "rollback --manifest <manifest.yaml> --snapshot <dir>",
+		Short:   "Roll back a deployment using the snapshot it saved with 'monaco deploy --snapshot'",
+		Example: "monaco rollback --manifest manifest.yaml --snapshot snapshot --environment dev-environment",
+		Args:    cobra.NoArgs,
+		PreRun:  cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			if exists, err := afero.DirExists(fs, snapshotDir); err != nil || !exists {
+				return fmt.Errorf("snapshot directory %q does not exist", snapshotDir)
+			}
+
+			absManifestFilePath, err := filepath.Abs(filepath.Clean(manifestName))
+			if err != nil {
+				return err
+			}
+
+			manifest, errs := manifestloader.Load(&manifestloader.Context{
+				Fs:           fs,
+				ManifestPath: absManifestFilePath,
+				Environments: environments,
+				Groups:       groups,
+				Opts:         manifestloader.Options{RequireEnvironmentGroups: true},
+			})
+			if len(errs) > 0 {
+				errutils.PrintErrors(errs)
+				return errors.New("error while loading manifest")
+			}
+
+			return Rollback(cmd.Context(), manifest.Environments.SelectedEnvironments, snapshot.NewStore(fs, snapshotDir))
+		},
+	}
+
+	rollbackCmd.Flags().StringVarP(&manifestName, "manifest", "m", "manifest.yaml", "The manifest defining the environments to roll back. (default: 'manifest.yaml' in the current folder)")
+	rollbackCmd.Flags().StringVar(&snapshotDir, "snapshot", "", "The snapshot directory written by 'monaco deploy --snapshot'.")
+	rollbackCmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) that should be rolled back. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--environment'. "+
+			"If neither --groups nor --environment is present, all environments contained in the snapshot will be rolled back")
+	rollbackCmd.Flags().StringSliceVarP(&environments, "environment", "e", []string{},
+		"Specify one (or multiple) environments(s) that should be rolled back. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'. "+
+			"If neither --groups nor --environment is present, all environments contained in the snapshot will be rolled back")
+
+	rollbackCmd.MarkFlagsMutuallyExclusive("environment", "group")
+	_ = rollbackCmd.MarkFlagRequired("snapshot")
+
+	return rollbackCmd
+}
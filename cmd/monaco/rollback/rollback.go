@@ -0,0 +1,65 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// Rollback restores the state recorded in the given snapshot store for each of the given environments.
+// Environments without any recorded state are skipped.
+func Rollback(ctx context.Context, environments manifest.EnvironmentDefinitionsByName, store *snapshot.Store) error {
+	var envsWithRollbackErrs []string
+	for _, env := range environments {
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+
+		entries, err := store.Load(env.Name)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			log.InfoContext(ctx, "Snapshot contains no configurations for environment %q", env.Name)
+			continue
+		}
+
+		clientSet, err := client.CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, client.ClientOptions{Connection: env.Connection})
+		if err != nil {
+			return fmt.Errorf("failed to create API client for environment %q due to the following error: %w", env.Name, err)
+		}
+
+		log.InfoContext(ctx, "Rolling back %d configurations of environment %q...", len(entries), env.Name)
+		if err := deploy.Rollback(ctx, clientSet, entries); err != nil {
+			log.ErrorContext(ctx, "Failed to roll back all configurations of environment %q - check log for details", env.Name)
+			envsWithRollbackErrs = append(envsWithRollbackErrs, env.Name)
+		}
+	}
+
+	if len(envsWithRollbackErrs) > 0 {
+		slices.Sort(envsWithRollbackErrs)
+		return fmt.Errorf("encountered rollback errors for the following environments: %v", strings.Join(envsWithRollbackErrs, ", "))
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/rollback"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
 	versionCommand "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
@@ -118,6 +119,7 @@ Examples:
 	rootCmd.AddCommand(download.GetDownloadCommand(fs, &download.DefaultCommand{}))
 	rootCmd.AddCommand(deploy.GetDeployCommand(fs))
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
+	rootCmd.AddCommand(rollback.GetRollbackCommand(fs))
 	rootCmd.AddCommand(versionCommand.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
 
@@ -0,0 +1,76 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package git provides access to a local git repository using the git binary. It never contacts a remote.
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChangedFiles returns the absolute paths of all files in the git repository containing dir, which differ between the
+// given revision and the working tree. This includes added, modified, deleted and untracked files. Renamed files are
+// returned with both their old and new path.
+func ChangedFiles(ctx context.Context, dir string, revision string) ([]string, error) {
+	topLevel, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("%q is not within a git repository: %w", dir, err)
+	}
+	topLevel = strings.TrimSpace(topLevel)
+
+	if _, err := run(ctx, topLevel, "rev-parse", "--verify", "--quiet", revision+"^{commit}"); err != nil {
+		return nil, fmt.Errorf("unknown git revision %q: %w", revision, err)
+	}
+
+	changed, err := run(ctx, topLevel, "diff", "--name-only", "--no-renames", "-z", revision, "--")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files changed since %q: %w", revision, err)
+	}
+
+	untracked, err := run(ctx, topLevel, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var files []string
+	for _, p := range strings.Split(changed+untracked, "\x00") {
+		if p != "" {
+			files = append(files, filepath.Join(topLevel, filepath.FromSlash(p)))
+		}
+	}
+	return files, nil
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, bytes.TrimSpace(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/git"
+)
+
+func TestChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not available")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	runGit := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	writeFile := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	runGit("init", "--quiet")
+	writeFile("project/config.yaml", "configs: []")
+	writeFile("project/unchanged.json", "{}")
+	writeFile("project/removed.json", "{}")
+	runGit("add", "--all")
+	runGit("commit", "--quiet", "--message", "initial")
+	runGit("tag", "base")
+
+	writeFile("project/config.yaml", "configs: [{}]")
+	require.NoError(t, os.Remove(filepath.Join(dir, "project", "removed.json")))
+	writeFile("project/new.json", "{}")
+
+	t.Run("returns modified, deleted and untracked files", func(t *testing.T) {
+		got, err := git.ChangedFiles(t.Context(), filepath.Join(dir, "project"), "base")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(dir, "project", "config.yaml"),
+			filepath.Join(dir, "project", "removed.json"),
+			filepath.Join(dir, "project", "new.json"),
+		}, got)
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		_, err := git.ChangedFiles(t.Context(), dir, "does-not-exist")
+		assert.ErrorContains(t, err, "does-not-exist")
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		_, err := git.ChangedFiles(t.Context(), t.TempDir(), "base")
+		assert.Error(t, err)
+	})
+}
@@ -19,6 +19,7 @@ package idutils
 import (
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 )
@@ -50,6 +51,40 @@ func GenerateExternalIDForSettingsObject(c coordinate.Coordinate) (string, error
 	return fmt.Sprintf("%s%s", prefix, encodedID), nil
 }
 
+// ParseExternalIDForSettingsObject returns the [[coordinate.Coordinate]] a Settings 2.0 external ID was generated
+// for by GenerateExternalIDForSettingsObject. If the given string is no such external ID, or the coordinate can not be
+// restored from it, e.g. because it was shortened, false is returned.
+func ParseExternalIDForSettingsObject(externalID string) (coordinate.Coordinate, bool) {
+	const prefix = "monaco:"
+
+	encodedID, found := strings.CutPrefix(externalID, prefix)
+	if !found {
+		return coordinate.Coordinate{}, false
+	}
+
+	decodedID, err := base64.StdEncoding.DecodeString(encodedID)
+	if err != nil {
+		return coordinate.Coordinate{}, false
+	}
+
+	var c coordinate.Coordinate
+	parts := strings.SplitN(string(decodedID), "$", 3)
+	switch len(parts) {
+	case 2:
+		c = coordinate.Coordinate{Type: parts[0], ConfigId: parts[1]}
+	case 3:
+		c = coordinate.Coordinate{Project: parts[0], Type: parts[1], ConfigId: parts[2]}
+	default:
+		return coordinate.Coordinate{}, false
+	}
+
+	// ensure that the external ID is generated from the parsed coordinate, which is not the case if it was shortened
+	if generated, err := GenerateExternalIDForSettingsObject(c); err != nil || generated != externalID {
+		return coordinate.Coordinate{}, false
+	}
+	return c, true
+}
+
 type ExternalIDGenerator func(coordinate.Coordinate) (string, error)
 
 // GenerateExternalID generates an external ID for a configuration. It is under 50 characters long and uses at most only "a-z", "A-Z", "0-9" and "-".
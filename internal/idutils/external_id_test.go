@@ -152,3 +152,40 @@ func TestGenerateExternalID(t *testing.T) {
 		assert.True(t, strings.HasPrefix(id, "monaco-"))
 	})
 }
+
+func TestParseExternalIDForSettingsObject(t *testing.T) {
+	t.Run("restores coordinate with project", func(t *testing.T) {
+		c := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "my$config"}
+		externalID, err := idutils.GenerateExternalIDForSettingsObject(c)
+		assert.NoError(t, err)
+
+		got, ok := idutils.ParseExternalIDForSettingsObject(externalID)
+		assert.True(t, ok)
+		assert.Equal(t, c, got)
+	})
+
+	t.Run("restores coordinate without project", func(t *testing.T) {
+		c := coordinate.Coordinate{Type: "builtin:alerting.profile", ConfigId: "config"}
+		externalID, err := idutils.GenerateExternalIDForSettingsObject(c)
+		assert.NoError(t, err)
+
+		got, ok := idutils.ParseExternalIDForSettingsObject(externalID)
+		assert.True(t, ok)
+		assert.Equal(t, c, got)
+	})
+
+	t.Run("rejects shortened external IDs", func(t *testing.T) {
+		externalID, err := idutils.GenerateExternalIDForSettingsObject(coordinate.Coordinate{Project: "project", Type: "schema", ConfigId: strings.Repeat("a", 500)})
+		assert.NoError(t, err)
+
+		_, ok := idutils.ParseExternalIDForSettingsObject(externalID)
+		assert.False(t, ok)
+	})
+
+	t.Run("rejects foreign external IDs", func(t *testing.T) {
+		for _, externalID := range []string{"", "my-external-id", "monaco:not-base64!", "monaco:" + base64.StdEncoding.EncodeToString([]byte("no-separator")), idutils.GenerateExternalID(coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"})} {
+			_, ok := idutils.ParseExternalIDForSettingsObject(externalID)
+			assert.False(t, ok, externalID)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandTimeout is the maximum duration a helper command may take to print a secret.
+const CommandTimeout = 30 * time.Second
+
+// Command is a helper command printing a secret to stdout, e.g. a call to the CLI of a password manager.
+type Command struct {
+	// Args are the program and its arguments.
+	Args []string
+	// Dir is the directory the command is run in.
+	Dir string
+	// Env are additional environment variables of the form `NAME=value`.
+	Env []string
+}
+
+func (c Command) key() string {
+	return strings.Join(append(append([]string{c.Dir}, c.Env...), c.Args...), "\x00")
+}
+
+// commandResults caches the output of each Command, as many configs and environments usually request the same secret.
+var commandResults = &commandCache{}
+
+type commandCache struct {
+	mutex   sync.Mutex
+	entries map[string]*commandCacheEntry
+}
+
+type commandCacheEntry struct {
+	mutex  sync.Mutex
+	done   bool
+	output string
+}
+
+// RunCommand runs the given Command and returns what it printed to stdout without the trailing newline. The command
+// is canceled after CommandTimeout. Each Command is only run once, later calls return the cached output, while
+// failing commands are run again.
+//
+// The returned error never contains what the command printed to stdout, as it may contain parts of the secret.
+func RunCommand(ctx context.Context, c Command) (string, error) {
+	return commandResults.run(ctx, c, CommandTimeout)
+}
+
+func (cc *commandCache) run(ctx context.Context, c Command, timeout time.Duration) (string, error) {
+	cc.mutex.Lock()
+	if cc.entries == nil {
+		cc.entries = make(map[string]*commandCacheEntry)
+	}
+	entry, found := cc.entries[c.key()]
+	if !found {
+		entry = &commandCacheEntry{}
+		cc.entries[c.key()] = entry
+	}
+	cc.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.done {
+		return entry.output, nil
+	}
+	output, err := runCommand(ctx, c, timeout)
+	if err != nil {
+		return "", err
+	}
+	entry.output, entry.done = output, true
+	return output, nil
+}
+
+func runCommand(ctx context.Context, c Command, timeout time.Duration) (string, error) {
+	if len(c.Args) == 0 || c.Args[0] == "" {
+		return "", errors.New("no command given or empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Args[0], c.Args[1:]...) // #nosec G204 -- the command is defined by the user on purpose
+	cmd.Dir = c.Dir
+	cmd.Env = append(os.Environ(), c.Env...)
+	// processes started by the command might keep its output open after it was killed
+	cmd.WaitDelay = time.Second
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", fmt.Errorf("command %q did not finish within %s", c.Args[0], timeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("command %q failed: %w: %s", c.Args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(out), "\n"), "\r"), nil
+}
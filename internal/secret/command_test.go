@@ -0,0 +1,77 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper commands in this test require a POSIX shell")
+	}
+
+	t.Run("returns output without trailing newline", func(t *testing.T) {
+		var cache commandCache
+		got, err := cache.run(context.TODO(), Command{Args: []string{"sh", "-c", `echo "value-of-$NAME"`}, Env: []string{"NAME=api-key"}}, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "value-of-api-key", got)
+	})
+
+	t.Run("runs each command only once", func(t *testing.T) {
+		counter := t.TempDir() + "/counter"
+		c := Command{Args: []string{"sh", "-c", "echo x >> " + counter + "; wc -l < " + counter}}
+
+		var cache commandCache
+		for range 2 {
+			got, err := cache.run(context.TODO(), c, time.Second)
+			require.NoError(t, err)
+			assert.Equal(t, "1", strings.TrimSpace(got))
+		}
+
+		got, err := cache.run(context.TODO(), Command{Args: c.Args, Env: []string{"OTHER=1"}}, time.Second)
+		require.NoError(t, err)
+		assert.Equal(t, "2", strings.TrimSpace(got), "commands with other environment variables are run on their own")
+	})
+
+	t.Run("returns error without output if command fails", func(t *testing.T) {
+		var cache commandCache
+		_, err := cache.run(context.TODO(), Command{Args: []string{"sh", "-c", "echo s3cr3t; echo denied >&2; exit 1"}}, time.Second)
+		assert.ErrorContains(t, err, "denied")
+		assert.NotContains(t, err.Error(), "s3cr3t")
+	})
+
+	t.Run("returns error if command times out", func(t *testing.T) {
+		var cache commandCache
+		_, err := cache.run(context.TODO(), Command{Args: []string{"sh", "-c", "sleep 10"}}, 50*time.Millisecond)
+		assert.ErrorContains(t, err, "did not finish within")
+	})
+
+	t.Run("returns error for missing command", func(t *testing.T) {
+		_, err := RunCommand(context.TODO(), Command{})
+		assert.ErrorContains(t, err, "no command given or empty")
+	})
+}
@@ -0,0 +1,68 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+// Unmask returns the given value with all MaskedStrings replaced by their actual value, including MaskedStrings nested
+// in maps and slices, and whether the value contained any MaskedString. It is meant to be used right before sensitive
+// values are required in their plain form, e.g. when rendering templates.
+func Unmask(v any) (any, bool) {
+	switch value := v.(type) {
+	case MaskedString:
+		return value.Value(), true
+	case map[string]any:
+		unmasked := make(map[string]any, len(value))
+		masked := false
+		for k, val := range value {
+			u, m := Unmask(val)
+			unmasked[k] = u
+			masked = masked || m
+		}
+		return unmasked, masked
+	case []any:
+		unmasked := make([]any, len(value))
+		masked := false
+		for i, val := range value {
+			u, m := Unmask(val)
+			unmasked[i] = u
+			masked = masked || m
+		}
+		return unmasked, masked
+	}
+	return v, false
+}
+
+// MaskedValues returns the actual values of all MaskedStrings contained in the given value, including MaskedStrings
+// nested in maps and slices.
+func MaskedValues(v any) []string {
+	switch value := v.(type) {
+	case MaskedString:
+		return []string{value.Value()}
+	case map[string]any:
+		var values []string
+		for _, val := range value {
+			values = append(values, MaskedValues(val)...)
+		}
+		return values
+	case []any:
+		var values []string
+		for _, val := range value {
+			values = append(values, MaskedValues(val)...)
+		}
+		return values
+	}
+	return nil
+}
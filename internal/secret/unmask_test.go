@@ -0,0 +1,61 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmask(t *testing.T) {
+	t.Run("unmasks nested values", func(t *testing.T) {
+		got, masked := Unmask(map[string]any{
+			"plain":  "value",
+			"secret": MaskedString("s3cr3t"),
+			"list":   []any{MaskedString("a"), "b"},
+			"nested": map[string]any{"secret": MaskedString("c")},
+		})
+
+		assert.True(t, masked)
+		assert.Equal(t, map[string]any{
+			"plain":  "value",
+			"secret": "s3cr3t",
+			"list":   []any{"a", "b"},
+			"nested": map[string]any{"secret": "c"},
+		}, got)
+	})
+
+	t.Run("returns values without masked strings unchanged", func(t *testing.T) {
+		got, masked := Unmask(map[string]any{"plain": "value", "number": 1})
+
+		assert.False(t, masked)
+		assert.Equal(t, map[string]any{"plain": "value", "number": 1}, got)
+	})
+}
+
+func TestMaskedValues(t *testing.T) {
+	got := MaskedValues(map[string]any{
+		"plain":  "value",
+		"secret": MaskedString("s3cr3t"),
+		"list":   []any{MaskedString("a"), "b"},
+	})
+
+	assert.ElementsMatch(t, []string{"s3cr3t", "a"}, got)
+}
@@ -22,6 +22,7 @@ import (
 	"reflect"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
 )
 
 func escapeSpecialCharactersInMap(properties map[string]interface{}, escapeFunc StringEscapeFunction) (map[string]interface{}, error) {
@@ -58,6 +59,56 @@ func EscapeSpecialCharactersInValue(value interface{}, escapeFunc StringEscapeFu
 	}
 }
 
+// UnescapeJSONString reverses FullStringEscapeFunction, returning the original string of a value escaped for use in
+// JSON. If the given string is no valid escaped JSON string, e.g. as it was not escaped at all, false is returned.
+func UnescapeJSONString(escaped string) (string, bool) {
+	var unescaped string
+	if err := json.Unmarshal([]byte(`"`+escaped+`"`), &unescaped); err != nil {
+		return "", false
+	}
+	return unescaped, true
+}
+
+// UnescapeStringValue reverts the escaping of a string value escaped using the FullStringEscapeFunction. Values that
+// are no strings, or no valid escaped JSON strings, are returned unchanged.
+func UnescapeStringValue(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if unescaped, ok := UnescapeJSONString(s); ok {
+		return unescaped
+	}
+	return s
+}
+
+// NormalizeValue walks maps/maps-of-maps and lists recursively, converting maps as parsed from YAML to
+// map[string]interface{}, so that the value can be encoded as JSON. All other values are converted using the given
+// convertFunc, e.g. UnescapeStringValue to revert EscapeSpecialCharactersInValue. If convertFunc is nil, they are
+// returned unchanged.
+func NormalizeValue(value interface{}, convertFunc func(interface{}) interface{}) interface{} {
+	switch field := value.(type) {
+	case map[interface{}]interface{}:
+		return NormalizeValue(maps.ToStringMap(field), convertFunc)
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(field))
+		for k, v := range field {
+			normalized[k] = NormalizeValue(v, convertFunc)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(field))
+		for i, v := range field {
+			normalized[i] = NormalizeValue(v, convertFunc)
+		}
+		return normalized
+	}
+	if convertFunc == nil {
+		return value
+	}
+	return convertFunc(value)
+}
+
 func escapeCharactersForStringMap(properties map[string]string, escapeFunc StringEscapeFunction) (map[string]string, error) {
 	escapedProperties := make(map[string]string, len(properties))
 
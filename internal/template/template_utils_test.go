@@ -87,3 +87,48 @@ func Test_escapeCharactersForJson(t *testing.T) {
 		})
 	}
 }
+
+func TestUnescapeJSONString(t *testing.T) {
+	for _, s := range []string{"plain", `with "quotes" and \ backslash`, "new\nline\ttab", "<, > & \x01"} {
+		t.Run(s, func(t *testing.T) {
+			escaped, err := escapeCharactersForJson(s)
+			require.NoError(t, err)
+
+			got, ok := UnescapeJSONString(escaped)
+			assert.True(t, ok)
+			assert.Equal(t, s, got)
+		})
+	}
+
+	t.Run("returns false for invalid escaped string", func(t *testing.T) {
+		_, ok := UnescapeJSONString(`unescaped " quote`)
+		assert.False(t, ok)
+	})
+}
+
+func TestNormalizeValue(t *testing.T) {
+	value := map[interface{}]interface{}{
+		"name": `a \"quoted\" name`,
+		"list": []interface{}{`new\nline`, 42, map[interface{}]interface{}{"key": `C:\\temp`}},
+	}
+
+	t.Run("converts maps and keeps other values", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{
+			"name": `a \"quoted\" name`,
+			"list": []interface{}{`new\nline`, 42, map[string]interface{}{"key": `C:\\temp`}},
+		}, NormalizeValue(value, nil))
+	})
+
+	t.Run("unescapes strings", func(t *testing.T) {
+		assert.Equal(t, map[string]interface{}{
+			"name": `a "quoted" name`,
+			"list": []interface{}{"new\nline", 42, map[string]interface{}{"key": `C:\temp`}},
+		}, NormalizeValue(value, UnescapeStringValue))
+	})
+}
+
+func TestUnescapeStringValue(t *testing.T) {
+	assert.Equal(t, "new\nline", UnescapeStringValue(`new\nline`))
+	assert.Equal(t, `unescaped " quote`, UnescapeStringValue(`unescaped " quote`), "invalid escaped strings are kept")
+	assert.Equal(t, 42, UnescapeStringValue(42))
+}
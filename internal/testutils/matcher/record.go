@@ -58,7 +58,7 @@ func FindRecord(records []report.Record, wantedRecord report.Record) (report.Rec
 }
 
 func isRecord(record, wanted report.Record) bool {
-	if !cmp.Equal(record, wanted, cmpopts.IgnoreFields(report.Record{}, "Time", "Error", "Message", "Details")) {
+	if !cmp.Equal(record, wanted, cmpopts.IgnoreFields(report.Record{}, "Time", "Environment", "Error", "Message", "Details")) {
 		return false
 	}
 	if wanted.Error == "" && record.Error != "" {
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"runtime"
 	"time"
@@ -33,12 +34,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
-	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/trafficlogs"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	clientauth "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/auth"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/connection"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/useragent"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 )
@@ -48,6 +51,7 @@ var (
 	_ ConfigClient   = (*dtclient.ConfigClient)(nil)
 	_ SettingsClient = (*dtclient.DummySettingsClient)(nil)
 	_ ConfigClient   = (*dtclient.DummyConfigClient)(nil)
+	_ EntitiesClient = (*dtclient.EntitiesClient)(nil)
 )
 
 //go:generate mockgen -source=clientset.go -destination=client_mock.go -package=client ConfigClient
@@ -87,6 +91,11 @@ type ConfigClient interface {
 	//	 PUT <environment-url>/api/config/v1/alertingProfiles/<id> ... with the given (or found by unique name) entity ID
 	UpsertByNonUniqueNameAndId(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (entity dtclient.DynatraceEntity, err error)
 
+	// UpdateByID updates the Dynatrace config identified by id from the given API, regardless of its name.
+	// It calls the underlying PUT endpoint for the API. E.g. for alerting profiles this would be:
+	//    PUT <environment-url>/api/config/v1/alertingProfiles/<id>
+	UpdateByID(ctx context.Context, a api.API, id string, name string, payload []byte) (entity dtclient.DynatraceEntity, err error)
+
 	// Delete removes a given config for a given API using its id.
 	// It calls the DELETE endpoint for the API. E.g. for alerting profiles this would be:
 	//    DELETE <environment-url>/api/config/v1/alertingProfiles/<id> ... to delete the config
@@ -189,6 +198,12 @@ type SegmentClient interface {
 	Get(ctx context.Context, id string) (libAPI.Response, error)
 }
 
+// EntitiesClient reads monitored entities, e.g. hosts and services, of an environment
+type EntitiesClient interface {
+	// ListIDs returns the IDs of all monitored entities matching the given entity selector
+	ListIDs(ctx context.Context, entitySelector string) ([]string, error)
+}
+
 type ServiceLevelObjectiveClient interface {
 	List(ctx context.Context) (libAPI.PagedListResponse, error)
 	Update(ctx context.Context, id string, body []byte) (libAPI.Response, error)
@@ -212,11 +227,14 @@ type ClientSet struct {
 	OpenPipelineClient          OpenPipelineClient
 	SegmentClient               SegmentClient
 	ServiceLevelObjectiveClient ServiceLevelObjectiveClient
+	EntitiesClient              EntitiesClient
 }
 
 type ClientOptions struct {
 	CustomUserAgent string
 	CachingDisabled bool
+	// Connection holds the connection settings of the environment, e.g. its proxy or additional headers.
+	Connection manifest.Connection
 }
 
 func (o ClientOptions) getUserAgentString() string {
@@ -261,20 +279,26 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		openPipelineClient          OpenPipelineClient
 		segmentClient               SegmentClient
 		serviceLevelObjectiveClient ServiceLevelObjectiveClient
+		entitiesClient              EntitiesClient
 		err                         error
 	)
 	if err = validateURL(url); err != nil {
 		return nil, err
 	}
 
-	concurrentReqLimit := environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
+	httpClient, err := connection.NewHTTPClient(opts.Connection)
+	if err != nil {
+		return nil, err
+	}
+	// OAuth token sources, and the platform clients authenticated by them, use the HTTP client of the environment
+	ctx = connection.ContextWithHTTPClient(ctx, httpClient)
+
 	cFactory := clients.Factory().
-		WithConcurrentRequestLimit(concurrentReqLimit).
+		WithConcurrentRequestLimit(connection.ConcurrentRequestLimit(opts.Connection)).
 		WithUserAgent(opts.getUserAgentString()).
 		WithRetryOptions(&DefaultRetryOptions).
 		WithRateLimiter(true).
-		WithCustomHeaders(additionalHeaders)
+		WithCustomHeaders(connection.Headers(opts.Connection))
 
 	if supportarchive.IsEnabled(ctx) {
 		cFactory = cFactory.WithHTTPListener(&rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles})
@@ -338,6 +362,8 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 			return nil, err
 		}
 
+		entitiesClient = dtclient.NewPlatformEntitiesClient(client)
+
 		classicURL, err = metadata.GetDynatraceClassicURL(ctx, *client)
 		if err != nil {
 			return nil, err
@@ -345,9 +371,13 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 	}
 
 	if auth.ApiToken != nil {
-		cFactory = cFactory.WithAccessToken(auth.ApiToken.Value.Value()).
-			WithClassicURL(classicURL)
-		client, err := cFactory.CreateClassicClient()
+		client, err := NewClassicClient(ctx, classicURL, auth.ApiToken.Value.Value(), httpClient, ClassicClientOptions{
+			UserAgent:              opts.getUserAgentString(),
+			Headers:                connection.Headers(opts.Connection),
+			ConcurrentRequestLimit: connection.ConcurrentRequestLimit(opts.Connection),
+			RetryOptions:           &DefaultRetryOptions,
+			RateLimiter:            true,
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -363,6 +393,10 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 				return nil, err
 			}
 		}
+
+		if entitiesClient == nil {
+			entitiesClient = dtclient.NewClassicEntitiesClient(client)
+		}
 	}
 
 	return &ClientSet{
@@ -374,5 +408,57 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		OpenPipelineClient:          openPipelineClient,
 		SegmentClient:               segmentClient,
 		ServiceLevelObjectiveClient: serviceLevelObjectiveClient,
+		EntitiesClient:              entitiesClient,
 	}, nil
 }
+
+// ClassicClientOptions configure the clients created by NewClassicClient.
+type ClassicClientOptions struct {
+	// UserAgent is sent with each request, if set
+	UserAgent string
+	// Headers are additional headers sent with each request
+	Headers map[string]string
+	// ConcurrentRequestLimit limits the number of concurrent requests, if greater than zero
+	ConcurrentRequestLimit int
+	// RetryOptions define how failed requests are retried, if set
+	RetryOptions *rest.RetryOptions
+	// RateLimiter enables limiting requests according to the rate limit responses of the environment
+	RateLimiter bool
+}
+
+// NewClassicClient returns a client for the classic API at the given URL, which sends all requests using the given
+// HTTP client of the environment. Requests are authenticated using the given API token, if it is not empty.
+func NewClassicClient(ctx context.Context, classicURL string, apiToken string, httpClient *http.Client, opts ClassicClientOptions) (*rest.Client, error) {
+	parsedURL, err := url.ParseRequestURI(classicURL)
+	if err != nil {
+		return nil, fmt.Errorf("classic environment url %q was not valid: %w", classicURL, err)
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	transport = connection.NewHeaderTransport(transport, opts.Headers)
+	if opts.UserAgent != "" {
+		transport = useragent.NewCustomUserAgentTransport(transport, opts.UserAgent)
+	}
+	if apiToken != "" {
+		transport = clientauth.NewTokenAuthTransport(transport, apiToken)
+	}
+
+	var restOpts []rest.Option
+	if opts.ConcurrentRequestLimit > 0 {
+		restOpts = append(restOpts, rest.WithConcurrentRequestLimit(opts.ConcurrentRequestLimit))
+	}
+	if opts.RetryOptions != nil {
+		restOpts = append(restOpts, rest.WithRetryOptions(opts.RetryOptions))
+	}
+	if opts.RateLimiter {
+		restOpts = append(restOpts, rest.WithRateLimiter())
+	}
+	if supportarchive.IsEnabled(ctx) {
+		restOpts = append(restOpts, rest.WithHTTPListener(&rest.HTTPListener{Callback: trafficlogs.GetInstance().LogToFiles}))
+	}
+
+	return rest.NewClient(parsedURL, &http.Client{Transport: transport, Timeout: httpClient.Timeout}, restOpts...), nil
+}
@@ -162,6 +162,45 @@ func TestCreateClientSetWithAdditionalHeaders(t *testing.T) {
 	require.Equal(t, 404, apiErr.StatusCode)
 }
 
+func TestCreateClientSetWithOptions_SendsRequestsUsingConnectionOfEnvironment(t *testing.T) {
+	var proxiedHosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxiedHosts = append(proxiedHosts, req.URL.Host)
+		if strings.HasSuffix(req.URL.Path, "sso") {
+			rw.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(rw).Encode(&oauth2.Token{AccessToken: "test-access-token", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)})
+			return
+		}
+		if strings.Contains(req.URL.Path, "settings/objects") {
+			rw.WriteHeader(404)
+			return
+		}
+
+		rw.WriteHeader(200)
+		_, _ = rw.Write([]byte(`{"version" : "0.59.3.20231603", "domain": "http://environment.invalid", "endpoint": "http://environment.invalid"}`))
+	}))
+	defer proxy.Close()
+
+	defaultTransport := http.DefaultTransport
+	clientSet, err := CreateClientSetWithOptions(t.Context(), "http://environment.invalid", manifest.Auth{
+		ApiToken: &manifest.AuthSecret{Name: "token-env-var", Value: "mock token"},
+		OAuth: &manifest.OAuth{
+			ClientID:      manifest.AuthSecret{Name: "client-id", Value: "resolved-client-id"},
+			ClientSecret:  manifest.AuthSecret{Name: "client-secret", Value: "resolved-client-secret"},
+			TokenEndpoint: &manifest.URLDefinition{Value: "http://token.invalid/sso"},
+		},
+	}, ClientOptions{Connection: manifest.Connection{ProxyURL: proxy.URL}})
+	require.NoError(t, err)
+	assert.Same(t, defaultTransport, http.DefaultTransport, "the connection settings must not change the default transport")
+
+	var apiErr api.APIError
+	_, err = clientSet.SettingsClient.Get(t.Context(), "")
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.StatusCode)
+	assert.Contains(t, proxiedHosts, "token.invalid", "token requests are sent using the connection of the environment")
+	assert.Contains(t, proxiedHosts, "environment.invalid")
+}
+
 func TestCreateClientSetWithPlatformToken_ClientsUsePlatformToken(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if strings.HasSuffix(req.URL.Path, "sso") {
@@ -0,0 +1,173 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package connection applies the per-environment connection settings of the manifest to HTTP clients.
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// NewHTTPClient returns the HTTP client to send all requests to an environment with, applying the proxy, TLS and
+// timeout settings of the given connection to a clone of http.DefaultTransport. Each environment uses its own client,
+// so that the settings of one environment never apply to requests to another environment, even if both use the same
+// hosts, e.g. the same OAuth token endpoint.
+func NewHTTPClient(c manifest.Connection) (*http.Client, error) {
+	if !c.RequiresTransport() {
+		return &http.Client{Transport: http.DefaultTransport}, nil
+	}
+
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("failed to apply connection settings: the default HTTP transport has been replaced")
+	}
+	t, err := NewTransport(base, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply connection settings: %w", err)
+	}
+	return &http.Client{Transport: t}, nil
+}
+
+// ContextWithHTTPClient returns a context, with which OAuth token sources and the clients authenticated by them send
+// all requests, including those to the token endpoint, using the given HTTP client.
+func ContextWithHTTPClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, client)
+}
+
+// NewTransport returns an http.RoundTripper applying the proxy, TLS and timeout settings of the given connection. It
+// is based on a clone of the given transport, which is not modified.
+func NewTransport(base *http.Transport, c manifest.Connection) (http.RoundTripper, error) {
+	t := base.Clone()
+
+	if c.ProxyURL != "" {
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", c.ProxyURL, err)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(c.CACertificates) > 0 || c.Insecure {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if t.TLSClientConfig != nil {
+			tlsConfig = t.TLSClientConfig.Clone()
+		}
+
+		if len(c.CACertificates) > 0 {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(c.CACertificates) {
+				return nil, fmt.Errorf("CA file %q does not contain any PEM encoded certificate", c.CAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		tlsConfig.InsecureSkipVerify = c.Insecure // #nosec G402 -- disabling verification is configured by the user on purpose
+		t.TLSClientConfig = tlsConfig
+	}
+
+	if c.Timeout > 0 {
+		return &timeoutTransport{RoundTripper: t, timeout: c.Timeout}, nil
+	}
+	return t, nil
+}
+
+// timeoutTransport cancels requests, including reading their response body, after a timeout.
+type timeoutTransport struct {
+	http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.RoundTripper.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fmt.Errorf("request timed out after %s: %w", t.timeout, err)
+		}
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request once its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// headerTransport adds headers to each request.
+type headerTransport struct {
+	http.RoundTripper
+	headers map[string]string
+}
+
+// NewHeaderTransport returns an http.RoundTripper adding the given headers to each request sent using the given
+// transport.
+func NewHeaderTransport(base http.RoundTripper, headers map[string]string) http.RoundTripper {
+	if len(headers) == 0 {
+		return base
+	}
+	return &headerTransport{RoundTripper: base, headers: headers}
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// Headers returns the additional HTTP headers to send to an environment: The headers of MONACO_ADDITIONAL_HTTP_HEADERS,
+// overridden by the headers of the given connection.
+func Headers(c manifest.Connection) map[string]string {
+	headers := environment.GetAdditionalHTTPHeadersFromEnv()
+	maps.Copy(headers, c.Headers)
+	return headers
+}
+
+// ConcurrentRequestLimit returns the maximum number of concurrent requests to an environment: The limit of the given
+// connection if set, or else the value of MONACO_CONCURRENT_REQUESTS.
+func ConcurrentRequestLimit(c manifest.Connection) int {
+	if c.MaxConcurrentRequests > 0 {
+		return c.MaxConcurrentRequests
+	}
+	return environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
+}
@@ -0,0 +1,221 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package connection
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func newBaseTransport() *http.Transport {
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+func get(t *testing.T, transport http.RoundTripper, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	if err == nil {
+		t.Cleanup(func() { _ = resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestNewTransport_Proxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewTransport(newBaseTransport(), manifest.Connection{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	resp, err := get(t, transport, "http://environment.invalid/api/v2/settings")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+	assert.Equal(t, "http://environment.invalid/api/v2/settings", proxiedURL)
+}
+
+func TestNewTransport_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	t.Run("untrusted certificates fail", func(t *testing.T) {
+		transport, err := NewTransport(newBaseTransport(), manifest.Connection{})
+		require.NoError(t, err)
+		_, err = get(t, transport, server.URL)
+		assert.Error(t, err)
+	})
+
+	t.Run("certificates of the CA file are trusted", func(t *testing.T) {
+		transport, err := NewTransport(newBaseTransport(), manifest.Connection{CAFile: "ca.pem", CACertificates: ca})
+		require.NoError(t, err)
+		resp, err := get(t, transport, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("insecure skips verification", func(t *testing.T) {
+		transport, err := NewTransport(newBaseTransport(), manifest.Connection{Insecure: true})
+		require.NoError(t, err)
+		resp, err := get(t, transport, server.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("invalid CA certificates fail", func(t *testing.T) {
+		_, err := NewTransport(newBaseTransport(), manifest.Connection{CAFile: "ca.pem", CACertificates: []byte("no certificate")})
+		assert.ErrorContains(t, err, `CA file "ca.pem" does not contain any PEM encoded certificate`)
+	})
+}
+
+func TestNewTransport_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	transport, err := NewTransport(newBaseTransport(), manifest.Connection{Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = get(t, transport, server.URL)
+	assert.ErrorContains(t, err, "request timed out after 50ms")
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("uses the default transport without connection settings", func(t *testing.T) {
+		client, err := NewHTTPClient(manifest.Connection{})
+		require.NoError(t, err)
+		assert.Same(t, http.DefaultTransport, client.Transport)
+	})
+
+	t.Run("environments using the same host do not share connection settings", func(t *testing.T) {
+		var proxied int
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied++
+			w.WriteHeader(http.StatusTeapot)
+		}))
+		defer proxy.Close()
+
+		direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer direct.Close()
+
+		viaProxy, err := NewHTTPClient(manifest.Connection{ProxyURL: proxy.URL})
+		require.NoError(t, err)
+		withTimeout, err := NewHTTPClient(manifest.Connection{Timeout: time.Minute})
+		require.NoError(t, err)
+
+		resp, err := get(t, viaProxy.Transport, direct.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+		resp, err = get(t, withTimeout.Transport, direct.URL)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, proxied)
+	})
+
+	t.Run("invalid settings fail", func(t *testing.T) {
+		_, err := NewHTTPClient(manifest.Connection{CACertificates: []byte("no certificate"), CAFile: "ca.pem"})
+		assert.ErrorContains(t, err, "failed to apply connection settings")
+	})
+}
+
+func TestContextWithHTTPClient_RequestsOAuthTokensUsingClient(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		if r.URL.Path == "/sso/token" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":300}`))
+			return
+		}
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var used int
+	client := &http.Client{Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		used++
+		return http.DefaultTransport.RoundTrip(req)
+	})}
+
+	config := clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL + "/sso/token"}
+	oauthClient := config.Client(ContextWithHTTPClient(t.Context(), client))
+
+	resp, err := get(t, oauthClient.Transport, server.URL+"/api")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"/sso/token", "/api"}, requests)
+	assert.Equal(t, 2, used, "both the token and the API request are sent using the client")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewHeaderTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "value", r.Header.Get("X-Custom"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := get(t, NewHeaderTransport(newBaseTransport(), map[string]string{"X-Custom": "value"}), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHeaders(t *testing.T) {
+	t.Setenv("MONACO_ADDITIONAL_HTTP_HEADERS", "X-From-Env: env\nX-Overridden: env")
+
+	got := Headers(manifest.Connection{Headers: map[string]string{"X-Overridden": "manifest", "X-From-Manifest": "manifest"}})
+	assert.Equal(t, map[string]string{"X-From-Env": "env", "X-Overridden": "manifest", "X-From-Manifest": "manifest"}, got)
+}
+
+func TestConcurrentRequestLimit(t *testing.T) {
+	t.Setenv("MONACO_CONCURRENT_REQUESTS", "7")
+
+	assert.Equal(t, 7, ConcurrentRequestLimit(manifest.Connection{}))
+	assert.Equal(t, 2, ConcurrentRequestLimit(manifest.Connection{MaxConcurrentRequests: 2}))
+}
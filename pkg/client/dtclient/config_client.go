@@ -103,7 +103,7 @@ func (d *ConfigClient) Get(ctx context.Context, api api.API, id string) (json []
 		dtUrl = dtUrl + "/" + url.PathEscape(id)
 	}
 
-	response, err := coreapi.AsResponseOrError(d.client.GET(ctx, dtUrl, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests}))
+	response, err := coreapi.AsResponseOrError(d.client.GET(ctx, dtUrl, withMaxRetriesFromContext(ctx, corerest.RequestOptions{CustomShouldRetryFunc: corerest.RetryIfTooManyRequests})))
 	if err != nil {
 		return nil, err
 	}
@@ -250,6 +250,11 @@ func (d *ConfigClient) UpsertByNonUniqueNameAndId(ctx context.Context, theApi ap
 	return d.updateDynatraceObject(ctx, objectName, entityId, theApi, body)
 }
 
+// UpdateByID updates the config with the given id, regardless of its name.
+func (d *ConfigClient) UpdateByID(ctx context.Context, theApi api.API, id string, objectName string, payload []byte) (entity DynatraceEntity, err error) {
+	return d.updateDynatraceObject(ctx, objectName, id, theApi, payload)
+}
+
 func (d *ConfigClient) createDynatraceObject(ctx context.Context, objectName string, theApi api.API, payload []byte) (DynatraceEntity, error) {
 	endpoint := theApi.URLPath
 	if theApi.ID == api.KeyUserActionsMobile {
@@ -386,7 +391,7 @@ func stripCreateOnlyPropertiesFromAppMobile(payload []byte) []byte {
 // retrying in case of known errors on upload.
 func (d *ConfigClient) callWithRetryOnKnowTimingIssue(ctx context.Context, restCall SendRequestWithBody, endpoint string, requestBody []byte, theApi api.API, options corerest.RequestOptions) (coreapi.Response, error) {
 	var resp coreapi.Response
-	httpResp, err := restCall(ctx, endpoint, bytes.NewReader(requestBody), options)
+	httpResp, err := restCall(ctx, endpoint, bytes.NewReader(requestBody), withMaxRetriesFromContext(ctx, options))
 
 	if err == nil {
 		resp, err = coreapi.NewResponseFromHTTPResponse(httpResp)
@@ -401,6 +406,11 @@ func (d *ConfigClient) callWithRetryOnKnowTimingIssue(ctx context.Context, restC
 		return coreapi.Response{}, err
 	}
 
+	// the retries defined by the context were already done by the request itself
+	if _, ok := MaxRetriesFromContext(ctx); ok {
+		return coreapi.Response{}, err
+	}
+
 	var rs RetrySetting
 	// It can take longer until calculated service metrics are ready to be used in SLOs
 	if isCalculatedMetricNotReadyYet(apiError) ||
@@ -629,6 +639,7 @@ func (d *ConfigClient) List(ctx context.Context, theApi api.API) ([]Value, error
 	} else {
 		retrySetting = d.retrySettings.Normal
 	}
+	retrySetting = retrySettingFromContext(ctx, retrySetting)
 
 	httpResp, err := d.client.GET(ctx, theApi.URLPath, corerest.RequestOptions{
 		QueryParams: queryParams,
@@ -196,6 +196,10 @@ func (c *DummyConfigClient) UpsertByNonUniqueNameAndId(_ context.Context, a api.
 	}, nil
 }
 
+func (c *DummyConfigClient) UpdateByID(ctx context.Context, a api.API, id string, name string, data []byte) (entity DynatraceEntity, err error) {
+	return c.UpsertByNonUniqueNameAndId(ctx, a, id, name, data, false)
+}
+
 func (c *DummyConfigClient) writeRequest(a api.API, name string, payload []byte) {
 	if c.Fs == nil {
 		return
@@ -0,0 +1,83 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+const (
+	entitiesAPIPathClassic  = "/api/v2/entities"
+	entitiesAPIPathPlatform = "/platform/classic/environment-api/v2/entities"
+)
+
+// EntitiesClient reads monitored entities using the [Monitored entities API].
+//
+// [Monitored entities API]: https://docs.dynatrace.com/docs/dynatrace-api/environment-api/entity-v2
+type EntitiesClient struct {
+	client          *corerest.Client
+	entitiesAPIPath string
+}
+
+// NewClassicEntitiesClient creates a new entities client to be used for classic environments
+func NewClassicEntitiesClient(client *corerest.Client) *EntitiesClient {
+	return &EntitiesClient{client: client, entitiesAPIPath: entitiesAPIPathClassic}
+}
+
+// NewPlatformEntitiesClient creates a new entities client to be used for platform enabled environments
+func NewPlatformEntitiesClient(client *corerest.Client) *EntitiesClient {
+	return &EntitiesClient{client: client, entitiesAPIPath: entitiesAPIPathPlatform}
+}
+
+// ListIDs returns the IDs of all monitored entities matching the given entity selector, e.g. `type(HOST),tag(team:a)`.
+// Only entities seen within the default timeframe of the API (the last 72 hours) are returned.
+func (c *EntitiesClient) ListIDs(ctx context.Context, entitySelector string) ([]string, error) {
+	params := url.Values{
+		"entitySelector": []string{entitySelector},
+		"pageSize":       []string{defaultPageSize},
+		"fields":         []string{"entityId"},
+	}
+
+	result := make([]string, 0)
+
+	addToResult := func(body []byte) (int, error) {
+		var parsed struct {
+			Entities []struct {
+				EntityID string `json:"entityId"`
+			} `json:"entities"`
+		}
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		for _, e := range parsed.Entities {
+			result = append(result, e.EntityID)
+		}
+		return len(parsed.Entities), nil
+	}
+
+	if err := listPaginated(ctx, c.client, c.entitiesAPIPath, params, entitySelector, addToResult); err != nil {
+		return nil, fmt.Errorf("failed to list entities matching %q: %w", entitySelector, err)
+	}
+
+	return result, nil
+}
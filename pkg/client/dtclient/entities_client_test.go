@@ -0,0 +1,71 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dtclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
+)
+
+func TestEntitiesClient_ListIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, entitiesAPIPathClassic, req.URL.Path)
+
+		switch req.URL.Query().Get("nextPageKey") {
+		case "":
+			assert.Equal(t, "type(HOST),tag(team:payments)", req.URL.Query().Get("entitySelector"))
+			rw.Write([]byte(`{"totalCount": 3, "nextPageKey": "page2", "entities": [{"entityId": "HOST-1"}, {"entityId": "HOST-2"}]}`))
+		case "page2":
+			assert.Empty(t, req.URL.Query().Get("entitySelector"), "api/v2 endpoints only accept the next page key")
+			rw.Write([]byte(`{"totalCount": 3, "entities": [{"entityId": "HOST-3"}]}`))
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	ids, err := NewClassicEntitiesClient(corerest.NewClient(serverURL, server.Client())).ListIDs(context.TODO(), "type(HOST),tag(team:payments)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HOST-1", "HOST-2", "HOST-3"}, ids)
+}
+
+func TestEntitiesClient_ListIDs_ReturnsErrorOnFailedRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"error": {"code": 400, "message": "invalid entity selector"}}`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	_, err = NewPlatformEntitiesClient(corerest.NewClient(serverURL, server.Client())).ListIDs(context.TODO(), "type(")
+	assert.ErrorContains(t, err, `failed to list entities matching "type("`)
+}
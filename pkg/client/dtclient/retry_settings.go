@@ -17,7 +17,11 @@
 package dtclient
 
 import (
+	"context"
+	"net/http"
 	"time"
+
+	corerest "github.com/dynatrace/dynatrace-configuration-as-code-core/api/rest"
 )
 
 type RetrySetting struct {
@@ -45,3 +49,39 @@ var DefaultRetrySettings = RetrySettings{
 		MaxRetries: 60,
 	},
 }
+
+type ctxMaxRetriesKey struct{}
+
+// NewContextWithMaxRetries returns a new context defining how many times each failed request sent using it by the
+// ConfigClient or SettingsClient is retried. It replaces the default retries of the client, e.g. to let the deployment
+// of a single config fail fast.
+func NewContextWithMaxRetries(ctx context.Context, maxRetries int) context.Context {
+	return context.WithValue(ctx, ctxMaxRetriesKey{}, maxRetries)
+}
+
+// MaxRetriesFromContext returns the number of retries defined by NewContextWithMaxRetries, if any.
+func MaxRetriesFromContext(ctx context.Context) (int, bool) {
+	maxRetries, ok := ctx.Value(ctxMaxRetriesKey{}).(int)
+	return maxRetries, ok
+}
+
+// withMaxRetriesFromContext returns the given request options, retrying failed requests as many times as defined by
+// the given context, if it defines it.
+func withMaxRetriesFromContext(ctx context.Context, options corerest.RequestOptions) corerest.RequestOptions {
+	if maxRetries, ok := MaxRetriesFromContext(ctx); ok {
+		options.MaxRetries = &maxRetries
+		options.CustomShouldRetryFunc = func(resp *http.Response) bool {
+			return corerest.ShouldRetry(resp.StatusCode)
+		}
+	}
+	return options
+}
+
+// retrySettingFromContext returns the given RetrySetting, using the maximum number of retries defined by the given
+// context, if it defines it.
+func retrySettingFromContext(ctx context.Context, rs RetrySetting) RetrySetting {
+	if maxRetries, ok := MaxRetriesFromContext(ctx); ok {
+		rs.MaxRetries = maxRetries
+	}
+	return rs
+}
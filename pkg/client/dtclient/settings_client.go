@@ -524,6 +524,7 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 	if upsertOptions.OverrideRetry != nil {
 		retrySetting = *upsertOptions.OverrideRetry
 	}
+	retrySetting = retrySettingFromContext(ctx, retrySetting)
 
 	httpResp, err := d.client.POST(ctx, d.settingsObjectAPIPath, bytes.NewReader(payload), corerest.RequestOptions{
 		CustomShouldRetryFunc: func(response *http.Response) bool {
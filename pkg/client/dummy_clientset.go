@@ -18,7 +18,7 @@ package client
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
@@ -69,11 +69,22 @@ func (d *DummyAutomationClient) Update(ctx context.Context, resourceType automat
 	panic("unimplemented")
 }
 
-// Upsert implements AutomationClient.
+// Upsert implements AutomationClient. Like the API, it returns the upserted object, so that other configs can
+// reference its values.
 func (d *DummyAutomationClient) Upsert(ctx context.Context, resourceType automation.ResourceType, id string, data []byte) (result api.Response, err error) {
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		object = make(map[string]any)
+	}
+	object["id"] = id
+
+	response, err := json.Marshal(object)
+	if err != nil {
+		return api.Response{}, err
+	}
 	return api.Response{
-		StatusCode: 200,
-		Data:       []byte(fmt.Sprintf(`{"id" : "%s"}`, id)),
+		StatusCode: http.StatusOK,
+		Data:       response,
 	}, nil
 }
 
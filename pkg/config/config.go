@@ -18,6 +18,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -25,10 +26,15 @@ import (
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	entitySelectorParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/entityselector"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	expressionParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/expression"
 	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	lookupParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
+	objectParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/object"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	secretParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/secret"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
@@ -93,6 +99,30 @@ type Config struct {
 
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
+
+	// SourceFile is the path of the YAML file this configuration was loaded from, if it was loaded from a file
+	SourceFile string
+
+	// AdditionalSourceFiles are the paths of further files defining parts of this configuration, like the shared
+	// parameters file of its project, the items file of its forEach, or the files of the configurations it extends
+	AdditionalSourceFiles []string
+
+	// Deployment defines how this configuration is deployed, if it differs from the default
+	Deployment *DeploymentPolicy
+
+	// ParameterConstraints holds the constraints the resolved values of parameters must satisfy, by parameter name
+	ParameterConstraints map[string]ParameterConstraint
+}
+
+// DeploymentPolicy defines how a single configuration is deployed.
+type DeploymentPolicy struct {
+	// Retries is the number of times each failed request of the deployment is retried. If nil, the default retries
+	// of the clients apply.
+	Retries *int
+	// Timeout is the maximum duration of the deployment including all retries. Zero means unlimited.
+	Timeout time.Duration
+	// WaitAfter is the duration to wait after a successful deployment, before configurations depending on it are deployed
+	WaitAfter time.Duration
 }
 
 func (c *Config) Render(properties map[string]interface{}) (string, error) {
@@ -142,12 +172,17 @@ func (c *Config) Render(properties map[string]interface{}) (string, error) {
 
 // DefaultParameterParsers map defining a set of default parsers which can be used to load configurations
 var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
-	refParam.ReferenceParameterType:           refParam.ReferenceParameterSerde,
-	valueParam.ValueParameterType:             valueParam.ValueParameterSerde,
-	envParam.EnvironmentVariableParameterType: envParam.EnvironmentVariableParameterSerde,
-	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
-	listParam.ListParameterType:               listParam.ListParameterSerde,
-	fileParam.FileParameterType:               fileParam.FileParameterSerde,
+	refParam.ReferenceParameterType:                 refParam.ReferenceParameterSerde,
+	valueParam.ValueParameterType:                   valueParam.ValueParameterSerde,
+	envParam.EnvironmentVariableParameterType:       envParam.EnvironmentVariableParameterSerde,
+	compoundParam.CompoundParameterType:             compoundParam.CompoundParameterSerde,
+	listParam.ListParameterType:                     listParam.ListParameterSerde,
+	fileParam.FileParameterType:                     fileParam.FileParameterSerde,
+	lookupParam.LookupParameterType:                 lookupParam.LookupParameterSerde,
+	entitySelectorParam.EntitySelectorParameterType: entitySelectorParam.EntitySelectorParameterSerde,
+	secretParam.SecretParameterType:                 secretParam.SecretParameterSerde,
+	expressionParam.ExpressionParameterType:         expressionParam.ExpressionParameterSerde,
+	objectParam.ObjectParameterType:                 objectParam.ObjectParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -182,7 +217,9 @@ func (c *Config) HasRefTo(configType string) bool {
 	return false
 }
 
-// EntityLookup is used in parameter resolution to fetch the resolved entity of deployed configuration
+// EntityLookup is used in parameter resolution to fetch the resolved entity of deployed configuration.
+// If it implements parameter.ObjectLookup or parameter.MonitoredEntityLookup as well, it is used to resolve parameters
+// looking up existing objects or monitored entities.
 type EntityLookup interface {
 	parameter.PropertyResolver
 
@@ -31,6 +31,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	lookupParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -226,6 +227,85 @@ func TestResolveParameterValuesShouldFailWhenParameterResolveReturnsError(t *tes
 	assert.NotEmpty(t, errs, "there should be errors (no : %d)", len(errs))
 }
 
+func TestResolveParameterValuesShouldFailWhenConstraintIsViolated(t *testing.T) {
+	parameters := []parameter.NamedParameter{
+		{
+			Name:      NameParameter,
+			Parameter: &parameter.DummyParameter{Value: "name"},
+		},
+		{
+			Name:      "level",
+			Parameter: &parameter.DummyParameter{Value: "debug"},
+		},
+	}
+
+	conf := Config{
+		Template: generateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{
+			Project:  "project1",
+			Type:     "dashboard",
+			ConfigId: "dashboard-1",
+		},
+		Group:       "group",
+		Environment: "development",
+		Parameters:  toParameterMap(parameters),
+		ParameterConstraints: map[string]ParameterConstraint{
+			"level": {Enum: []any{"info", "warn"}},
+		},
+	}
+
+	_, errs := conf.ResolveParameterValues(entityLookup{})
+
+	require.Len(t, errs, 1)
+	var resolveErr parameter.ParameterResolveValueError
+	require.ErrorAs(t, errs[0], &resolveErr)
+	assert.Equal(t, conf.Coordinate, resolveErr.Location)
+	assert.Equal(t, "development", resolveErr.EnvironmentDetails.Environment)
+	assert.Equal(t, "level", resolveErr.ParameterName)
+	assert.Contains(t, resolveErr.Reason, "is not one of the allowed values")
+}
+
+// objectLookup is an entityLookup that resolves all looked up objects to the same value.
+type objectLookup struct {
+	entityLookup
+	value        any
+	placeholders bool
+}
+
+func (l objectLookup) LookupObject(parameter.ObjectQuery) (any, error) {
+	return l.value, nil
+}
+
+func (l objectLookup) ReturnsPlaceholders() bool {
+	return l.placeholders
+}
+
+func TestResolveParameterValues_DoesNotCheckConstraintsOfPlaceholders(t *testing.T) {
+	conf := Config{
+		Template:    generateDummyTemplate(t),
+		Coordinate:  coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Environment: "development",
+		Parameters: Parameters{
+			"zoneId": &lookupParam.LookupParameter{Query: parameter.ObjectQuery{API: api.ManagementZone, Name: "Team A", Property: "id"}},
+		},
+		ParameterConstraints: map[string]ParameterConstraint{
+			"zoneId": {Type: IntType},
+		},
+	}
+
+	t.Run("placeholder is not checked", func(t *testing.T) {
+		values, errs := conf.ResolveParameterValues(objectLookup{entityLookup: entityLookup{}, value: "placeholder", placeholders: true})
+		require.Empty(t, errs)
+		assert.Equal(t, "placeholder", values["zoneId"])
+	})
+
+	t.Run("looked up value is checked", func(t *testing.T) {
+		_, errs := conf.ResolveParameterValues(objectLookup{entityLookup: entityLookup{}, value: "not a number"})
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "zoneId")
+	})
+}
+
 func TestValidateParameterReferences(t *testing.T) {
 	configCoordinates := coordinate.Coordinate{
 		Project:  "project1",
@@ -0,0 +1,241 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// ParameterValueType is the type a resolved parameter value is constrained to.
+type ParameterValueType string
+
+const (
+	StringType ParameterValueType = "string"
+	IntType    ParameterValueType = "int"
+	NumberType ParameterValueType = "number"
+	BoolType   ParameterValueType = "bool"
+	ListType   ParameterValueType = "list"
+	ObjectType ParameterValueType = "object"
+)
+
+// ParameterValueTypes holds all types a ParameterConstraint may require.
+var ParameterValueTypes = []ParameterValueType{StringType, IntType, NumberType, BoolType, ListType, ObjectType}
+
+// ParameterConstraint declares the values a parameter may resolve to. It is checked after the parameter is resolved,
+// so that invalid values, e.g. in environment overrides, are reported before they are sent to Dynatrace.
+//
+// As values of environment variables are always strings, strings are accepted for the types int, number, bool and
+// list if they represent a valid value of that type, and for the type object if they contain a JSON object.
+type ParameterConstraint struct {
+	// Type the value must be of. Any type is allowed if empty.
+	Type ParameterValueType
+	// Enum holds the allowed values. Any value is allowed if empty.
+	Enum []any
+	// Pattern is a regular expression the string value must match.
+	Pattern *regexp.Regexp
+	// Min and Max limit the value of numbers, or the length of strings and lists.
+	Min, Max *float64
+	// NonEmpty requires the value to be neither null nor an empty string or list.
+	NonEmpty bool
+}
+
+// Check returns an error describing the first violated constraint, if the given resolved value violates any.
+// Secret values are never included in the error.
+func (c ParameterConstraint) Check(value any) error {
+	unmasked, masked := secret.Unmask(value)
+	value = template.UnescapeStringValue(unmasked)
+
+	describe := func() string {
+		if masked {
+			return "secret value"
+		}
+		return fmt.Sprintf("value %q", fmt.Sprint(value))
+	}
+
+	if c.NonEmpty && isEmpty(value) {
+		return fmt.Errorf("value must not be empty")
+	}
+
+	if c.Type != "" && !isOfType(c.Type, value) {
+		return fmt.Errorf("%s is not of type %s", describe(), c.Type)
+	}
+
+	if len(c.Enum) > 0 && !isOneOf(value, c.Enum) {
+		return fmt.Errorf("%s is not one of the allowed values %v", describe(), c.Enum)
+	}
+
+	if c.Pattern != nil {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string to match pattern %q", describe(), c.Pattern)
+		}
+		if !c.Pattern.MatchString(s) {
+			return fmt.Errorf("%s does not match pattern %q", describe(), c.Pattern)
+		}
+	}
+
+	if c.Min != nil || c.Max != nil {
+		measure, what, ok := measureOf(c.Type, value)
+		if !ok {
+			return fmt.Errorf("%s must be a number, string or list to be checked against min and max", describe())
+		}
+		if c.Min != nil && measure < *c.Min {
+			return fmt.Errorf("%s%s is less than the minimum of %v", what, describe(), *c.Min)
+		}
+		if c.Max != nil && measure > *c.Max {
+			return fmt.Errorf("%s%s is greater than the maximum of %v", what, describe(), *c.Max)
+		}
+	}
+
+	return nil
+}
+
+func isEmpty(v any) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(value) == ""
+	case []any:
+		return len(value) == 0
+	case []string:
+		return len(value) == 0
+	case map[any]any:
+		return len(value) == 0
+	case map[string]any:
+		return len(value) == 0
+	}
+	return false
+}
+
+func isOfType(t ParameterValueType, v any) bool {
+	switch t {
+	case StringType:
+		_, ok := v.(string)
+		return ok
+	case IntType:
+		if s, ok := v.(string); ok {
+			_, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+			return err == nil
+		}
+		f, ok := toFloat(v)
+		return ok && f == float64(int64(f))
+	case NumberType:
+		if s, ok := v.(string); ok {
+			_, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+			return err == nil
+		}
+		_, ok := toFloat(v)
+		return ok
+	case BoolType:
+		if s, ok := v.(string); ok {
+			_, err := strconv.ParseBool(strings.TrimSpace(s))
+			return err == nil
+		}
+		_, ok := v.(bool)
+		return ok
+	case ListType:
+		_, ok := toList(v)
+		return ok
+	case ObjectType:
+		switch value := v.(type) {
+		case map[any]any, map[string]any:
+			return true
+		case string:
+			var object map[string]any
+			return json.Unmarshal([]byte(value), &object) == nil && object != nil
+		}
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// toList returns the given value as list. Strings are lists if they contain a JSON array, like resolved list parameters.
+func toList(v any) ([]any, bool) {
+	switch l := v.(type) {
+	case []any:
+		return l, true
+	case []string:
+		list := make([]any, len(l))
+		for i, s := range l {
+			list[i] = s
+		}
+		return list, true
+	case string:
+		var list []any
+		if err := json.Unmarshal([]byte(l), &list); err == nil {
+			return list, true
+		}
+	}
+	return nil, false
+}
+
+// isOneOf compares values by their string representation, so that e.g. the string "5" of an environment variable
+// matches the allowed value 5.
+func isOneOf(v any, allowed []any) bool {
+	s := fmt.Sprint(v)
+	for _, a := range allowed {
+		if fmt.Sprint(a) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// measureOf returns what min and max are compared to: The value of numbers and the length of strings and lists, along
+// with a prefix describing the measure in errors.
+func measureOf(t ParameterValueType, v any) (float64, string, bool) {
+	if f, ok := toFloat(v); ok {
+		return f, "", true
+	}
+
+	s, isString := v.(string)
+	if isString && (t == IntType || t == NumberType) {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		return f, "", err == nil
+	}
+	if l, ok := toList(v); ok && (!isString || t == ListType) {
+		return float64(len(l)), "length of ", true
+	}
+	if isString {
+		return float64(utf8.RuneCountInString(s)), "length of ", true
+	}
+	return 0, "", false
+}
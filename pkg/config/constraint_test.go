@@ -0,0 +1,92 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+)
+
+func TestParameterConstraint_Check(t *testing.T) {
+	one, three := 1.0, 3.0
+
+	tests := []struct {
+		name       string
+		constraint ParameterConstraint
+		value      any
+		wantErr    string
+	}{
+		{"no constraint", ParameterConstraint{}, "anything", ""},
+		{"string", ParameterConstraint{Type: StringType}, "text", ""},
+		{"string mismatch", ParameterConstraint{Type: StringType}, 5, "is not of type string"},
+		{"int", ParameterConstraint{Type: IntType}, 5, ""},
+		{"int from float", ParameterConstraint{Type: IntType}, 5.0, ""},
+		{"int from string", ParameterConstraint{Type: IntType}, "42", ""},
+		{"int mismatch", ParameterConstraint{Type: IntType}, "five", "value \"five\" is not of type int"},
+		{"int mismatch fraction", ParameterConstraint{Type: IntType}, 1.5, "is not of type int"},
+		{"number", ParameterConstraint{Type: NumberType}, "1.5", ""},
+		{"bool", ParameterConstraint{Type: BoolType}, true, ""},
+		{"bool from string", ParameterConstraint{Type: BoolType}, "false", ""},
+		{"bool mismatch", ParameterConstraint{Type: BoolType}, "yes", "is not of type bool"},
+		{"list", ParameterConstraint{Type: ListType}, []any{"a"}, ""},
+		{"list from resolved list parameter", ParameterConstraint{Type: ListType}, `[ "a", "b" ]`, ""},
+		{"list mismatch", ParameterConstraint{Type: ListType}, "a", "is not of type list"},
+		{"object from resolved object parameter", ParameterConstraint{Type: ObjectType}, `{"team":"a"}`, ""},
+		{"object mismatch", ParameterConstraint{Type: ObjectType}, "null", "is not of type object"},
+		{"enum", ParameterConstraint{Enum: []any{"info", "warn"}}, "warn", ""},
+		{"enum number from string", ParameterConstraint{Enum: []any{1, 2}}, "2", ""},
+		{"enum mismatch", ParameterConstraint{Enum: []any{"info", "warn"}}, "debug", "is not one of the allowed values [info warn]"},
+		{"pattern", ParameterConstraint{Pattern: regexp.MustCompile(`^[a-z-]+$`)}, "my-name", ""},
+		{"pattern on unescaped value", ParameterConstraint{Pattern: regexp.MustCompile(`^"\w+"$`)}, `\"quoted\"`, ""},
+		{"pattern mismatch", ParameterConstraint{Pattern: regexp.MustCompile(`^[a-z-]+$`)}, "My Name", "does not match pattern"},
+		{"pattern requires string", ParameterConstraint{Pattern: regexp.MustCompile(`^\d+$`)}, 5, "must be a string"},
+		{"min and max", ParameterConstraint{Min: &one, Max: &three}, 2, ""},
+		{"min violated", ParameterConstraint{Min: &one}, 0, "value \"0\" is less than the minimum of 1"},
+		{"max violated", ParameterConstraint{Max: &three}, 3.5, "is greater than the maximum of 3"},
+		{"max of numeric string", ParameterConstraint{Type: IntType, Max: &three}, "4", "is greater than the maximum of 3"},
+		{"max length of string", ParameterConstraint{Max: &three}, "abcd", "length of value \"abcd\" is greater than the maximum of 3"},
+		{"min length of list", ParameterConstraint{Type: ListType, Min: &one}, "[]", "length of value \"[]\" is less than the minimum of 1"},
+		{"min and max require measurable value", ParameterConstraint{Min: &one}, true, "must be a number, string or list"},
+		{"non-empty", ParameterConstraint{NonEmpty: true}, "value", ""},
+		{"non-empty violated by blank string", ParameterConstraint{NonEmpty: true}, "  ", "must not be empty"},
+		{"non-empty violated by null", ParameterConstraint{NonEmpty: true}, nil, "must not be empty"},
+		{"non-empty violated by empty list", ParameterConstraint{NonEmpty: true}, []any{}, "must not be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constraint.Check(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParameterConstraint_Check_DoesNotRevealSecrets(t *testing.T) {
+	err := ParameterConstraint{Enum: []any{"a"}}.Check(secret.MaskedString("s3cr3t"))
+
+	assert.ErrorContains(t, err, "secret value is not one of the allowed values")
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
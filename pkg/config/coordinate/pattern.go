@@ -0,0 +1,69 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coordinate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pattern matches coordinates using glob patterns for their project, type and config ID. A '*' matches any sequence
+// of characters, and a '?' matches any single character.
+type Pattern struct {
+	raw                    string
+	project, typ, configID *regexp.Regexp
+}
+
+// ParsePattern parses a pattern of the form "<project>:<type>:<configId>", e.g. "infra:builtin:alerting.profile:*".
+// As types may contain colons themselves, the first part is the project, the last part is the config ID, and
+// everything in between is the type.
+func ParsePattern(s string) (Pattern, error) {
+	first := strings.Index(s, ":")
+	last := strings.LastIndex(s, ":")
+	if first < 0 || first == last {
+		return Pattern{}, fmt.Errorf("invalid coordinate pattern %q: expected format '<project>:<type>:<configId>'", s)
+	}
+
+	project, typ, configID := s[:first], s[first+1:last], s[last+1:]
+	if project == "" || typ == "" || configID == "" {
+		return Pattern{}, fmt.Errorf("invalid coordinate pattern %q: project, type and config ID must not be empty", s)
+	}
+
+	return Pattern{
+		raw:      s,
+		project:  globToRegexp(project),
+		typ:      globToRegexp(typ),
+		configID: globToRegexp(configID),
+	}, nil
+}
+
+func globToRegexp(glob string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(glob)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("^" + quoted + "$")
+}
+
+// Match tests if the given coordinate matches the pattern.
+func (p Pattern) Match(c Coordinate) bool {
+	return p.project.MatchString(c.Project) && p.typ.MatchString(c.Type) && p.configID.MatchString(c.ConfigId)
+}
+
+func (p Pattern) String() string {
+	return p.raw
+}
@@ -0,0 +1,84 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package coordinate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		matches []Coordinate
+		misses  []Coordinate
+	}{
+		{
+			pattern: "infra:builtin:alerting.profile:*",
+			matches: []Coordinate{
+				{Project: "infra", Type: "builtin:alerting.profile", ConfigId: "profile"},
+				{Project: "infra", Type: "builtin:alerting.profile", ConfigId: ""},
+			},
+			misses: []Coordinate{
+				{Project: "infra", Type: "builtin:alerting.maintenance-window", ConfigId: "profile"},
+				{Project: "other", Type: "builtin:alerting.profile", ConfigId: "profile"},
+			},
+		},
+		{
+			pattern: "*:dashboard:team-a-*",
+			matches: []Coordinate{
+				{Project: "p1", Type: "dashboard", ConfigId: "team-a-overview"},
+				{Project: "p2", Type: "dashboard", ConfigId: "team-a-"},
+			},
+			misses: []Coordinate{
+				{Project: "p1", Type: "dashboard", ConfigId: "team-b-overview"},
+				{Project: "p1", Type: "document", ConfigId: "team-a-overview"},
+			},
+		},
+		{
+			pattern: "p?:*:config.1",
+			matches: []Coordinate{{Project: "p1", Type: "builtin:tags.auto-tagging", ConfigId: "config.1"}},
+			misses:  []Coordinate{{Project: "p10", Type: "dashboard", ConfigId: "config.1"}, {Project: "p1", Type: "dashboard", ConfigId: "configX1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			p, err := ParsePattern(tt.pattern)
+			require.NoError(t, err)
+			assert.Equal(t, tt.pattern, p.String())
+
+			for _, c := range tt.matches {
+				assert.True(t, p.Match(c), c.String())
+			}
+			for _, c := range tt.misses {
+				assert.False(t, p.Match(c), c.String())
+			}
+		})
+	}
+}
+
+func TestParsePattern_Invalid(t *testing.T) {
+	for _, s := range []string{"", "project", "project:type", ":type:id", "project::id", "project:type:"} {
+		_, err := ParsePattern(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -52,20 +52,28 @@ func (r *EntityMap) Get() map[coordinate.Coordinate]ResolvedEntity {
 	return entityCopy
 }
 
+// GetResolvedProperty returns the value at the given PropertyPath of the entity resolved for the given coordinate.
+// The path is resolved within the properties of the entity first, and within its Response if it is not found there.
 func (r *EntityMap) GetResolvedProperty(coordinate coordinate.Coordinate, propertyName string) (any, bool) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
-	if e, f := r.resolvedEntities[coordinate]; f {
-
-		m := make(map[any]any)
-		for k, v := range e.Properties {
-			m[k] = v
-		}
+	e, f := r.resolvedEntities[coordinate]
+	if !f {
+		return nil, false
+	}
 
-		return ResolvePropValue(propertyName, m)
+	path, err := ParsePropertyPath(propertyName)
+	if err != nil {
+		return nil, false
 	}
 
+	if v, found := path.Resolve(e.Properties); found {
+		return v, true
+	}
+	if e.Response != nil {
+		return path.Resolve(e.Response)
+	}
 	return nil, false
 }
 
@@ -82,3 +82,36 @@ func TestEntityMap_PutResolved(t *testing.T) {
 	})
 
 }
+
+func TestEntityMap_GetResolvedProperty(t *testing.T) {
+	c := coordinate.Coordinate{Project: "project", Type: "type", ConfigId: "configID"}
+
+	entityMap := New()
+	entityMap.Put(ResolvedEntity{
+		Coordinate: c,
+		Properties: map[string]any{"id": "object-id", "name": "property name"},
+		Response:   map[string]any{"name": "response name", "rules": []any{map[string]any{"id": "rule-id"}}},
+	})
+
+	tests := []struct {
+		property string
+		want     any
+		found    bool
+	}{
+		{"id", "object-id", true},
+		{"name", "property name", true},
+		{"rules[0].id", "rule-id", true},
+		{"rules[1].id", nil, false},
+		{"rules[", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			got, found := entityMap.GetResolvedProperty(c, tt.property)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, found := entityMap.GetResolvedProperty(coordinate.Coordinate{Project: "other"}, "id")
+	assert.False(t, found)
+}
@@ -0,0 +1,167 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// PathSegment is a single step of a PropertyPath. It either accesses the property Key of an object, or the element at
+// Index of a list.
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// PropertyPath is a path to a nested property, e.g. `rules[0].id`. Properties are separated by dots, list elements
+// are accessed by their index in brackets. Properties containing dots or brackets can be accessed in quoted form,
+// e.g. `labels["app.kubernetes.io/name"]`.
+type PropertyPath []PathSegment
+
+// ParsePropertyPath parses the given path, returning an error if it is malformed.
+func ParsePropertyPath(path string) (PropertyPath, error) {
+	var segments PropertyPath
+	pos := 0
+	expectKey := true
+	for pos < len(path) {
+		switch path[pos] {
+		case '.':
+			if expectKey {
+				return nil, fmt.Errorf("invalid property path %q: empty property name at position %d", path, pos)
+			}
+			expectKey = true
+			pos++
+		case '[':
+			if expectKey && len(segments) > 0 {
+				return nil, fmt.Errorf("invalid property path %q: empty property name at position %d", path, pos)
+			}
+			end := strings.IndexByte(path[pos:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid property path %q: missing ']' for '[' at position %d", path, pos)
+			}
+			segment, err := parseBracketSegment(path[pos+1 : pos+end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid property path %q: %w at position %d", path, err, pos)
+			}
+			segments = append(segments, segment)
+			expectKey = false
+			pos += end + 1
+		default:
+			if !expectKey {
+				return nil, fmt.Errorf("invalid property path %q: expected '.' or '[' at position %d", path, pos)
+			}
+			end := strings.IndexAny(path[pos:], ".[]")
+			if end < 0 {
+				end = len(path) - pos
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid property path %q: unexpected ']' at position %d", path, pos)
+			}
+			segments = append(segments, PathSegment{Key: path[pos : pos+end]})
+			expectKey = false
+			pos += end
+		}
+	}
+
+	if expectKey {
+		return nil, fmt.Errorf("invalid property path %q: path must not be empty or end with '.'", path)
+	}
+	return segments, nil
+}
+
+// parseBracketSegment parses the content of brackets, which is either a list index or a quoted property name.
+func parseBracketSegment(content string) (PathSegment, error) {
+	if len(content) >= 2 && (content[0] == '"' || content[0] == '\'') && content[len(content)-1] == content[0] {
+		return PathSegment{Key: content[1 : len(content)-1]}, nil
+	}
+
+	index, err := strconv.Atoi(content)
+	if err != nil || index < 0 {
+		return PathSegment{}, fmt.Errorf("expected list index or quoted property name in brackets, but got %q", content)
+	}
+	return PathSegment{Index: index, IsIndex: true}, nil
+}
+
+// Resolve returns the value at the path within the given value, which may consist of nested maps and slices as
+// created when unmarshalling YAML or JSON.
+func (p PropertyPath) Resolve(v any) (any, bool) {
+	current := v
+	for _, segment := range p {
+		var found bool
+		if segment.IsIndex {
+			current, found = elementAt(current, segment.Index)
+		} else {
+			current, found = propertyOf(current, segment.Key)
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func propertyOf(v any, key string) (any, bool) {
+	var value any
+	var found bool
+	switch m := v.(type) {
+	case map[any]any:
+		value, found = m[key]
+	case map[string]any:
+		value, found = m[key]
+	case parameter.Properties:
+		value, found = m[key]
+	}
+	return value, found
+}
+
+func elementAt(v any, index int) (any, bool) {
+	switch l := v.(type) {
+	case []any:
+		if index < len(l) {
+			return l[index], true
+		}
+	case []string:
+		if index < len(l) {
+			return l[index], true
+		}
+	}
+	return nil, false
+}
+
+// String returns the path in the form it is parsed from.
+func (p PropertyPath) String() string {
+	var sb strings.Builder
+	for i, segment := range p {
+		switch {
+		case segment.IsIndex:
+			sb.WriteString("[" + strconv.Itoa(segment.Index) + "]")
+		case strings.ContainsAny(segment.Key, ".[]"):
+			sb.WriteString(`["` + segment.Key + `"]`)
+		default:
+			if i > 0 {
+				sb.WriteString(".")
+			}
+			sb.WriteString(segment.Key)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePropertyPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want PropertyPath
+	}{
+		{"id", PropertyPath{{Key: "id"}}},
+		{"keys.key", PropertyPath{{Key: "keys"}, {Key: "key"}}},
+		{"rules[0].id", PropertyPath{{Key: "rules"}, {Index: 0, IsIndex: true}, {Key: "id"}}},
+		{"matrix[1][2]", PropertyPath{{Key: "matrix"}, {Index: 1, IsIndex: true}, {Index: 2, IsIndex: true}}},
+		{`labels["app.kubernetes.io/name"]`, PropertyPath{{Key: "labels"}, {Key: "app.kubernetes.io/name"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := ParsePropertyPath(tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.path, got.String())
+		})
+	}
+}
+
+func TestParsePropertyPath_SingleQuotedKey(t *testing.T) {
+	got, err := ParsePropertyPath(`labels['x'].y`)
+	require.NoError(t, err)
+	assert.Equal(t, PropertyPath{{Key: "labels"}, {Key: "x"}, {Key: "y"}}, got)
+	assert.Equal(t, "labels.x.y", got.String())
+}
+
+func TestParsePropertyPath_Errors(t *testing.T) {
+	for _, path := range []string{"", "a.", ".a", "a..b", "a.[0]", "a[", "a[x]", "a[-1]", "a]", "a[0]b"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := ParsePropertyPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPropertyPath_Resolve(t *testing.T) {
+	value := map[string]any{
+		"rules": []any{
+			map[string]any{"id": "rule-1"},
+			map[any]any{"id": "rule-2"},
+		},
+		"table": "logs",
+	}
+
+	tests := []struct {
+		path  string
+		want  any
+		found bool
+	}{
+		{"table", "logs", true},
+		{"rules[0].id", "rule-1", true},
+		{"rules[1].id", "rule-2", true},
+		{"rules[2].id", nil, false},
+		{"rules.id", nil, false},
+		{"table[0]", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := ParsePropertyPath(tt.path)
+			require.NoError(t, err)
+
+			got, found := path.Resolve(value)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
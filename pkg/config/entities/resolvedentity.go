@@ -17,8 +17,6 @@
 package entities
 
 import (
-	str "strings"
-
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 )
@@ -34,33 +32,23 @@ type ResolvedEntity struct {
 	// Skip flag indicating that this entity was skipped
 	// if an entity is skipped, there will be no properties
 	Skip bool
+
+	// Response is the decoded JSON payload of the deployed object, as returned by the API or, if the API does not
+	// return it, as it was sent. Nested values of it can be referenced by other configs, e.g. `rules[0].id`.
+	Response any
 }
 
 // ResolvePropValue retrieves the value associated with the specified key in a nested map.
-
-// The ResolvePropValue function is designed to work with nested maps where keys are
-// structured using dots to represent nested levels. It recursively traverses the
-// nested maps to find the value associated with the specified key.
+//
+// The key is a PropertyPath, e.g. `first.second` or `rules[0].id`, which is used to traverse
+// nested maps and lists to find the value associated with it.
 //
 // If the key is found, the function returns the associated value and true. If the
-// key is not found, it returns nil and false.
+// key is not found or is not a valid PropertyPath, it returns nil and false.
 func ResolvePropValue(key string, props map[any]any) (any, bool) {
-	first, rest, _ := str.Cut(key, ".")
-	if p, f := props[first]; f {
-		if rest == "" {
-			return p, true
-		}
-		if valMap, ok := p.(map[any]any); ok {
-			// If the value is a map, recursively call ResolvePropValue
-			if str.Contains(rest, ".") {
-				return ResolvePropValue(rest, valMap)
-			}
-			// Otherwise, check if the rest of the key exists in the nested map
-			if val, found := valMap[rest]; found {
-				return val, true
-			}
-		}
+	path, err := ParsePropertyPath(key)
+	if err != nil {
+		return nil, false
 	}
-	// Key not found
-	return nil, false
+	return path.Resolve(props)
 }
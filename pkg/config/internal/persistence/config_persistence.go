@@ -27,11 +27,30 @@ type EnvironmentOverride struct {
 }
 
 type ConfigDefinition struct {
-	Name           ConfigParameter            `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=The name of this configuration - required for Classic Config API types."`
-	Parameters     map[string]ConfigParameter `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters for this configuration."`
-	Template       string                     `yaml:"template,omitempty" json:"template,omitempty" jsonschema:"required,description=The filepath to the JSON template used for this configuration"`
-	Skip           ConfigParameter            `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"description=Defines whether this config should be skipped when deploying."`
-	OriginObjectId string                     `yaml:"originObjectId,omitempty" json:"originObjectId,omitempty" jsonschema:"description=description=The identifier of the Dynatrace object this config originated from - this is filled when downloading, but can also be set to tie a config to a specific object."`
+	Name           ConfigParameter                 `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=The name of this configuration - required for Classic Config API types."`
+	Parameters     map[string]ConfigParameter      `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters for this configuration."`
+	Template       string                          `yaml:"template,omitempty" json:"template,omitempty" jsonschema:"required,description=The filepath to the JSON template used for this configuration"`
+	Skip           ConfigParameter                 `yaml:"skip,omitempty" json:"skip,omitempty" jsonschema:"description=Defines whether this config should be skipped when deploying."`
+	OriginObjectId string                          `yaml:"originObjectId,omitempty" json:"originObjectId,omitempty" jsonschema:"description=description=The identifier of the Dynatrace object this config originated from - this is filled when downloading, but can also be set to tie a config to a specific object."`
+	Deployment     *DeploymentDefinition           `yaml:"deployment,omitempty" json:"deployment,omitempty" jsonschema:"description=Defines how this configuration is deployed, e.g. how often a failed deployment is retried."`
+	Constraints    map[string]ConstraintDefinition `yaml:"constraints,omitempty" json:"constraints,omitempty" jsonschema:"description=Constraints the resolved values of parameters must satisfy, by parameter name. Overrides replace the constraints of each parameter they define."`
+}
+
+// ConstraintDefinition declares the values a parameter may resolve to. All defined constraints must be satisfied.
+type ConstraintDefinition struct {
+	Type     string        `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=string,enum=int,enum=number,enum=bool,enum=list,enum=object,description=The type the value must be of. Strings representing a value of the type are accepted as well."`
+	Enum     []interface{} `yaml:"enum,omitempty" json:"enum,omitempty" jsonschema:"description=The allowed values."`
+	Pattern  string        `yaml:"pattern,omitempty" json:"pattern,omitempty" jsonschema:"description=A regular expression string values must match."`
+	Min      *float64      `yaml:"min,omitempty" json:"min,omitempty" jsonschema:"description=The minimum value of numbers, or the minimum length of strings and lists."`
+	Max      *float64      `yaml:"max,omitempty" json:"max,omitempty" jsonschema:"description=The maximum value of numbers, or the maximum length of strings and lists."`
+	NonEmpty bool          `yaml:"nonEmpty,omitempty" json:"nonEmpty,omitempty" jsonschema:"description=Whether the value must not be null or an empty string or list."`
+}
+
+// DeploymentDefinition defines how a config is deployed. Overrides replace each field they define individually.
+type DeploymentDefinition struct {
+	Retries   *int   `yaml:"retries,omitempty" json:"retries,omitempty" jsonschema:"minimum=0,description=The number of times each failed request deploying this configuration is retried, e.g. 0 to fail fast. Only supported for classic and Settings 2.0 configurations. Defaults to the retries of the client."`
+	Timeout   string `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"description=The maximum duration of the deployment of this configuration including all retries, e.g. '30s' or '5m'. Unlimited if not set."`
+	WaitAfter string `yaml:"waitAfter,omitempty" json:"waitAfter,omitempty" jsonschema:"description=The duration to wait after this configuration was deployed before configurations depending on it are deployed, e.g. '30s'."`
 }
 
 type TopLevelConfigDefinition struct {
@@ -42,6 +61,36 @@ type TopLevelConfigDefinition struct {
 	GroupOverrides []GroupOverride `yaml:"groupOverrides,omitempty" json:"groupOverrides,omitempty" jsonschema:"description=GroupOverrides overwrite specific parts of the Config when deploying it to any environment in a given group."`
 	// EnvironmentOverrides overwrite specific parts of the Config when deploying it to a given environment
 	EnvironmentOverrides []EnvironmentOverride `yaml:"environmentOverrides,omitempty" json:"environmentOverrides,omitempty" jsonschema:"description=EnvironmentOverrides overwrite specific parts of the Config when deploying it to a given environment."`
+	// Extends references the config definition this config inherits from
+	Extends *ExtendsDefinition `yaml:"extends,omitempty" json:"extends,omitempty" jsonschema:"oneof_type=string;object,description=The ID of a configuration in the same project, or the project and ID of a configuration in another project, this configuration inherits its type, configuration and overrides from. Everything defined by this configuration takes precedence."`
+	// ForEach expands the config into one config per item
+	ForEach *ForEachDefinition `yaml:"forEach,omitempty" json:"forEach,omitempty" jsonschema:"description=Expands this configuration into one configuration per item, with the ID '<id>-<key>' and the properties of the item as parameters."`
+}
+
+// ExtendsDefinition references the config definition a config extends. It can be defined as just the ID of a config
+// in the same project, or as a map containing the project and configId.
+type ExtendsDefinition struct {
+	Project  string `yaml:"project,omitempty" json:"project,omitempty" jsonschema:"description=The project of the extended configuration. Defaults to the project of this configuration."`
+	ConfigId string `yaml:"configId" json:"configId" jsonschema:"required,description=The ID of the extended configuration."`
+}
+
+// UnmarshalYAML allows to define an ExtendsDefinition by just the ID of the extended config.
+func (e *ExtendsDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var configId string
+	if err := unmarshal(&configId); err == nil {
+		e.ConfigId = configId
+		return nil
+	}
+
+	type plain ExtendsDefinition
+	return unmarshal((*plain)(e))
+}
+
+// ForEachDefinition defines the items a config is expanded for. Either Items or File must be set.
+type ForEachDefinition struct {
+	Items []interface{} `yaml:"items,omitempty" json:"items,omitempty" jsonschema:"description=The items to expand the configuration for. Each item is a map of parameters."`
+	File  string        `yaml:"file,omitempty" json:"file,omitempty" jsonschema:"description=The path to a YAML or JSON file containing a list of items, relative to the config file."`
+	Key   string        `yaml:"key,omitempty" json:"key,omitempty" jsonschema:"description=The property of each item identifying it, which is appended to the ID of the configuration. Defaults to 'key'."`
 }
 
 type TopLevelDefinition struct {
@@ -49,3 +98,24 @@ type TopLevelDefinition struct {
 }
 
 type ConfigParameter interface{}
+
+// SharedParametersFileName is the name of the file in the root folder of a project defining the parameters inherited
+// by all configs of the project.
+const SharedParametersFileName = "_parameters.yaml"
+
+// SharedParametersDefinition defines parameters inherited by all configs of a project.
+type SharedParametersDefinition struct {
+	Parameters           map[string]ConfigParameter            `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"description=Parameters inherited by all configurations of the project. Parameters defined by a configuration take precedence."`
+	GroupOverrides       []SharedParametersGroupOverride       `yaml:"groupOverrides,omitempty" json:"groupOverrides,omitempty" jsonschema:"description=Parameters overwriting the shared parameters for any environment in a given group."`
+	EnvironmentOverrides []SharedParametersEnvironmentOverride `yaml:"environmentOverrides,omitempty" json:"environmentOverrides,omitempty" jsonschema:"description=Parameters overwriting the shared parameters for a given environment."`
+}
+
+type SharedParametersGroupOverride struct {
+	Group      string                     `yaml:"group" json:"group" jsonschema:"required,description=Name of the group this override applies for."`
+	Parameters map[string]ConfigParameter `yaml:"parameters" json:"parameters" jsonschema:"required,description=Parameters overwriting the shared parameters for any environment in this group."`
+}
+
+type SharedParametersEnvironmentOverride struct {
+	Environment string                     `yaml:"environment" json:"environment" jsonschema:"required,description=Name of the environment this override applies for."`
+	Parameters  map[string]ConfigParameter `yaml:"parameters" json:"parameters" jsonschema:"required,description=Parameters overwriting the shared parameters for this environment."`
+}
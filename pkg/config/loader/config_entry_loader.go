@@ -20,8 +20,10 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
+	"time"
 
 	"github.com/spf13/afero"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
@@ -43,6 +46,11 @@ func parseConfigEntry(
 	definition persistence.TopLevelConfigDefinition,
 ) ([]config.Config, []error) {
 
+	definition, extendedFiles, err := resolveExtends(loaderContext, definition)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, &singleConfigEntryLoadContext{configFileLoaderContext: loaderContext}, err.Error())}
+	}
+
 	if definition.Type == (persistence.TypeDefinition{}) {
 		return nil, []error{errors.New("missing type definition")}
 	}
@@ -62,18 +70,28 @@ func parseConfigEntry(
 	warnForUndefinedEnvironments(loaderContext, definition.EnvironmentOverrides)
 	environmentOverrideMap := toEnvironmentOverrideMap(definition.EnvironmentOverrides)
 
+	definitions, err := expandForEach(fs, singleConfigContext, definition)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	additionalSourceFiles := additionalSourceFiles(loaderContext, definition, extendedFiles)
+
 	var results []config.Config
 	var errs []error
-	for _, env := range loaderContext.Environments.SelectedEnvironments {
+	for _, d := range definitions {
+		for _, env := range loaderContext.Environments.SelectedEnvironments {
 
-		result, definitionErrors := parseDefinitionForEnvironment(fs, singleConfigContext, configId, env, definition, groupOverrideMap, environmentOverrideMap)
+			result, definitionErrors := parseDefinitionForEnvironment(fs, singleConfigContext, d.Id, env, d, groupOverrideMap, environmentOverrideMap)
 
-		if definitionErrors != nil {
-			errs = append(errs, definitionErrors...)
-			continue
-		}
+			if definitionErrors != nil {
+				errs = append(errs, definitionErrors...)
+				continue
+			}
 
-		results = append(results, result)
+			result.AdditionalSourceFiles = additionalSourceFiles
+			results = append(results, result)
+		}
 	}
 
 	if len(errs) != 0 {
@@ -83,6 +101,21 @@ func parseConfigEntry(
 	return results, nil
 }
 
+// additionalSourceFiles returns the paths of all files, other than the config file itself, which define parts of the
+// configs loaded from the given definition.
+func additionalSourceFiles(context *configFileLoaderContext, definition persistence.TopLevelConfigDefinition, extendedFiles []string) []string {
+	var files []string
+	if context.SharedParameters != nil {
+		files = append(files, context.SharedParameters.path)
+	}
+	if definition.ForEach != nil {
+		if f := forEachFile(context, *definition.ForEach); f != "" {
+			files = append(files, f)
+		}
+	}
+	return append(files, extendedFiles...)
+}
+
 func warnForUndefinedGroups(loaderContext *configFileLoaderContext, groupOverrides []persistence.GroupOverride) {
 	for _, group := range groupOverrides {
 		if _, exists := loaderContext.Environments.AllGroupNames[group.Group]; !exists {
@@ -130,7 +163,7 @@ func parseDefinitionForEnvironment(
 ) (config.Config, []error) {
 
 	configDefinition := persistence.ConfigDefinition{
-		Parameters:     make(map[string]persistence.ConfigParameter),
+		Parameters:     context.SharedParameters.forEnvironment(environment),
 		OriginObjectId: definition.Config.OriginObjectId,
 	}
 
@@ -170,6 +203,34 @@ func applyOverrides(base *persistence.ConfigDefinition, override persistence.Con
 		base.Parameters[name] = param
 	}
 
+	if override.Deployment != nil {
+		applyDeploymentOverrides(base, *override.Deployment)
+	}
+
+	for name, constraint := range override.Constraints {
+		if base.Constraints == nil {
+			base.Constraints = make(map[string]persistence.ConstraintDefinition)
+		}
+		base.Constraints[name] = constraint
+	}
+}
+
+func applyDeploymentOverrides(base *persistence.ConfigDefinition, override persistence.DeploymentDefinition) {
+	if base.Deployment == nil {
+		base.Deployment = &persistence.DeploymentDefinition{}
+	}
+
+	if override.Retries != nil {
+		base.Deployment.Retries = override.Retries
+	}
+
+	if override.Timeout != "" {
+		base.Deployment.Timeout = override.Timeout
+	}
+
+	if override.WaitAfter != "" {
+		base.Deployment.WaitAfter = override.WaitAfter
+	}
 }
 
 func getConfigFromDefinition(
@@ -203,6 +264,14 @@ func getConfigFromDefinition(
 		parameters = make(map[string]parameter.Parameter)
 	}
 
+	var deploymentPolicy *config.DeploymentPolicy
+	if definition.Deployment != nil {
+		deploymentPolicy, err = parseDeploymentPolicy(*definition.Deployment, configType.Type.ID())
+		if err != nil {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("invalid `deployment`: %s", err)))
+		}
+	}
+
 	skipConfig := false
 
 	if definition.Skip != nil {
@@ -230,6 +299,9 @@ func getConfigFromDefinition(
 		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, "missing parameter `name`"))
 	}
 
+	constraints, constraintErrs := parseParameterConstraints(context, environment, configId, definition.Constraints, parameters)
+	errs = append(errs, constraintErrs...)
+
 	if errs != nil {
 		return config.Config{}, errs
 	}
@@ -272,6 +344,10 @@ func getConfigFromDefinition(
 		Parameters:     parameters,
 		Skip:           skipConfig,
 		OriginObjectId: definition.OriginObjectId,
+		SourceFile:     context.Path,
+		Deployment:     deploymentPolicy,
+
+		ParameterConstraints: constraints,
 	}, nil
 }
 
@@ -311,3 +387,112 @@ func parseSkip(fs afero.Fs,
 
 	return retVal, err
 }
+
+func parseDeploymentPolicy(definition persistence.DeploymentDefinition, typeID config.TypeID) (*config.DeploymentPolicy, error) {
+	var policy config.DeploymentPolicy
+
+	if definition.Retries != nil {
+		if *definition.Retries < 0 {
+			return nil, fmt.Errorf("`retries` must not be negative, but is %d", *definition.Retries)
+		}
+		// only the clients of classic configs and settings support retrying the requests of a single deployment
+		if typeID != config.ClassicApiTypeID && typeID != config.SettingsTypeID {
+			return nil, fmt.Errorf("`retries` is only supported for classic and Settings 2.0 configurations, but not for type %q", typeID)
+		}
+		retries := *definition.Retries
+		policy.Retries = &retries
+	}
+
+	var err error
+	if policy.Timeout, err = parseDeploymentDuration("timeout", definition.Timeout); err != nil {
+		return nil, err
+	}
+	if policy.WaitAfter, err = parseDeploymentDuration("waitAfter", definition.WaitAfter); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+func parseDeploymentDuration(property string, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("`%s` must be a duration like '30s' or '5m': %w", property, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("`%s` must not be negative, but is %s", property, value)
+	}
+	return d, nil
+}
+
+// parseParameterConstraints parses the constraint definitions of a config. Constraints of value parameters are checked
+// right away, as their values are known when loading.
+func parseParameterConstraints(context *singleConfigEntryLoadContext, environment manifest.EnvironmentDefinition, configId string,
+	definitions map[string]persistence.ConstraintDefinition, parameters config.Parameters) (map[string]config.ParameterConstraint, []error) {
+
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+
+	constraints := make(map[string]config.ParameterConstraint, len(definitions))
+	var errs []error
+	for name, definition := range definitions {
+		param, found := parameters[name]
+		if !found {
+			errs = append(errs, newParameterDefinitionParserError(name, configId, context, environment, "constraint defined for unknown parameter"))
+			continue
+		}
+
+		constraint, err := parseParameterConstraint(definition)
+		if err != nil {
+			errs = append(errs, newParameterDefinitionParserError(name, configId, context, environment, fmt.Sprintf("invalid constraint: %s", err)))
+			continue
+		}
+
+		if v, ok := param.(*valueParam.ValueParameter); ok {
+			resolved, err := v.ResolveValue(parameter.ResolveContext{ParameterName: name})
+			if err == nil {
+				err = constraint.Check(resolved)
+			}
+			if err != nil {
+				errs = append(errs, newParameterDefinitionParserError(name, configId, context, environment, err.Error()))
+				continue
+			}
+		}
+
+		constraints[name] = constraint
+	}
+	return constraints, errs
+}
+
+func parseParameterConstraint(definition persistence.ConstraintDefinition) (config.ParameterConstraint, error) {
+	constraint := config.ParameterConstraint{
+		Type:     config.ParameterValueType(definition.Type),
+		Enum:     definition.Enum,
+		Min:      definition.Min,
+		Max:      definition.Max,
+		NonEmpty: definition.NonEmpty,
+	}
+
+	if constraint.Type != "" && !slices.Contains(config.ParameterValueTypes, constraint.Type) {
+		return config.ParameterConstraint{}, fmt.Errorf("unknown type `%s`, must be one of %v", definition.Type, config.ParameterValueTypes)
+	}
+
+	if definition.Pattern != "" {
+		pattern, err := regexp.Compile(definition.Pattern)
+		if err != nil {
+			return config.ParameterConstraint{}, fmt.Errorf("`pattern` is not a valid regular expression: %w", err)
+		}
+		constraint.Pattern = pattern
+	}
+
+	if constraint.Min != nil && constraint.Max != nil && *constraint.Min > *constraint.Max {
+		return config.ParameterConstraint{}, fmt.Errorf("`min` (%v) must not be greater than `max` (%v)", *constraint.Min, *constraint.Max)
+	}
+
+	return constraint, nil
+}
@@ -41,6 +41,12 @@ type LoaderContext struct {
 	Environments    manifest.Environments
 	KnownApis       map[string]struct{}
 	ParametersSerDe map[string]parameter.ParameterSerDe
+	// SharedParameters are inherited by all configs loaded with this context, if set
+	SharedParameters *SharedParameters
+	// ConfigDefinitions are used to look up the definitions configs extend, if set
+	ConfigDefinitions *ConfigDefinitionIndex
+	// ForEachFiles are the item files referenced by the forEach of configs, which are skipped when loading config files
+	ForEachFiles map[string]struct{}
 }
 
 // configFileLoaderContext is a context for each config-file
@@ -68,6 +74,11 @@ func LoadConfigFile(ctx context.Context, fs afero.Fs, context *LoaderContext, fi
 	// that the user tries to deploy monaco v1 configuration using monaco v2.
 	var content map[string]any
 	if err := yaml.Unmarshal(data, &content); err != nil {
+		// files containing a list can not be config files, but may contain the items of a config's forEach
+		if _, found := context.ForEachFiles[filepath.Clean(filePath)]; found {
+			log.WithFields(field.F("file", filePath)).DebugContext(ctx, "File %q contains the items of a forEach, skipping loading", filePath)
+			return []config.Config{}, nil
+		}
 		return nil, []error{newLoadError(filePath, err)}
 	}
 	if content["config"] != nil {
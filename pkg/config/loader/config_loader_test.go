@@ -20,8 +20,10 @@ package loader
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
@@ -1281,6 +1283,406 @@ configs:
 				},
 			},
 		},
+		{
+			name:             "loads config with deployment policy and overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      retries: 3
+      timeout: 5m
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      schemaVersion: '1.0'
+      scope: 'tenant'
+  groupOverrides:
+    - group: default
+      override:
+        deployment:
+          timeout: 1m
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        deployment:
+          waitAfter: 30s`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId:      "builtin:profile.test",
+						SchemaVersion: "1.0",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Environment: "env name",
+					Group:       "default",
+					Deployment: &config.DeploymentPolicy{
+						Retries:   pointer.Pointer(3),
+						Timeout:   time.Minute,
+						WaitAfter: 30 * time.Second,
+					},
+				},
+			},
+		},
+		{
+			name:             "reports error on invalid deployment policy",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      timeout: forever
+  type: some-api
+`,
+			wantErrorsContain: []string{"`timeout` must be a duration"},
+		},
+		{
+			name:             "reports error on negative deployment retries",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    deployment:
+      retries: -1
+  type: some-api
+`,
+			wantErrorsContain: []string{"`retries` must not be negative"},
+		},
+		{
+			name:             "reports error on deployment retries for unsupported type",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: bucket-id
+  config:
+    template: 'profile.json'
+    deployment:
+      retries: 0
+  type: bucket
+`,
+			wantErrorsContain: []string{"`retries` is only supported for classic and Settings 2.0 configurations"},
+		},
+		{
+			name:             "loads config with parameter constraints and overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    parameters:
+      threshold: 5
+      level: info
+    constraints:
+      threshold:
+        type: int
+        min: 1
+        max: 10
+      level:
+        enum: [info, warn]
+  type:
+    settings:
+      schema: 'builtin:profile.test'
+      schemaVersion: '1.0'
+      scope: 'tenant'
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          threshold: 20
+        constraints:
+          threshold:
+            type: int
+            nonEmpty: true`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "builtin:profile.test",
+						ConfigId: "profile-id",
+					},
+					Type: config.SettingsType{
+						SchemaId:      "builtin:profile.test",
+						SchemaVersion: "1.0",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":                &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						"threshold":           &value.ValueParameter{Value: 20},
+						"level":               &value.ValueParameter{Value: "info"},
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+					Environment: "env name",
+					Group:       "default",
+					ParameterConstraints: map[string]config.ParameterConstraint{
+						"threshold": {Type: config.IntType, NonEmpty: true},
+						"level":     {Enum: []any{"info", "warn"}},
+					},
+				},
+			},
+		},
+		{
+			name:             "reports error if value parameter violates its constraint",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    parameters:
+      threshold: 5
+    constraints:
+      threshold:
+        type: int
+        max: 3
+  type: some-api
+`,
+			wantErrorsContain: []string{"is greater than the maximum of 3"},
+		},
+		{
+			name:             "expands config for each item",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+    parameters:
+      severity: high
+  type: some-api
+  forEach:
+    key: team
+    items:
+      - team: team-a
+        owners: [alice, bob]
+      - team: team-b
+        dashboard:
+          type: reference
+          configType: some-api
+          configId: overview
+          property: id
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          severity: low`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "alerting-team-a"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":     &value.ValueParameter{Value: "Alerting"},
+						"severity": &value.ValueParameter{Value: "low"},
+						"team":     &value.ValueParameter{Value: "team-a"},
+						"owners":   &value.ValueParameter{Value: []interface{}{"alice", "bob"}},
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "alerting-team-b"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Alerting"},
+						"severity":  &value.ValueParameter{Value: "low"},
+						"team":      &value.ValueParameter{Value: "team-b"},
+						"dashboard": ref.New("project", "some-api", "overview", "id"),
+					},
+					Environment: "env name",
+					Group:       "default",
+				},
+			},
+		},
+		{
+			name:             "reports error if forEach item has no key",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    items:
+      - team: team-a
+`,
+			wantErrorsContain: []string{`invalid ` + "`forEach`" + ` item 0: missing key property "key"`},
+		},
+		{
+			name:             "reports error on duplicate forEach keys",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    items:
+      - key: a
+      - key: a
+`,
+			wantErrorsContain: []string{`duplicate key "a"`},
+		},
+		{
+			name:             "reports error on invalid forEach key",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    items:
+      - key: team a
+`,
+			wantErrorsContain: []string{`key "team a" is invalid`},
+		},
+		{
+			name:             "reports error if forEach item conflicts with config parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+    parameters:
+      team: default
+  type: some-api
+  forEach:
+    items:
+      - key: a
+        team: team-a
+`,
+			wantErrorsContain: []string{`property "team" conflicts with parameter "team" of the config`},
+		},
+		{
+			name:             "reports error if forEach item defines reserved parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    items:
+      - key: a
+        name: team-a
+`,
+			wantErrorsContain: []string{`property "name" is a reserved parameter name`},
+		},
+		{
+			name:             "reports error if forEach defines neither items nor file",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    key: team
+`,
+			wantErrorsContain: []string{"either `items` or `file` must be defined"},
+		},
+		{
+			name:             "reports error if forEach is combined with originObjectId",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+    originObjectId: some-object
+  type: some-api
+  forEach:
+    items:
+      - key: a
+`,
+			wantErrorsContain: []string{"`forEach` can not be combined with `originObjectId`"},
+		},
+		{
+			name:             "reports error on constraint of unknown parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    constraints:
+      threshold:
+        type: int
+  type: some-api
+`,
+			wantErrorsContain: []string{"constraint defined for unknown parameter"},
+		},
+		{
+			name:             "reports error on invalid constraint",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    parameters:
+      threshold: 5
+    constraints:
+      threshold:
+        type: integer
+  type: some-api
+`,
+			wantErrorsContain: []string{"unknown type `integer`"},
+		},
 		{
 			name:             "reports error if some-api API is missing name",
 			filePathArgument: "test-file.yaml",
@@ -1961,6 +2363,11 @@ configs:
 			}
 			assert.Empty(t, gotErrs, "expected no errors but got: %v", gotErrs)
 
+			// all configs are expected to point to the file they were loaded from
+			for i := range tt.wantConfigs {
+				tt.wantConfigs[i].SourceFile = tt.filePathArgument
+			}
+
 			// compare template contents
 			assert.Empty(t, cmp.Diff(tt.wantConfigs, gotConfigs, cmp.Comparer(func(a, b template.Template) bool {
 				cA, _ := a.Content()
@@ -1977,6 +2384,81 @@ configs:
 	}
 }
 
+func TestLoadConfigFile_ForEachItemsFromFile(t *testing.T) {
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project/",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"env": {Name: "env", Group: "default"},
+			},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	testFs := afero.NewMemMapFs()
+	_ = afero.WriteFile(testFs, "project/alerting/config.yaml", []byte(`
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    file: teams/teams.yaml
+`), 0644)
+	_ = afero.WriteFile(testFs, "project/alerting/teams/teams.yaml", []byte(`
+- key: a
+  owner: alice
+- key: b
+  owner: bob
+`), 0644)
+	_ = afero.WriteFile(testFs, "project/alerting/profile.json", []byte("{}"), 0644)
+
+	configs, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/alerting/config.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, configs, 2)
+	assert.Equal(t, "alerting-a", configs[0].Coordinate.ConfigId)
+	assert.Equal(t, &value.ValueParameter{Value: "alice"}, configs[0].Parameters["owner"])
+	assert.Equal(t, "alerting-b", configs[1].Coordinate.ConfigId)
+	assert.Equal(t, &value.ValueParameter{Value: "bob"}, configs[1].Parameters["owner"])
+	assert.Equal(t, []string{filepath.Join("project", "alerting", "teams", "teams.yaml")}, configs[0].AdditionalSourceFiles)
+
+	t.Run("items file is not loaded as config file", func(t *testing.T) {
+		forEachFiles := FindForEachFiles(testFs, []string{"project/alerting/config.yaml"})
+		assert.Equal(t, map[string]struct{}{filepath.Join("project", "alerting", "teams", "teams.yaml"): {}}, forEachFiles)
+
+		loaderContext := *loaderContext
+		loaderContext.ForEachFiles = forEachFiles
+		configs, errs := LoadConfigFile(t.Context(), testFs, &loaderContext, "project/alerting/teams/teams.yaml")
+		assert.Empty(t, errs)
+		assert.Empty(t, configs)
+	})
+
+	t.Run("list file not referenced by a forEach is invalid", func(t *testing.T) {
+		configs, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/alerting/teams/teams.yaml")
+		assert.Len(t, errs, 1)
+		assert.Empty(t, configs)
+	})
+
+	t.Run("missing items file", func(t *testing.T) {
+		_ = afero.WriteFile(testFs, "project/other/config.yaml", []byte(`
+configs:
+- id: alerting
+  config:
+    name: 'Alerting'
+    template: 'profile.json'
+  type: some-api
+  forEach:
+    file: missing.yaml
+`), 0644)
+		_, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/other/config.yaml")
+		assert.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], "failed to read items file")
+	})
+}
+
 func Test_validateParameter(t *testing.T) {
 	knownAPIs := map[string]struct{}{"some-api": {}, "other-api": {}}
 
@@ -0,0 +1,337 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	objectParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/object"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	secretParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/secret"
+)
+
+// ConfigDefinitionIndex looks up the config definitions other configs extend. The config files of a project are only
+// read once a config extends a config of that project.
+type ConfigDefinitionIndex struct {
+	fs           afero.Fs
+	projectPaths map[string]string
+	projects     map[string]map[string][]indexedDefinition
+}
+
+// indexedDefinition is a config definition together with the file it is defined in.
+type indexedDefinition struct {
+	definition persistence.TopLevelConfigDefinition
+	file       string
+	folder     string
+}
+
+// NewConfigDefinitionIndex returns an index of the config definitions of the projects at the given paths, by project ID.
+func NewConfigDefinitionIndex(fs afero.Fs, projectPaths map[string]string) *ConfigDefinitionIndex {
+	return &ConfigDefinitionIndex{
+		fs:           fs,
+		projectPaths: projectPaths,
+		projects:     make(map[string]map[string][]indexedDefinition),
+	}
+}
+
+// resolveExtends returns the given definition merged with the definitions it transitively extends, as well as the
+// files these extended definitions are defined in. Definitions not extending any other definition are returned unchanged.
+func resolveExtends(context *configFileLoaderContext, definition persistence.TopLevelConfigDefinition) (persistence.TopLevelConfigDefinition, []string, error) {
+	if definition.Extends == nil {
+		return definition, nil, nil
+	}
+	if context.ConfigDefinitions == nil {
+		return persistence.TopLevelConfigDefinition{}, nil, fmt.Errorf("`extends` is not supported when loading single config files")
+	}
+	return context.ConfigDefinitions.resolve(context.ProjectId, context.Folder, definition, []string{context.ProjectId + ":" + definition.Id})
+}
+
+func (i *ConfigDefinitionIndex) resolve(project string, folder string, definition persistence.TopLevelConfigDefinition, chain []string) (persistence.TopLevelConfigDefinition, []string, error) {
+	if definition.Extends == nil {
+		return definition, nil, nil
+	}
+
+	if definition.Extends.ConfigId == "" {
+		return persistence.TopLevelConfigDefinition{}, nil, fmt.Errorf("`extends` is missing the `configId` of the extended config")
+	}
+
+	baseProject := definition.Extends.Project
+	if baseProject == "" {
+		baseProject = project
+	}
+
+	key := baseProject + ":" + definition.Extends.ConfigId
+	if slices.Contains(chain, key) {
+		return persistence.TopLevelConfigDefinition{}, nil, fmt.Errorf("cyclic `extends`: %s -> %s", strings.Join(chain, " -> "), key)
+	}
+
+	base, err := i.lookup(baseProject, definition.Extends.ConfigId)
+	if err != nil {
+		return persistence.TopLevelConfigDefinition{}, nil, err
+	}
+
+	resolvedBase, baseFiles, err := i.resolve(baseProject, base.folder, base.definition, append(chain, key))
+	if err != nil {
+		return persistence.TopLevelConfigDefinition{}, nil, err
+	}
+
+	r := rebase{
+		fromFolder:  base.folder,
+		toFolder:    folder,
+		fromProject: baseProject,
+		toProject:   project,
+		fromType:    resolvedBase.Type.GetApiType(),
+	}
+	return mergeDefinitions(resolvedBase, definition, r), append([]string{base.file}, baseFiles...), nil
+}
+
+// lookup returns the definition with the given ID in the given project, which must be unique within the project.
+func (i *ConfigDefinitionIndex) lookup(project string, configId string) (indexedDefinition, error) {
+	definitions, err := i.loadProject(project)
+	if err != nil {
+		return indexedDefinition{}, err
+	}
+
+	switch found := definitions[configId]; len(found) {
+	case 0:
+		return indexedDefinition{}, fmt.Errorf("extended config %q does not exist in project %q", configId, project)
+	case 1:
+		return found[0], nil
+	default:
+		return indexedDefinition{}, fmt.Errorf("extended config %q is ambiguous, as it is defined %d times in project %q", configId, len(found), project)
+	}
+}
+
+func (i *ConfigDefinitionIndex) loadProject(project string) (map[string][]indexedDefinition, error) {
+	if definitions, found := i.projects[project]; found {
+		return definitions, nil
+	}
+
+	projectPath, found := i.projectPaths[project]
+	if !found {
+		return nil, fmt.Errorf("project %q of extended config is not defined in the manifest", project)
+	}
+
+	configFiles, err := files.FindYamlFiles(i.fs, projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk files of project %q: %w", project, err)
+	}
+
+	definitions := make(map[string][]indexedDefinition)
+	for _, file := range configFiles {
+		data, err := afero.ReadFile(i.fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", file, err)
+		}
+
+		// files that are no config files, or are invalid, are skipped - errors are reported when loading them
+		var topLevel persistence.TopLevelDefinition
+		if err := yaml.UnmarshalStrict(data, &topLevel); err != nil {
+			continue
+		}
+
+		for _, d := range topLevel.Configs {
+			definitions[d.Id] = append(definitions[d.Id], indexedDefinition{definition: d, file: file, folder: filepath.Dir(file)})
+		}
+	}
+
+	i.projects[project] = definitions
+	return definitions, nil
+}
+
+// rebase describes how definitions inherited from a base definition are made relative to the extending definition.
+type rebase struct {
+	// fromFolder is the folder of the base definition, toFolder the folder of the extending definition
+	fromFolder, toFolder string
+	// fromProject is the project of the base definition, toProject the project of the extending definition
+	fromProject, toProject string
+	// fromType is the type of the base definition, which short-form references of the base implicitly refer to
+	fromType string
+}
+
+// mergeDefinitions returns the definition extending the given base. The type, config, forEach and overrides of the base
+// are inherited, with everything the extending definition defines taking precedence. Paths of the base are rebased to
+// the folder of the extending definition, and references are made absolute if the base is defined in another project.
+// The originObjectId of the base is not inherited, as it is specific to the base.
+func mergeDefinitions(base persistence.TopLevelConfigDefinition, definition persistence.TopLevelConfigDefinition, r rebase) persistence.TopLevelConfigDefinition {
+	merged := definition
+	merged.Extends = nil
+
+	if merged.Type == (persistence.TypeDefinition{}) {
+		merged.Type = base.Type
+	}
+
+	if merged.ForEach == nil && base.ForEach != nil {
+		forEach := *base.ForEach
+		forEach.File = r.path(forEach.File)
+		merged.ForEach = &forEach
+	}
+
+	merged.Config = r.copy(base.Config)
+	merged.Config.OriginObjectId = ""
+	applyOverrides(&merged.Config, definition.Config)
+
+	merged.GroupOverrides = nil
+	for _, o := range base.GroupOverrides {
+		o.Override = r.copy(o.Override)
+		merged.GroupOverrides = append(merged.GroupOverrides, o)
+	}
+	for _, o := range definition.GroupOverrides {
+		if i := slices.IndexFunc(merged.GroupOverrides, func(b persistence.GroupOverride) bool { return b.Group == o.Group }); i >= 0 {
+			applyOverrides(&merged.GroupOverrides[i].Override, o.Override)
+			continue
+		}
+		merged.GroupOverrides = append(merged.GroupOverrides, o)
+	}
+
+	merged.EnvironmentOverrides = nil
+	for _, o := range base.EnvironmentOverrides {
+		o.Override = r.copy(o.Override)
+		merged.EnvironmentOverrides = append(merged.EnvironmentOverrides, o)
+	}
+	for _, o := range definition.EnvironmentOverrides {
+		if i := slices.IndexFunc(merged.EnvironmentOverrides, func(b persistence.EnvironmentOverride) bool { return b.Environment == o.Environment }); i >= 0 {
+			applyOverrides(&merged.EnvironmentOverrides[i].Override, o.Override)
+			continue
+		}
+		merged.EnvironmentOverrides = append(merged.EnvironmentOverrides, o)
+	}
+
+	return merged
+}
+
+// copy returns a copy of the given definition, which can be modified without modifying the original. Its template and
+// parameters are rebased.
+func (r rebase) copy(definition persistence.ConfigDefinition) persistence.ConfigDefinition {
+	c := definition
+
+	c.Name = r.parameter(definition.Name)
+	c.Skip = r.parameter(definition.Skip)
+	c.Parameters = make(map[string]persistence.ConfigParameter, len(definition.Parameters))
+	for name, param := range definition.Parameters {
+		c.Parameters[name] = r.parameter(param)
+	}
+	c.Constraints = maps.Clone(definition.Constraints)
+	if definition.Deployment != nil {
+		deployment := *definition.Deployment
+		c.Deployment = &deployment
+	}
+
+	c.Template = r.path(definition.Template)
+	return c
+}
+
+// path returns the given path, relative to the folder of the base definition, relative to the folder of the extending
+// definition. Empty and absolute paths are returned unchanged.
+func (r rebase) path(path string) string {
+	if path == "" || filepath.IsAbs(path) || r.fromFolder == r.toFolder {
+		return path
+	}
+	if rel, err := filepath.Rel(r.toFolder, filepath.Join(r.fromFolder, filepath.FromSlash(path))); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// parameter returns a rebased copy of the given parameter definition: Paths of file and secret parameters are rebased,
+// and short-form references are made absolute if the base is defined in another project. Properties of object
+// parameters are rebased recursively.
+func (r rebase) parameter(param persistence.ConfigParameter) persistence.ConfigParameter {
+	switch p := param.(type) {
+	case []interface{}:
+		return r.shortReference(p)
+	case map[interface{}]interface{}:
+		return r.typedParameter(p)
+	}
+	return param
+}
+
+// shortReference returns the given short-form reference (`[configId, property]` or `[type, configId, property]`)
+// including the project of the base, if it is defined in another project. References to properties of the config
+// itself (`[property]`) are kept, as the extending config inherits them.
+func (r rebase) shortReference(ref []interface{}) []interface{} {
+	if r.fromProject == r.toProject {
+		return ref
+	}
+	switch len(ref) {
+	case 2:
+		return []interface{}{r.fromProject, r.fromType, ref[0], ref[1]}
+	case 3:
+		return []interface{}{r.fromProject, ref[0], ref[1], ref[2]}
+	}
+	return ref
+}
+
+func (r rebase) typedParameter(param map[interface{}]interface{}) map[interface{}]interface{} {
+	c := maps.Clone(param)
+
+	switch fmt.Sprint(param["type"]) {
+	case fileParam.FileParameterType:
+		r.rebasePathProperties(c, "path")
+	case secretParam.SecretParameterType:
+		r.rebasePathProperties(c, "file", "dir")
+	case refParam.ReferenceParameterType:
+		// references without configId refer to the config itself, and are inherited as they are
+		if _, hasProject := c["project"]; !hasProject && r.fromProject != r.toProject && c["configId"] != nil {
+			c["project"] = r.fromProject
+			if _, hasType := c["configType"]; !hasType {
+				c["configType"] = r.fromType
+			}
+		}
+	case objectParam.ObjectParameterType:
+		if properties, ok := c["properties"].(map[interface{}]interface{}); ok {
+			c["properties"] = r.objectProperties(properties)
+		}
+	}
+	return c
+}
+
+// objectProperties rebases the properties of an object parameter. Only typed properties are parameters, untyped maps
+// are nested objects and all other values are plain values.
+func (r rebase) objectProperties(properties map[interface{}]interface{}) map[interface{}]interface{} {
+	c := make(map[interface{}]interface{}, len(properties))
+	for k, v := range properties {
+		if m, ok := v.(map[interface{}]interface{}); ok {
+			if _, typed := m["type"]; typed {
+				c[k] = r.typedParameter(m)
+			} else {
+				c[k] = r.objectProperties(m)
+			}
+			continue
+		}
+		c[k] = v
+	}
+	return c
+}
+
+func (r rebase) rebasePathProperties(param map[interface{}]interface{}, properties ...string) {
+	for _, property := range properties {
+		if path, ok := param[property].(string); ok {
+			param[property] = r.path(path)
+		}
+	}
+}
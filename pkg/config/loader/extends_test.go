@@ -0,0 +1,301 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	fileParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	objectParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/object"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	secretParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+const baseConfigs = `
+configs:
+- id: base
+  config:
+    name: Base
+    template: base.json
+    originObjectId: some-object
+    parameters:
+      threshold: 5
+      severity: high
+  type: some-api
+  environmentOverrides:
+  - environment: prod
+    override:
+      parameters:
+        threshold: 10
+`
+
+func newExtendsTestContext(fs afero.Fs) *LoaderContext {
+	return &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{"some-api": {}, "other-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"prod": {Name: "prod", Group: "default"},
+			},
+			AllEnvironmentNames: map[string]struct{}{"prod": {}},
+			AllGroupNames:       map[string]struct{}{"default": {}, "prod": {}},
+		},
+		ParametersSerDe:   config.DefaultParameterParsers,
+		ConfigDefinitions: NewConfigDefinitionIndex(fs, map[string]string{"project": "project", "other": "other"}),
+	}
+}
+
+func writeExtendsTestFiles(t *testing.T, fs afero.Fs, files map[string]string) {
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+}
+
+func TestLoadConfigFile_Extends(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeExtendsTestFiles(t, fs, map[string]string{
+		"project/base/config.yaml": baseConfigs,
+		"project/base/base.json":   "{}",
+		"project/child/config.yaml": `
+configs:
+- id: child
+  config:
+    name: Child
+    parameters:
+      severity: low
+  extends: base
+  environmentOverrides:
+  - environment: prod
+    override:
+      parameters:
+        owner: team-a
+`,
+	})
+
+	configs, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/child/config.yaml")
+	require.Empty(t, errs)
+	require.Len(t, configs, 1)
+
+	c := configs[0]
+	assert.Equal(t, "child", c.Coordinate.ConfigId)
+	assert.Equal(t, "some-api", c.Coordinate.Type)
+	assert.Equal(t, filepath.Join("project", "base", "base.json"), c.Template.ID(), "template is loaded relative to the extended config")
+	assert.Empty(t, c.OriginObjectId, "originObjectId is not inherited")
+	assert.Equal(t, config.Parameters{
+		config.NameParameter: value.New("Child"),
+		"severity":           value.New("low"),
+		"threshold":          value.New(10),
+		"owner":              value.New("team-a"),
+	}, c.Parameters)
+}
+
+func TestLoadConfigFile_ExtendsConfigOfOtherProject(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeExtendsTestFiles(t, fs, map[string]string{
+		"other/base/config.yaml": baseConfigs,
+		"other/base/base.json":   "{}",
+		"project/child/config.yaml": `
+configs:
+- id: child
+  config:
+    name: Child
+  type: other-api
+  extends:
+    project: other
+    configId: base
+`,
+	})
+
+	configs, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/child/config.yaml")
+	require.Empty(t, errs)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "other-api", configs[0].Coordinate.Type, "type of the extending config takes precedence")
+	assert.Equal(t, []string{filepath.Join("other", "base", "config.yaml")}, configs[0].AdditionalSourceFiles)
+	assert.Equal(t, value.New(10), configs[0].Parameters["threshold"])
+}
+
+func TestLoadConfigFile_ExtendsRebasesPaths(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeExtendsTestFiles(t, fs, map[string]string{
+		"project/base/config.yaml": `
+configs:
+- id: base
+  config:
+    name: Base
+    template: base.json
+    parameters:
+      content:
+        type: file
+        path: content.txt
+      token:
+        type: secret
+        name: TOKEN
+        file: secrets/.env
+      settings:
+        type: object
+        properties:
+          nested:
+            content:
+              type: file
+              path: content.txt
+  type: some-api
+  forEach:
+    file: teams.yaml
+`,
+		"project/base/base.json":     "{}",
+		"project/base/content.txt":   "content",
+		"project/base/teams.yaml":    "- key: a",
+		"project/child/config.yaml":  "configs:\n- id: child\n  config:\n    name: Child\n  extends: base\n",
+		"project/base/secrets/.env":  "TOKEN=secret",
+		"project/child/content.txt":  "wrong content",
+		"project/child/teams.yaml":   "- key: wrong",
+		"project/child/secrets/.env": "TOKEN=wrong",
+	})
+
+	configs, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/child/config.yaml")
+	require.Empty(t, errs)
+	require.Len(t, configs, 1)
+
+	c := configs[0]
+	assert.Equal(t, "child-a", c.Coordinate.ConfigId, "forEach is inherited and loads items relative to the extended config")
+	assert.Equal(t, filepath.Join("project", "base", "content.txt"), c.Parameters["content"].(*fileParam.FileParameter).Path)
+	assert.Equal(t, filepath.Join("project", "base", "secrets", ".env"), filepath.Join("project", "child", c.Parameters["token"].(*secretParam.SecretParameter).File))
+
+	nested := c.Parameters["settings"].(*objectParam.ObjectParameter).Properties["nested"].(*objectParam.ObjectParameter)
+	assert.Equal(t, filepath.Join("project", "base", "content.txt"), nested.Properties["content"].(*fileParam.FileParameter).Path)
+	assert.Contains(t, c.AdditionalSourceFiles, filepath.Join("project", "base", "teams.yaml"))
+}
+
+func TestLoadConfigFile_ExtendsConfigOfOtherProjectMakesReferencesAbsolute(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeExtendsTestFiles(t, fs, map[string]string{
+		"other/base/config.yaml": `
+configs:
+- id: base
+  config:
+    name: Base
+    template: base.json
+    parameters:
+      sameType: [profile, id]
+      otherType: [other-api, profile, id]
+      absolute: [third, other-api, profile, id]
+      self: [name]
+      full:
+        type: reference
+        configId: profile
+        property: id
+      ownProperty:
+        type: reference
+        property: name
+  type: some-api
+`,
+		"other/base/base.json":      "{}",
+		"project/child/config.yaml": "configs:\n- id: child\n  config:\n    name: Child\n  extends:\n    project: other\n    configId: base\n",
+	})
+
+	configs, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/child/config.yaml")
+	require.Empty(t, errs)
+	require.Len(t, configs, 1)
+
+	p := configs[0].Parameters
+	assert.Equal(t, refParam.New("other", "some-api", "profile", "id"), p["sameType"])
+	assert.Equal(t, refParam.New("other", "other-api", "profile", "id"), p["otherType"])
+	assert.Equal(t, refParam.New("third", "other-api", "profile", "id"), p["absolute"])
+	assert.Equal(t, refParam.New("other", "some-api", "profile", "id"), p["full"])
+	assert.Equal(t, refParam.New("project", "some-api", "child", "name"), p["self"], "references to the config itself refer to the extending config")
+	assert.Equal(t, refParam.New("project", "some-api", "child", "name"), p["ownProperty"], "references to the config itself refer to the extending config")
+}
+
+func TestLoadConfigFile_ExtendsChain(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeExtendsTestFiles(t, fs, map[string]string{
+		"project/base/config.yaml": baseConfigs,
+		"project/base/base.json":   "{}",
+		"project/child/config.yaml": `
+configs:
+- id: middle
+  config:
+    parameters:
+      severity: medium
+  extends: base
+- id: child
+  config:
+    name: Child
+  extends: middle
+`,
+	})
+
+	configs, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/child/config.yaml")
+	require.Empty(t, errs)
+	require.Len(t, configs, 2)
+	assert.Equal(t, value.New("medium"), configs[1].Parameters["severity"])
+	assert.Equal(t, value.New("Child"), configs[1].Parameters[config.NameParameter])
+	assert.Equal(t, []string{
+		filepath.Join("project", "child", "config.yaml"),
+		filepath.Join("project", "base", "config.yaml"),
+	}, configs[1].AdditionalSourceFiles, "files of all extended configs are source files")
+}
+
+func TestLoadConfigFile_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantErrorMsg string
+	}{
+		{
+			name:         "unknown config",
+			content:      "configs:\n- id: child\n  config: {}\n  extends: unknown",
+			wantErrorMsg: `extended config "unknown" does not exist in project "project"`,
+		},
+		{
+			name:         "unknown project",
+			content:      "configs:\n- id: child\n  config: {}\n  extends: {project: unknown, configId: base}",
+			wantErrorMsg: `project "unknown" of extended config is not defined in the manifest`,
+		},
+		{
+			name:         "cycle",
+			content:      "configs:\n- id: a\n  config: {}\n  extends: b\n- id: b\n  config: {}\n  extends: a",
+			wantErrorMsg: "cyclic `extends`: project:a -> project:b -> project:a",
+		},
+		{
+			name:         "missing configId",
+			content:      "configs:\n- id: child\n  config: {}\n  extends: {project: other}",
+			wantErrorMsg: "`extends` is missing the `configId` of the extended config",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			writeExtendsTestFiles(t, fs, map[string]string{"project/config.yaml": tt.content})
+
+			_, errs := LoadConfigFile(t.Context(), fs, newExtendsTestContext(fs), "project/config.yaml")
+			require.NotEmpty(t, errs)
+			assert.ErrorContains(t, errs[0], tt.wantErrorMsg)
+		})
+	}
+}
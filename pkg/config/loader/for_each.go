@@ -0,0 +1,207 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+// defaultForEachKey is the item property identifying an item, if forEach does not define a key.
+const defaultForEachKey = "key"
+
+// forEachKeyPattern restricts keys to characters that result in readable and stable config IDs.
+var forEachKeyPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// expandForEach returns one definition per item of the forEach of the given definition. Each expanded definition
+// has the ID `<id>-<key>` and all properties of its item added as parameters. Definitions without forEach are
+// returned unchanged.
+//
+// The items file of the forEach, if any, is recorded by forEachFile.
+func expandForEach(fs afero.Fs, context *singleConfigEntryLoadContext, definition persistence.TopLevelConfigDefinition) ([]persistence.TopLevelConfigDefinition, error) {
+	if definition.ForEach == nil {
+		return []persistence.TopLevelConfigDefinition{definition}, nil
+	}
+
+	if definition.Config.OriginObjectId != "" {
+		return nil, newDefinitionParserError(definition.Id, context, "`forEach` can not be combined with `originObjectId`, as all expanded configs would refer to the same object")
+	}
+
+	items, err := loadForEachItems(fs, context, *definition.ForEach)
+	if err != nil {
+		return nil, newDefinitionParserError(definition.Id, context, fmt.Sprintf("invalid `forEach`: %s", err))
+	}
+
+	keyProperty := definition.ForEach.Key
+	if keyProperty == "" {
+		keyProperty = defaultForEachKey
+	}
+
+	expanded := make([]persistence.TopLevelConfigDefinition, 0, len(items))
+	keys := make(map[string]struct{}, len(items))
+	for i, item := range items {
+		key, parameters, err := parseForEachItem(item, keyProperty, definition.Config.Parameters)
+		if err != nil {
+			return nil, newDefinitionParserError(definition.Id, context, fmt.Sprintf("invalid `forEach` item %d: %s", i, err))
+		}
+
+		if _, found := keys[key]; found {
+			return nil, newDefinitionParserError(definition.Id, context, fmt.Sprintf("invalid `forEach` item %d: duplicate key %q", i, key))
+		}
+		keys[key] = struct{}{}
+
+		d := definition
+		d.Id = definition.Id + "-" + key
+		d.ForEach = nil
+		d.Config.Parameters = parameters
+		expanded = append(expanded, d)
+	}
+	return expanded, nil
+}
+
+// loadForEachItems returns the items defined inline, or loaded from the YAML or JSON file relative to the config file.
+func loadForEachItems(fs afero.Fs, context *singleConfigEntryLoadContext, forEach persistence.ForEachDefinition) ([]interface{}, error) {
+	if forEach.File == "" {
+		if forEach.Items == nil {
+			return nil, fmt.Errorf("either `items` or `file` must be defined")
+		}
+		return forEach.Items, nil
+	}
+
+	if forEach.Items != nil {
+		return nil, fmt.Errorf("only one of `items` and `file` may be defined")
+	}
+
+	data, err := afero.ReadFile(fs, forEachFile(context.configFileLoaderContext, forEach))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read items file: %w", err)
+	}
+
+	var items []interface{}
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("items file %q does not contain a list of items: %w", forEach.File, err)
+	}
+	return items, nil
+}
+
+// FindForEachFiles returns the item files referenced by the forEach of any config defined in the given files. Files
+// that can not be read or are no valid config files are ignored - errors are reported when loading them.
+func FindForEachFiles(fs afero.Fs, configFiles []string) map[string]struct{} {
+	forEachFiles := make(map[string]struct{})
+	for _, file := range configFiles {
+		data, err := afero.ReadFile(fs, file)
+		if err != nil {
+			continue
+		}
+
+		var topLevel persistence.TopLevelDefinition
+		if err := yaml.UnmarshalStrict(data, &topLevel); err != nil {
+			continue
+		}
+
+		context := &configFileLoaderContext{Folder: filepath.Dir(file), Path: file}
+		for _, d := range topLevel.Configs {
+			if d.ForEach != nil && d.ForEach.File != "" {
+				forEachFiles[forEachFile(context, *d.ForEach)] = struct{}{}
+			}
+		}
+	}
+	return forEachFiles
+}
+
+// forEachFile returns the path of the items file of the given forEach, or an empty string if its items are inline.
+func forEachFile(context *configFileLoaderContext, forEach persistence.ForEachDefinition) string {
+	if forEach.File == "" {
+		return ""
+	}
+	return filepath.Join(context.Folder, filepath.FromSlash(forEach.File))
+}
+
+// parseForEachItem returns the key of the given item, and the parameters of the config expanded for it. Typed item
+// properties (maps with a `type`) are parsed like any other parameter, all other properties become value parameters.
+func parseForEachItem(item interface{}, keyProperty string, configParameters map[string]persistence.ConfigParameter) (string, map[string]persistence.ConfigParameter, error) {
+	properties, ok := item.(map[interface{}]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("expected a map of properties, but got %T", item)
+	}
+
+	key, err := forEachItemKey(properties, keyProperty)
+	if err != nil {
+		return "", nil, err
+	}
+
+	parameters := make(map[string]persistence.ConfigParameter, len(configParameters)+len(properties))
+	for name, param := range configParameters {
+		parameters[name] = param
+	}
+
+	for k, v := range properties {
+		name := fmt.Sprint(k)
+		if slices.Contains(config.ReservedParameterNames, name) {
+			return "", nil, fmt.Errorf("property %q is a reserved parameter name", name)
+		}
+		if _, found := configParameters[name]; found {
+			return "", nil, fmt.Errorf("property %q conflicts with parameter %q of the config", name, name)
+		}
+		parameters[name] = toForEachParameter(v)
+	}
+	return key, parameters, nil
+}
+
+func forEachItemKey(properties map[interface{}]interface{}, keyProperty string) (string, error) {
+	value, found := properties[keyProperty]
+	if !found {
+		return "", fmt.Errorf("missing key property %q", keyProperty)
+	}
+
+	switch value.(type) {
+	case string, int, int64, uint64, float64, bool:
+	default:
+		return "", fmt.Errorf("key property %q must be a scalar value, but got %T", keyProperty, value)
+	}
+
+	key := strings.TrimSpace(fmt.Sprint(value))
+	if !forEachKeyPattern.MatchString(key) {
+		return "", fmt.Errorf("key %q is invalid - keys must start with a letter or digit and may only contain letters, digits, '_', '.' and '-'", key)
+	}
+	return key, nil
+}
+
+// toForEachParameter wraps lists and untyped maps in value parameters, as they would otherwise be parsed as
+// references or fail to parse.
+func toForEachParameter(v interface{}) persistence.ConfigParameter {
+	switch value := v.(type) {
+	case []interface{}:
+		return map[interface{}]interface{}{"type": valueParam.ValueParameterType, "value": value}
+	case map[interface{}]interface{}:
+		if _, typed := value["type"]; typed {
+			return value
+		}
+		return map[interface{}]interface{}{"type": valueParam.ValueParameterType, "value": value}
+	}
+	return v
+}
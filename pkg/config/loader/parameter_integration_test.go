@@ -29,6 +29,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/object"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -69,4 +70,6 @@ func TestParametersAreLoadedAsExpected(t *testing.T) {
 	assert.Equal(t, compound.CompoundParameterType, cfg.Parameters["compound_value"].GetType())
 	assert.Equal(t, compound.CompoundParameterType, cfg.Parameters["empty_compound"].GetType())
 	assert.Equal(t, compound.CompoundParameterType, cfg.Parameters["compound_on_compound"].GetType())
+	assert.Equal(t, object.ObjectParameterType, cfg.Parameters["object"].GetType())
+	assert.Equal(t, reference.ReferenceParameterType, cfg.Parameters["object"].(*object.ObjectParameter).Properties["reference"].GetType())
 }
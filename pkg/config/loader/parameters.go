@@ -25,6 +25,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
@@ -136,6 +137,8 @@ func parseParameter(fs afero.Fs, context *singleConfigEntryLoadContext, environm
 			Fs:            fs,
 			ParameterName: name,
 			Value:         maps.ToStringMap(val),
+
+			ParametersSerDe: context.ParametersSerDe,
 		})
 	}
 
@@ -172,6 +175,10 @@ func arrayToReferenceParameter(context *singleConfigEntryLoadContext, environmen
 		property = toString(arr[3])
 	}
 
+	if _, err := entities.ParsePropertyPath(property); err != nil {
+		return nil, newParameterDefinitionParserError(parameterName, configId, context, environment, err.Error())
+	}
+
 	return refParam.New(project, configType, cfg, property), nil
 }
 
@@ -0,0 +1,105 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// SharedParametersFileName is the name of the file in the root folder of a project defining the parameters inherited
+// by all configs of the project.
+const SharedParametersFileName = persistence.SharedParametersFileName
+
+// SharedParameters are the parameters inherited by all configs of a project. Parameters of a config take precedence.
+type SharedParameters struct {
+	definition persistence.SharedParametersDefinition
+	path       string
+}
+
+// LoadSharedParameters loads the SharedParametersFileName of the project in the given folder. It returns nil if the
+// project does not define shared parameters.
+func LoadSharedParameters(fs afero.Fs, projectPath string) (*SharedParameters, error) {
+	filePath := filepath.Join(projectPath, SharedParametersFileName)
+	if exists, err := afero.Exists(fs, filePath); err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	var definition persistence.SharedParametersDefinition
+	if err := yaml.UnmarshalStrict(data, &definition); err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	groups := make(map[string]struct{}, len(definition.GroupOverrides))
+	for _, override := range definition.GroupOverrides {
+		if _, found := groups[override.Group]; found {
+			return nil, newLoadError(filePath, fmt.Errorf("duplicate override for group %q", override.Group))
+		}
+		groups[override.Group] = struct{}{}
+	}
+
+	environments := make(map[string]struct{}, len(definition.EnvironmentOverrides))
+	for _, override := range definition.EnvironmentOverrides {
+		if _, found := environments[override.Environment]; found {
+			return nil, newLoadError(filePath, fmt.Errorf("duplicate override for environment %q", override.Environment))
+		}
+		environments[override.Environment] = struct{}{}
+	}
+
+	return &SharedParameters{definition: definition, path: filePath}, nil
+}
+
+// forEnvironment returns the shared parameters with the overrides of the given environment and its group applied.
+func (s *SharedParameters) forEnvironment(environment manifest.EnvironmentDefinition) map[string]persistence.ConfigParameter {
+	parameters := make(map[string]persistence.ConfigParameter)
+	if s == nil {
+		return parameters
+	}
+
+	for name, param := range s.definition.Parameters {
+		parameters[name] = param
+	}
+
+	for _, override := range s.definition.GroupOverrides {
+		if override.Group == environment.Group {
+			for name, param := range override.Parameters {
+				parameters[name] = param
+			}
+		}
+	}
+
+	for _, override := range s.definition.EnvironmentOverrides {
+		if override.Environment == environment.Name {
+			for name, param := range override.Parameters {
+				parameters[name] = param
+			}
+		}
+	}
+
+	return parameters
+}
@@ -0,0 +1,86 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func TestLoadSharedParameters(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/_parameters.yaml", []byte(`
+parameters:
+  owner: team-a
+  tags: common
+groupOverrides:
+- group: prod
+  parameters:
+    owner: team-prod
+environmentOverrides:
+- environment: prod-eu
+  parameters:
+    tags: eu
+`), 0644))
+
+	shared, err := LoadSharedParameters(fs, "project")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]persistence.ConfigParameter{"owner": "team-a", "tags": "common"},
+		shared.forEnvironment(manifest.EnvironmentDefinition{Name: "dev", Group: "dev"}))
+	assert.Equal(t, map[string]persistence.ConfigParameter{"owner": "team-prod", "tags": "common"},
+		shared.forEnvironment(manifest.EnvironmentDefinition{Name: "prod-us", Group: "prod"}))
+	assert.Equal(t, map[string]persistence.ConfigParameter{"owner": "team-prod", "tags": "eu"},
+		shared.forEnvironment(manifest.EnvironmentDefinition{Name: "prod-eu", Group: "prod"}))
+}
+
+func TestLoadSharedParameters_ReturnsNilWithoutFile(t *testing.T) {
+	shared, err := LoadSharedParameters(afero.NewMemMapFs(), "project")
+
+	require.NoError(t, err)
+	assert.Nil(t, shared)
+	assert.Empty(t, shared.forEnvironment(manifest.EnvironmentDefinition{Name: "dev"}))
+}
+
+func TestLoadSharedParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown field", "params:\n  owner: team-a", "field params not found"},
+		{"duplicate group", "groupOverrides:\n- group: prod\n  parameters: {a: b}\n- group: prod\n  parameters: {a: c}", "duplicate override for group \"prod\""},
+		{"duplicate environment", "environmentOverrides:\n- environment: dev\n  parameters: {a: b}\n- environment: dev\n  parameters: {a: c}", "duplicate override for environment \"dev\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "project/_parameters.yaml", []byte(tt.content), 0644))
+
+			_, err := LoadSharedParameters(fs, "project")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	template2 "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
 
@@ -77,15 +78,23 @@ func (p *CompoundParameter) ResolveValue(context parameter.ResolveContext) (inte
 		compoundData[param.Property] = value
 	}
 
+	unmaskedData, masked := secret.Unmask(compoundData)
+
 	out := bytes.Buffer{}
-	err := p.format.Execute(&out, compoundData)
+	err := p.format.Execute(&out, unmaskedData)
 
 	if err != nil {
 		return nil, fmt.Errorf("error resolving compound value: %w", err)
 	}
 
 	str := out.String()
-	return template2.EscapeSpecialCharactersInValue(str, template2.FullStringEscapeFunction)
+	escaped, err := template2.EscapeSpecialCharactersInValue(str, template2.FullStringEscapeFunction)
+	if err != nil || !masked {
+		return escaped, err
+	}
+
+	// a compound value containing secrets is a secret itself
+	return secret.MaskedString(strings.ToString(escaped)), nil
 
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
@@ -148,6 +149,22 @@ func TestResolveComplexValue(t *testing.T) {
 }
 
 // TestResolveValueErrorOnUndefinedReference tests that resolving a compound parameter using an undefined reference results in an error.
+func TestResolveValueWithSecret(t *testing.T) {
+	testFormat := "Bearer {{ .token }}"
+	context := parameter.ResolveContext{
+		ResolvedParameterValues: parameter.Properties{
+			"token": secret.MaskedString("s3cr3t"),
+		},
+	}
+	compoundParameter, err := New("testName", testFormat, []parameter.ParameterReference{{Property: "token"}})
+	require.NoError(t, err)
+
+	result, err := compoundParameter.ResolveValue(context)
+	require.NoError(t, err)
+
+	assert.Equal(t, secret.MaskedString("Bearer s3cr3t"), result)
+}
+
 func TestResolveValueErrorOnUndefinedReference(t *testing.T) {
 	testFormat := "Hi {{ .name }} "
 	compoundParameter, err := New("testName", testFormat,
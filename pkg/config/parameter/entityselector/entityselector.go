@@ -0,0 +1,121 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entityselector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// EntitySelectorParameterType specifies the type of the parameter used in config files
+const EntitySelectorParameterType = "entitySelector"
+
+var EntitySelectorParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeEntitySelectorParameter,
+	Deserializer: parseEntitySelectorParameter,
+}
+
+// EntitySelectorParameter resolves the IDs of the monitored entities matching an entity selector, e.g.
+// `type(HOST),tag(team:payments)`. The entities are searched at deploy time, as their IDs differ between environments.
+//
+// By default, exactly one entity has to match and its ID is resolved. If List is set, the IDs of all matching entities
+// are resolved to a list, in the same format as a list parameter.
+type EntitySelectorParameter struct {
+	Selector string
+	List     bool
+}
+
+// this forces the compiler to check if EntitySelectorParameter is of type Parameter
+var _ parameter.Parameter = (*EntitySelectorParameter)(nil)
+
+func (p *EntitySelectorParameter) GetType() string {
+	return EntitySelectorParameterType
+}
+
+func (p *EntitySelectorParameter) GetReferences() []parameter.ParameterReference {
+	// monitored entities are not managed by monaco, so entity selector parameters can not reference other configs
+	return []parameter.ParameterReference{}
+}
+
+func (p *EntitySelectorParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	if context.MonitoredEntityLookup == nil {
+		return nil, parameter.NewParameterResolveValueError(context, "monitored entities can not be looked up in this context")
+	}
+
+	ids, err := context.MonitoredEntityLookup.FindMonitoredEntities(p.Selector)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to find monitored entities matching %q: %v", p.Selector, err))
+	}
+
+	if p.List {
+		quoted := make([]string, len(ids))
+		for i, id := range ids {
+			quoted[i] = fmt.Sprintf(`"%s"`, id)
+		}
+		return fmt.Sprintf("[ %s ]", strings.Join(quoted, ",")), nil
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("no monitored entity matches %q", p.Selector))
+	case 1:
+		return ids[0], nil
+	default:
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("%d monitored entities match %q, but exactly one was expected. Set `list: true` to resolve all of them", len(ids), p.Selector))
+	}
+}
+
+// parseEntitySelectorParameter parses an EntitySelectorParameter from the given context. The property `selector` is
+// required, `list` is optional and defaults to false.
+func parseEntitySelectorParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	selector, ok := context.Value["selector"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `selector`")
+	}
+
+	selectorString, ok := selector.(string)
+	if !ok || strings.TrimSpace(selectorString) == "" {
+		return nil, parameter.NewParameterParserError(context, "malformed property `selector` - expected non-empty string")
+	}
+
+	list := false
+	if l, ok := context.Value["list"]; ok {
+		if list, ok = l.(bool); !ok {
+			return nil, parameter.NewParameterParserError(context, "malformed property `list` - expected boolean")
+		}
+	}
+
+	return &EntitySelectorParameter{Selector: selectorString, List: list}, nil
+}
+
+func writeEntitySelectorParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	entitySelectorParam, ok := context.Parameter.(*EntitySelectorParameter)
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `EntitySelectorParameter`")
+	}
+
+	result := map[string]interface{}{
+		"selector": entitySelectorParam.Selector,
+	}
+	if entitySelectorParam.List {
+		result["list"] = true
+	}
+
+	return result, nil
+}
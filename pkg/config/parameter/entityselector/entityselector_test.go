@@ -0,0 +1,148 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package entityselector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+type monitoredEntityLookupFunc func(entitySelector string) ([]string, error)
+
+func (f monitoredEntityLookupFunc) FindMonitoredEntities(entitySelector string) ([]string, error) {
+	return f(entitySelector)
+}
+
+func returning(ids ...string) parameter.MonitoredEntityLookup {
+	return monitoredEntityLookupFunc(func(string) ([]string, error) { return ids, nil })
+}
+
+func TestParseEntitySelectorParameter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		want    *EntitySelectorParameter
+		wantErr string
+	}{
+		{
+			name:  "single entity",
+			value: map[string]interface{}{"selector": "type(HOST),tag(team:payments)"},
+			want:  &EntitySelectorParameter{Selector: "type(HOST),tag(team:payments)"},
+		},
+		{
+			name:  "list of entities",
+			value: map[string]interface{}{"selector": "type(SERVICE)", "list": true},
+			want:  &EntitySelectorParameter{Selector: "type(SERVICE)", List: true},
+		},
+		{
+			name:    "missing selector",
+			value:   map[string]interface{}{},
+			wantErr: "missing property `selector`",
+		},
+		{
+			name:    "empty selector",
+			value:   map[string]interface{}{"selector": " "},
+			wantErr: "malformed property `selector`",
+		},
+		{
+			name:    "list is no boolean",
+			value:   map[string]interface{}{"selector": "type(HOST)", "list": "yes"},
+			wantErr: "malformed property `list`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEntitySelectorParameter(parameter.ParameterParserContext{Value: tt.value})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteEntitySelectorParameter(t *testing.T) {
+	written, err := writeEntitySelectorParameter(parameter.ParameterWriterContext{Parameter: &EntitySelectorParameter{Selector: "type(HOST)", List: true}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"selector": "type(HOST)", "list": true}, written)
+
+	written, err = writeEntitySelectorParameter(parameter.ParameterWriterContext{Parameter: &EntitySelectorParameter{Selector: "type(HOST)"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"selector": "type(HOST)"}, written)
+}
+
+func TestResolveValue(t *testing.T) {
+	single := &EntitySelectorParameter{Selector: "type(HOST)"}
+	list := &EntitySelectorParameter{Selector: "type(HOST)", List: true}
+
+	t.Run("resolves single entity", func(t *testing.T) {
+		got, err := single.ResolveValue(parameter.ResolveContext{
+			MonitoredEntityLookup: monitoredEntityLookupFunc(func(entitySelector string) ([]string, error) {
+				assert.Equal(t, "type(HOST)", entitySelector)
+				return []string{"HOST-1"}, nil
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "HOST-1", got)
+	})
+
+	t.Run("resolves list of entities", func(t *testing.T) {
+		got, err := list.ResolveValue(parameter.ResolveContext{MonitoredEntityLookup: returning("HOST-1", "HOST-2")})
+		require.NoError(t, err)
+		assert.Equal(t, `[ "HOST-1","HOST-2" ]`, got)
+	})
+
+	t.Run("resolves empty list", func(t *testing.T) {
+		got, err := list.ResolveValue(parameter.ResolveContext{MonitoredEntityLookup: returning()})
+		require.NoError(t, err)
+		assert.Equal(t, "[  ]", got)
+	})
+
+	t.Run("returns error if no entity matches", func(t *testing.T) {
+		_, err := single.ResolveValue(parameter.ResolveContext{MonitoredEntityLookup: returning()})
+		assert.ErrorContains(t, err, "no monitored entity matches")
+	})
+
+	t.Run("returns error if several entities match", func(t *testing.T) {
+		_, err := single.ResolveValue(parameter.ResolveContext{MonitoredEntityLookup: returning("HOST-1", "HOST-2")})
+		assert.ErrorContains(t, err, "2 monitored entities match")
+	})
+
+	t.Run("returns error if lookup fails", func(t *testing.T) {
+		_, err := single.ResolveValue(parameter.ResolveContext{
+			MonitoredEntityLookup: monitoredEntityLookupFunc(func(string) ([]string, error) {
+				return nil, errors.New("invalid entity selector")
+			}),
+		})
+		assert.ErrorContains(t, err, "invalid entity selector")
+		assert.ErrorAs(t, err, &parameter.ParameterResolveValueError{})
+	})
+
+	t.Run("returns error without monitored entity lookup", func(t *testing.T) {
+		_, err := single.ResolveValue(parameter.ResolveContext{})
+		assert.Error(t, err)
+	})
+}
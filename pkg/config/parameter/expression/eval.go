@@ -0,0 +1,277 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// scope holds everything an expression may access during evaluation. Expressions can not access anything else, e.g.
+// files or environment variables.
+type scope struct {
+	variables   map[string]any
+	environment string
+	group       string
+}
+
+func (n *literalNode) eval(*scope) (any, error) {
+	return n.value, nil
+}
+
+func (n *variableNode) eval(s *scope) (any, error) {
+	v, found := s.variables[n.name]
+	if !found {
+		return nil, fmt.Errorf("unknown parameter %q", n.name)
+	}
+	return v, nil
+}
+
+func (n *listNode) eval(s *scope) (any, error) {
+	items := make([]any, len(n.items))
+	for i, item := range n.items {
+		v, err := item.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = v
+	}
+	return items, nil
+}
+
+func (n *unaryNode) eval(s *scope) (any, error) {
+	v, err := n.operand.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "!" {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator \"!\" expects a boolean, but got %s", typeName(v))
+		}
+		return !b, nil
+	}
+
+	f, ok := v.(float64)
+	if !ok {
+		return nil, fmt.Errorf("operator \"-\" expects a number, but got %s", typeName(v))
+	}
+	return -f, nil
+}
+
+func (n *binaryNode) eval(s *scope) (any, error) {
+	left, err := n.left.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || only evaluate their right operand if needed
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %q expects booleans, but got %s", n.op, typeName(left))
+		}
+		if (n.op == "&&" && !l) || (n.op == "||" && l) {
+			return l, nil
+		}
+		right, err := n.right.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("operator %q expects booleans, but got %s", n.op, typeName(right))
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==":
+		return reflect.DeepEqual(left, right), nil
+	case "!=":
+		return !reflect.DeepEqual(left, right), nil
+	case "+":
+		// strings are concatenated, other values added to a string are converted to their string representation
+		ls, lIsString := left.(string)
+		rs, rIsString := right.(string)
+		if lIsString || rIsString {
+			if !lIsString {
+				ls = toString(left)
+			}
+			if !rIsString {
+				rs = toString(right)
+			}
+			return ls + rs, nil
+		}
+	case "<", "<=", ">", ">=":
+		if ls, ok := left.(string); ok {
+			if rs, ok := right.(string); ok {
+				return compare(n.op, ls, rs), nil
+			}
+		}
+	}
+
+	l, lOk := left.(float64)
+	r, rOk := right.(float64)
+	if !lOk || !rOk {
+		return nil, fmt.Errorf("operator %q is not defined for %s and %s", n.op, typeName(left), typeName(right))
+	}
+
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(l, r), nil
+	default:
+		return compare(n.op, l, r), nil
+	}
+}
+
+func compare[T string | float64](op string, l, r T) bool {
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	default:
+		return l >= r
+	}
+}
+
+func (n *conditionalNode) eval(s *scope) (any, error) {
+	v, err := n.condition.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	condition, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("condition expects a boolean, but got %s", typeName(v))
+	}
+
+	if condition {
+		return n.then.eval(s)
+	}
+	return n.otherwise.eval(s)
+}
+
+func (n *callNode) eval(s *scope) (any, error) {
+	args := make([]any, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(s)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	v, err := functions[n.function].call(s, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.function, err)
+	}
+	return v, nil
+}
+
+func (n *memberNode) eval(s *scope) (any, error) {
+	target, err := n.target.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	m, ok := target.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("can not access property %q of %s", n.key, typeName(target))
+	}
+	v, found := m[n.key]
+	if !found {
+		return nil, fmt.Errorf("property %q does not exist", n.key)
+	}
+	return v, nil
+}
+
+func (n *indexNode) eval(s *scope) (any, error) {
+	target, err := n.target.eval(s)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := target.(type) {
+	case []any:
+		i, ok := index.(float64)
+		if !ok || i != math.Trunc(i) {
+			return nil, fmt.Errorf("lists can only be indexed by whole numbers, but got %s", typeName(index))
+		}
+		// the range is checked before converting the index, as converting numbers exceeding int is undefined
+		if i < 0 || i >= float64(len(t)) {
+			return nil, fmt.Errorf("index %s is out of range of list with %d element(s)", toString(i), len(t))
+		}
+		return t[int(i)], nil
+	case map[string]any:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("objects can only be indexed by strings, but got %s", typeName(index))
+		}
+		v, found := t[key]
+		if !found {
+			return nil, fmt.Errorf("property %q does not exist", key)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("can not index %s", typeName(target))
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
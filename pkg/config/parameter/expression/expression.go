@@ -0,0 +1,197 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// ExpressionParameterType specifies the type of the parameter used in config files
+const ExpressionParameterType = "expression"
+
+var ExpressionParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeExpressionParameter,
+	Deserializer: parseExpressionParameter,
+}
+
+// ExpressionParameter evaluates an expression over other parameters of the same config, e.g.
+//
+//	environment() == "prod" ? upper(replace(name, " ", "-")) : "test-" + name
+//
+// Identifiers in the expression refer to parameters of the same config, which are its references.
+// Expressions support string, number, boolean, null and list literals, the operators `+ - * / % == != < <= > >= && || !`,
+// conditionals (`condition ? a : b`), property access (`a.b`, `a["b"]`, `a[0]`) and the functions defined in functions.
+// Expressions are sandboxed: they can only access the referenced parameters and the environment and group deployed to.
+//
+// String results are escaped like other string parameters. List and object results are resolved to their JSON
+// representation, so they can be used in templates like list parameters.
+type ExpressionParameter struct {
+	expression           string
+	root                 node
+	referencedParameters []parameter.ParameterReference
+}
+
+// this forces the compiler to check if ExpressionParameter is of type Parameter
+var _ parameter.Parameter = (*ExpressionParameter)(nil)
+
+// New parses the given expression and returns an ExpressionParameter referencing all parameters used in it, which
+// belong to the config with the given coordinate.
+func New(expression string, config coordinate.Coordinate) (*ExpressionParameter, error) {
+	root, variables, err := parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]parameter.ParameterReference, len(variables))
+	for i, name := range variables {
+		references[i] = parameter.ParameterReference{Config: config, Property: name}
+	}
+	return &ExpressionParameter{expression: expression, root: root, referencedParameters: references}, nil
+}
+
+func (p *ExpressionParameter) GetType() string {
+	return ExpressionParameterType
+}
+
+func (p *ExpressionParameter) GetReferences() []parameter.ParameterReference {
+	return p.referencedParameters
+}
+
+func (p *ExpressionParameter) Expression() string {
+	return p.expression
+}
+
+func (p *ExpressionParameter) Equal(o *ExpressionParameter) bool {
+	return p.expression == o.expression && cmp.Equal(p.referencedParameters, o.referencedParameters)
+}
+
+func (p *ExpressionParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	s := &scope{
+		variables:   make(map[string]any, len(p.referencedParameters)),
+		environment: context.Environment,
+		group:       context.Group,
+	}
+
+	masked := false
+	for _, ref := range p.referencedParameters {
+		value, ok := context.ResolvedParameterValues[ref.Property]
+		if !ok {
+			return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("unknown parameter %q", ref.Property))
+		}
+		unmasked, m := secret.Unmask(value)
+		masked = masked || m
+		s.variables[ref.Property] = normalize(unmasked)
+	}
+
+	result, err := p.root.eval(s)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to evaluate expression: %v", err))
+	}
+
+	resolved, err := toParameterValue(result)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, err.Error())
+	}
+
+	// an expression evaluated using secrets is a secret itself
+	if masked {
+		return secret.MaskedString(toString(resolved)), nil
+	}
+	return resolved, nil
+}
+
+// normalize converts a resolved parameter value to the types expressions work with: Numbers are converted to float64,
+// maps to map[string]any, and strings, which are already escaped for JSON, are unescaped.
+func normalize(v any) any {
+	return template.NormalizeValue(v, func(v any) any {
+		switch value := v.(type) {
+		case int:
+			return float64(value)
+		case int64:
+			return float64(value)
+		case uint64:
+			return float64(value)
+		case float32:
+			return float64(value)
+		}
+		return template.UnescapeStringValue(v)
+	})
+}
+
+// toParameterValue converts the result of an expression to the resolved value of the parameter.
+func toParameterValue(result any) (any, error) {
+	switch value := result.(type) {
+	case nil:
+		return nil, fmt.Errorf("expression evaluated to null")
+	case string:
+		return template.EscapeSpecialCharactersInValue(value, template.FullStringEscapeFunction)
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+			return int(value), nil
+		}
+		return value, nil
+	case bool:
+		return value, nil
+	}
+	return toString(result), nil
+}
+
+// parseExpressionParameter parses an ExpressionParameter from the given context. It requires the property `expression`.
+func parseExpressionParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	expr, ok := context.Value["expression"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `expression`")
+	}
+
+	exprString, ok := expr.(string)
+	if !ok || strings.TrimSpace(exprString) == "" {
+		return nil, parameter.NewParameterParserError(context, "malformed property `expression` - expected non-empty string")
+	}
+
+	p, err := New(exprString, context.Coordinate)
+	if err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid expression: %v", err))
+	}
+
+	for _, ref := range p.referencedParameters {
+		if ref.Property == context.ParameterName {
+			return nil, parameter.NewParameterParserError(context, "expression must not refer to its own parameter")
+		}
+	}
+
+	return p, nil
+}
+
+func writeExpressionParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	expressionParam, ok := context.Parameter.(*ExpressionParameter)
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ExpressionParameter`")
+	}
+
+	return map[string]interface{}{
+		"expression": expressionParam.expression,
+	}, nil
+}
@@ -0,0 +1,389 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+var testCoordinate = coordinate.Coordinate{Project: "project", Type: "type", ConfigId: "config"}
+
+func resolve(t *testing.T, expr string, values parameter.Properties) (any, error) {
+	t.Helper()
+	p, err := New(expr, testCoordinate)
+	require.NoError(t, err)
+	return p.ResolveValue(parameter.ResolveContext{
+		ConfigCoordinate:        testCoordinate,
+		Environment:             "prod-eu",
+		Group:                   "prod",
+		ParameterName:           "result",
+		ResolvedParameterValues: values,
+	})
+}
+
+func TestResolveValue(t *testing.T) {
+	values := parameter.Properties{
+		"name":     "Payments Service",
+		"count":    3,
+		"ratio":    0.5,
+		"enabled":  true,
+		"json":     `{\"hosts\": [\"a\", \"b\"], \"port\": 8080}`,
+		"escaped":  `say \"hi\"`,
+		"encoded":  "c2VjcmV0",
+		"settings": map[string]any{"threshold": 10, "tags": []any{"x", "y"}},
+	}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`"literal"`, "literal"},
+		{`'single quoted'`, "single quoted"},
+		{`lower(name)`, "payments service"},
+		{`upper(name)`, "PAYMENTS SERVICE"},
+		{`trim("  x  ")`, "x"},
+		{`replace(lower(name), " ", "-")`, "payments-service"},
+		{`regexMatch(name, "^Pay")`, true},
+		{`regexReplace(name, "(\\w+) (\\w+)", "$2-$1")`, "Service-Payments"},
+		{`count * 2 + 1`, 7},
+		{`(count + 1) / 8`, 0.5},
+		{`count % 2`, 1},
+		{`-count`, -3},
+		{`ratio < 1 && enabled`, true},
+		{`!enabled || count >= 3`, true},
+		{`"a" < "b"`, true},
+		{`name + "-" + count`, "Payments Service-3"},
+		{`environment() == "prod-eu" ? 5 : 1`, 5},
+		{`group() == "dev" ? 5 : group() == "prod" ? 10 : 1`, 10},
+		{`jsonDecode(json).port`, 8080},
+		{`jsonDecode(json).hosts[1]`, "b"},
+		{`jsonDecode(json)["hosts"]`, `["a","b"]`},
+		{`jsonEncode(jsonDecode(json).hosts)`, `[\"a\",\"b\"]`},
+		{`[name, count]`, `["Payments Service",3]`},
+		{`escaped`, `say \"hi\"`},
+		{`base64Decode(encoded)`, "secret"},
+		{`base64Encode("secret")`, "c2VjcmV0"},
+		{`settings.threshold * 2`, 20},
+		{`settings.tags[0]`, "x"},
+		{`toNumber("42") + 1`, 43},
+		{`toString(count) == "3"`, true},
+		{`null == null`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := resolve(t, tt.expression, values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveValue_Errors(t *testing.T) {
+	values := parameter.Properties{"name": "x", "count": 3, "list": []any{"a"}}
+
+	tests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{`count / 0`, "division by zero"},
+		{`name - 1`, `operator "-" is not defined for string and number`},
+		{`count ? 1 : 2`, "condition expects a boolean"},
+		{`lower(count)`, "lower: argument 1 must be a string"},
+		{`regexMatch(name, "(")`, "regexMatch: error parsing regexp"},
+		{`list[1]`, "index 1 is out of range"},
+		{`name.property`, `can not access property "property" of string`},
+		{`jsonDecode("{")`, "jsonDecode"},
+		{`null`, "expression evaluated to null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := resolve(t, tt.expression, values)
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.ErrorAs(t, err, &parameter.ParameterResolveValueError{})
+		})
+	}
+}
+
+func TestResolveValue_IndexOutOfRange(t *testing.T) {
+	values := parameter.Properties{"list": []any{"a", "b"}}
+
+	tests := []string{
+		`list[2]`,
+		`list[-1]`,
+		`list[1000000000000000000000000000000]`,
+		`list[toNumber("1e30")]`,
+		`list[toNumber("-1e30")]`,
+		`list[toNumber("Inf")]`,
+	}
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			_, err := resolve(t, expression, values)
+			assert.ErrorContains(t, err, "is out of range of list with 2 element(s)")
+		})
+	}
+
+	t.Run(`list[toNumber("NaN")]`, func(t *testing.T) {
+		_, err := resolve(t, `list[toNumber("NaN")]`, values)
+		assert.ErrorContains(t, err, "lists can only be indexed by whole numbers")
+	})
+}
+
+func TestResolveValue_OperatorPrecedence(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`1 + 2 * 3`, 7},
+		{`(1 + 2) * 3`, 9},
+		{`10 - 4 - 3`, 3},
+		{`24 / 4 / 2`, 3},
+		{`2 * 7 % 4`, 2},
+		{`1 + 7 % 4`, 4},
+		{`-2 * 3`, -6},
+		{`-(2 + 3)`, -5},
+		{`--2`, 2},
+		{`1 + 2 == 3`, true},
+		{`1 + 2 < 2 * 2`, true},
+		{`1 < 2 == 2 < 3`, true},
+		{`!true == false`, true},
+		{`!false && false`, false},
+		{`true || false && false`, true},
+		{`(true || false) && false`, false},
+		{`1 == 1 && 2 != 2`, false},
+		{`true ? 1 : 2 + 10`, 1},
+		{`false ? 1 : 2 + 10`, 12},
+		{`1 > 2 ? "a" : 2 > 1 ? "b" : "c"`, "b"},
+		{`"a" + 1 + 2`, "a12"},
+		{`1 + 2 + "a"`, "3a"},
+		{`"a" + (1 + 2)`, "a3"},
+		{`[1, 2][0] + [3][0] * 2`, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := resolve(t, tt.expression, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveValue_ShortCircuiting(t *testing.T) {
+	values := parameter.Properties{"list": []any{"a"}}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`false && list[5] == "a"`, false},
+		{`true || list[5] == "a"`, true},
+		{`false && 1 / 0`, false},
+		{`true || 1 / 0`, true},
+		{`true ? "a" : list[5]`, "a"},
+		{`false ? list[5] : "b"`, "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := resolve(t, tt.expression, values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	errorTests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{`true && list[5] == "a"`, "index 5 is out of range"},
+		{`false || 1 / 0`, "division by zero"},
+		{`true && 1`, `operator "&&" expects booleans, but got number`},
+		{`1 || true`, `operator "||" expects booleans, but got number`},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := resolve(t, tt.expression, values)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveValue_Functions(t *testing.T) {
+	values := parameter.Properties{
+		"object": map[string]any{"b": 1, "a": []any{"x", true}},
+	}
+
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`lower("MiXeD Case")`, "mixed case"},
+		{`upper("MiXeD Case")`, "MIXED CASE"},
+		{`trim(" \t padded\n ")`, "padded"},
+		{`replace("a-b-c", "-", "_")`, "a_b_c"},
+		{`replace("abc", "x", "y")`, "abc"},
+		{`regexMatch("team-42", "\\d+$")`, true},
+		{`regexMatch("team", "\\d+$")`, false},
+		{`regexReplace("a1b22", "\\d+", "#")`, "a#b#"},
+		{`regexReplace("John Smith", "(\\w+) (\\w+)", "$2, $1")`, "Smith, John"},
+		{`toString(42)`, "42"},
+		{`toString(0.25)`, "0.25"},
+		{`toString(true)`, "true"},
+		{`toString(null)`, ""},
+		{`toString("text")`, "text"},
+		{`toString([1, "a"])`, `[1,\"a\"]`},
+		{`toString(object)`, `{\"a\":[\"x\",true],\"b\":1}`},
+		{`toNumber(" 42 ")`, 42},
+		{`toNumber("1.5")`, 1.5},
+		{`toNumber(7)`, 7},
+		{`jsonEncode(object)`, `{\"a\":[\"x\",true],\"b\":1}`},
+		{`jsonEncode("quoted \"text\"")`, `\"quoted \\\"text\\\"\"`},
+		{`jsonDecode("[1, 2]")[1]`, 2},
+		{`jsonDecode("{\"a\": {\"b\": \"c\"}}").a.b`, "c"},
+		{`jsonDecode("true")`, true},
+		{`base64Encode("user:pass")`, "dXNlcjpwYXNz"},
+		{`base64Decode("dXNlcjpwYXNz")`, "user:pass"},
+		{`base64Decode(base64Encode("round trip"))`, "round trip"},
+		{`environment()`, "prod-eu"},
+		{`group()`, "prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := resolve(t, tt.expression, values)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	errorTests := []struct {
+		expression string
+		wantErr    string
+	}{
+		{`upper(1)`, "upper: argument 1 must be a string, but got number"},
+		{`trim(true)`, "trim: argument 1 must be a string, but got boolean"},
+		{`replace("a", 1, "b")`, "replace: argument 2 must be a string"},
+		{`regexReplace("a", "[", "b")`, "regexReplace: error parsing regexp"},
+		{`toNumber("abc")`, `toNumber: "abc" is not a number`},
+		{`toNumber(true)`, "toNumber: can not convert boolean to a number"},
+		{`jsonDecode(1)`, "jsonDecode: argument 1 must be a string"},
+		{`base64Encode(1)`, "base64Encode: argument 1 must be a string"},
+		{`base64Decode("not base64!")`, "base64Decode: illegal base64 data"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := resolve(t, tt.expression, values)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveValue_NumberFormatting(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       any
+	}{
+		{`1.5 * 2`, 3},
+		{`10 / 4`, 2.5},
+		{`0.1 + 0.2`, 0.30000000000000004},
+		{`7 % 2.5`, 2},
+		{`-0.5 * 2`, -1},
+		{`4503599627370496 * 2 - 1`, 9007199254740991},
+		{`4503599627370496 * 2`, 9007199254740992.0},
+		{`"n=" + 2.50`, "n=2.5"},
+		{`"n=" + 1000000000000000000000`, "n=1000000000000000000000"},
+		{`"n=" + 10 / 4`, "n=2.5"},
+		{`toString(1 / 3)`, "0.3333333333333333"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			got, err := resolve(t, tt.expression, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveValue_MasksResultOfSecrets(t *testing.T) {
+	got, err := resolve(t, `base64Encode(user + ":" + password)`, parameter.Properties{
+		"user":     "admin",
+		"password": secret.MaskedString("s3cr3t"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, secret.MaskedString("YWRtaW46czNjcjN0"), got)
+}
+
+func TestParseExpressionParameter(t *testing.T) {
+	t.Run("references all used parameters", func(t *testing.T) {
+		p, err := parseExpressionParameter(parameter.ParameterParserContext{
+			Coordinate:    testCoordinate,
+			ParameterName: "result",
+			Value:         map[string]interface{}{"expression": `environment() == "prod" ? upper(name) : name + suffix`},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []parameter.ParameterReference{
+			{Config: testCoordinate, Property: "name"},
+			{Config: testCoordinate, Property: "suffix"},
+		}, p.GetReferences())
+	})
+
+	tests := []struct {
+		name       string
+		expression any
+		wantErr    string
+	}{
+		{"missing expression", nil, "missing property `expression`"},
+		{"expression is no string", 42, "malformed property `expression`"},
+		{"unknown function", `exec("rm")`, `unknown function "exec"`},
+		{"wrong number of arguments", `lower(a, b)`, `function "lower" at position 0 expects 1 argument(s), but got 2`},
+		{"unterminated string", `"abc`, "unterminated string"},
+		{"missing closing parenthesis", `(a + b`, `expected ")" at end of expression`},
+		{"incomplete conditional", `a ? b`, `expected ":"`},
+		{"trailing tokens", `a b`, `unexpected "b" at position 2`},
+		{"unexpected character", `a # b`, `unexpected character '#'`},
+		{"self reference", `result + 1`, "must not refer to its own parameter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value := map[string]interface{}{}
+			if tt.expression != nil {
+				value["expression"] = tt.expression
+			}
+			_, err := parseExpressionParameter(parameter.ParameterParserContext{Coordinate: testCoordinate, ParameterName: "result", Value: value})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWriteExpressionParameter(t *testing.T) {
+	p, err := New(`upper(name)`, testCoordinate)
+	require.NoError(t, err)
+
+	written, err := writeExpressionParameter(parameter.ParameterWriterContext{Parameter: p})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"expression": `upper(name)`}, written)
+
+	parsed, err := parseExpressionParameter(parameter.ParameterParserContext{Coordinate: testCoordinate, Value: written})
+	require.NoError(t, err)
+	assert.True(t, p.Equal(parsed.(*ExpressionParameter)))
+}
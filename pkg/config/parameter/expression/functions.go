@@ -0,0 +1,182 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type function struct {
+	arity int
+	call  func(s *scope, args []any) (any, error)
+}
+
+// functions is the library of functions that can be called in expressions. It intentionally contains only pure
+// functions, so that evaluating an expression has no side effects.
+var functions = map[string]function{
+	"lower":        stringFunction(strings.ToLower),
+	"upper":        stringFunction(strings.ToUpper),
+	"trim":         stringFunction(strings.TrimSpace),
+	"replace":      {arity: 3, call: replace},
+	"regexMatch":   {arity: 2, call: regexMatch},
+	"regexReplace": {arity: 3, call: regexReplace},
+	"toString":     {arity: 1, call: func(_ *scope, args []any) (any, error) { return toString(args[0]), nil }},
+	"toNumber":     {arity: 1, call: toNumber},
+	"jsonEncode":   {arity: 1, call: jsonEncode},
+	"jsonDecode":   {arity: 1, call: jsonDecode},
+	"base64Encode": {arity: 1, call: base64Encode},
+	"base64Decode": {arity: 1, call: base64Decode},
+	"environment":  {arity: 0, call: func(s *scope, _ []any) (any, error) { return s.environment, nil }},
+	"group":        {arity: 0, call: func(s *scope, _ []any) (any, error) { return s.group, nil }},
+}
+
+func stringFunction(f func(string) string) function {
+	return function{arity: 1, call: func(_ *scope, args []any) (any, error) {
+		s, err := stringArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return f(s[0]), nil
+	}}
+}
+
+// stringArgs returns the given arguments as strings, or an error if any of them is not a string.
+func stringArgs(args []any) ([]string, error) {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		s, ok := arg.(string)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be a string, but got %s", i+1, typeName(arg))
+		}
+		strs[i] = s
+	}
+	return strs, nil
+}
+
+// replace replaces all occurrences of a substring: replace(s, old, new)
+func replace(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ReplaceAll(s[0], s[1], s[2]), nil
+}
+
+// regexMatch reports whether a string contains a match of a regular expression: regexMatch(s, pattern)
+func regexMatch(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(s[1])
+	if err != nil {
+		return nil, err
+	}
+	return re.MatchString(s[0]), nil
+}
+
+// regexReplace replaces all matches of a regular expression, supporting `$1` style references to capturing groups:
+// regexReplace(s, pattern, replacement)
+func regexReplace(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(s[1])
+	if err != nil {
+		return nil, err
+	}
+	return re.ReplaceAllString(s[0], s[2]), nil
+}
+
+func toNumber(_ *scope, args []any) (any, error) {
+	switch v := args[0].(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", v)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("can not convert %s to a number", typeName(args[0]))
+}
+
+func jsonEncode(_ *scope, args []any) (any, error) {
+	b, err := json.Marshal(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func jsonDecode(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal([]byte(s[0]), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func base64Encode(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s[0])), nil
+}
+
+func base64Decode(_ *scope, args []any) (any, error) {
+	s, err := stringArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(s[0])
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// toString returns the string representation of a value. Lists and objects are represented as JSON.
+func toString(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,138 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdentifier
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any // parsed value of number and string tokens
+	pos   int
+}
+
+// operators lists all operators and punctuation, longer ones first, so that e.g. `<=` is not lexed as `<` and `=`.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "(", ")", "[", "]", ",", ".", "?", ":", "+", "-", "*", "/", "%", "!", "<", ">"}
+
+// tokenize splits the given expression into tokens. The last token is always of kind tokenEOF.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	pos := 0
+	for pos < len(expr) {
+		c := rune(expr[pos])
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+		case c == '"' || c == '\'':
+			s, end, err := lexString(expr, pos)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: expr[pos:end], value: s, pos: pos})
+			pos = end
+		case isDigit(c):
+			end := pos
+			for end < len(expr) && (isDigit(rune(expr[end])) || expr[end] == '.') {
+				end++
+			}
+			n, err := strconv.ParseFloat(expr[pos:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expr[pos:end], pos)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[pos:end], value: n, pos: pos})
+			pos = end
+		case isIdentifierStart(c):
+			end := pos
+			for end < len(expr) && (isIdentifierStart(rune(expr[end])) || isDigit(rune(expr[end]))) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: expr[pos:end], pos: pos})
+			pos = end
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(expr[pos:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: pos}), nil
+}
+
+// lexString lexes the string literal starting at start, which is enclosed in either double or single quotes. It returns
+// the unescaped string and the position after the closing quote.
+func lexString(expr string, start int) (string, int, error) {
+	quote := expr[start]
+	var sb strings.Builder
+	for pos := start + 1; pos < len(expr); pos++ {
+		c := expr[pos]
+		switch {
+		case c == quote:
+			return sb.String(), pos + 1, nil
+		case c == '\\':
+			pos++
+			if pos == len(expr) {
+				break
+			}
+			switch expr[pos] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"', '\'':
+				sb.WriteByte(expr[pos])
+			default:
+				return "", 0, fmt.Errorf("unknown escape sequence \\%c at position %d", expr[pos], pos-1)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string starting at position %d", start)
+}
+
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierStart(c rune) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,298 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package expression
+
+import (
+	"fmt"
+	"slices"
+)
+
+// node is a node of the syntax tree of a parsed expression.
+type node interface {
+	eval(s *scope) (any, error)
+}
+
+type (
+	literalNode struct {
+		value any
+	}
+	variableNode struct {
+		name string
+	}
+	listNode struct {
+		items []node
+	}
+	unaryNode struct {
+		op      string
+		operand node
+	}
+	binaryNode struct {
+		op          string
+		left, right node
+	}
+	conditionalNode struct {
+		condition, then, otherwise node
+	}
+	callNode struct {
+		function string
+		args     []node
+	}
+	memberNode struct {
+		target node
+		key    string
+	}
+	indexNode struct {
+		target, index node
+	}
+)
+
+// binaryPrecedence lists the binary operators from the lowest to the highest precedence.
+var binaryPrecedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+type parser struct {
+	tokens    []token
+	pos       int
+	variables []string
+}
+
+// parse parses the given expression. It returns the syntax tree and the names of all variables used by the expression,
+// in order of their first usage.
+func parse(expr string) (node, []string, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.parseConditional()
+	if err != nil {
+		return nil, nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, nil, p.unexpected(t)
+	}
+	return n, p.variables, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) acceptOperator(ops ...string) (string, bool) {
+	if t := p.peek(); t.kind == tokenOperator && slices.Contains(ops, t.text) {
+		p.pos++
+		return t.text, true
+	}
+	return "", false
+}
+
+func (p *parser) expectOperator(op string) error {
+	if _, ok := p.acceptOperator(op); !ok {
+		t := p.peek()
+		if t.kind == tokenEOF {
+			return fmt.Errorf("expected %q at end of expression", op)
+		}
+		return fmt.Errorf("expected %q at position %d, found %q", op, t.pos, t.text)
+	}
+	return nil
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at position %d", t.text, t.pos)
+}
+
+// parseConditional parses `condition ? then : otherwise`, which has the lowest precedence and is right associative.
+func (p *parser) parseConditional() (node, error) {
+	condition, err := p.parseBinary(0)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.acceptOperator("?"); !ok {
+		return condition, nil
+	}
+
+	then, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectOperator(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseConditional()
+	if err != nil {
+		return nil, err
+	}
+	return &conditionalNode{condition: condition, then: then, otherwise: otherwise}, nil
+}
+
+// parseBinary parses left-associative binary operations of the given precedence level and above.
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryPrecedence) {
+		return p.parseUnary()
+	}
+
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.acceptOperator(binaryPrecedence[level]...)
+		if !ok {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if op, ok := p.acceptOperator("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+// parsePostfix parses member access (`a.b`) and indexing (`a[0]`) of a primary expression.
+func (p *parser) parsePostfix() (node, error) {
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.acceptOperator("."); ok {
+			t := p.next()
+			if t.kind != tokenIdentifier {
+				return nil, p.unexpected(t)
+			}
+			n = &memberNode{target: n, key: t.text}
+		} else if _, ok := p.acceptOperator("["); ok {
+			index, err := p.parseConditional()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOperator("]"); err != nil {
+				return nil, err
+			}
+			n = &indexNode{target: n, index: index}
+		} else {
+			return n, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber, tokenString:
+		return &literalNode{value: t.value}, nil
+	case tokenIdentifier:
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if _, ok := p.acceptOperator("("); ok {
+			return p.parseCall(t)
+		}
+		if !slices.Contains(p.variables, t.text) {
+			p.variables = append(p.variables, t.text)
+		}
+		return &variableNode{name: t.text}, nil
+	case tokenOperator:
+		switch t.text {
+		case "(":
+			n, err := p.parseConditional()
+			if err != nil {
+				return nil, err
+			}
+			return n, p.expectOperator(")")
+		case "[":
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return &listNode{items: items}, nil
+		}
+	}
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	f, found := functions[name.text]
+	if !found {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+
+	args, err := p.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+	if len(args) != f.arity {
+		return nil, fmt.Errorf("function %q at position %d expects %d argument(s), but got %d", name.text, name.pos, f.arity, len(args))
+	}
+	return &callNode{function: name.text, args: args}, nil
+}
+
+// parseList parses a comma separated list of expressions, up to the given closing operator.
+func (p *parser) parseList(closing string) ([]node, error) {
+	var items []node
+	if _, ok := p.acceptOperator(closing); ok {
+		return items, nil
+	}
+	for {
+		item, err := p.parseConditional()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		if _, ok := p.acceptOperator(closing); ok {
+			return items, nil
+		}
+		if err := p.expectOperator(","); err != nil {
+			return nil, err
+		}
+	}
+}
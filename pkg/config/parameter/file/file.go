@@ -22,6 +22,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
@@ -68,11 +69,18 @@ func (f *FileParameter) ResolveValue(context parameter.ResolveContext) (interfac
 	if err != nil {
 		return nil, parameter.NewParameterResolveValueError(context, err.Error())
 	}
+	var result interface{} = strContent
 	if f.Escape {
-		return template.EscapeSpecialCharactersInValue(strContent, template.FullStringEscapeFunction)
+		if result, err = template.EscapeSpecialCharactersInValue(strContent, template.FullStringEscapeFunction); err != nil {
+			return nil, err
+		}
 	}
 
-	return strContent, nil
+	// file contents rendered with secrets are secrets themselves
+	if _, masked := secret.Unmask(resolvedParameterValues); masked {
+		return secret.MaskedString(strings.ToString(result)), nil
+	}
+	return result, nil
 }
 
 // getReferencedParameterValues gets the resolved values of parameters defined in the `references` section of the file parameter. If an unknown parameter is referenced, an error is returned.
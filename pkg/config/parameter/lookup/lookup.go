@@ -0,0 +1,176 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lookup
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	stringutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// LookupParameterType specifies the type of the parameter used in config files
+const LookupParameterType = "lookup"
+
+// DefaultProperty is the property of the found object resolved if no property is defined
+const DefaultProperty = "id"
+
+// SettingsValuePropertyPrefix is the prefix of properties resolving a path within the value of a settings object
+const SettingsValuePropertyPrefix = "value."
+
+var LookupParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeLookupParameter,
+	Deserializer: parseLookupParameter,
+}
+
+// LookupParameter resolves a property of an object that exists in the environment deployed to, but is not managed by
+// monaco, e.g. a management zone created by another team. The object is looked up at deploy time.
+type LookupParameter struct {
+	Query parameter.ObjectQuery
+}
+
+// this forces the compiler to check if LookupParameter is of type Parameter
+var _ parameter.Parameter = (*LookupParameter)(nil)
+
+func (p *LookupParameter) GetType() string {
+	return LookupParameterType
+}
+
+func (p *LookupParameter) GetReferences() []parameter.ParameterReference {
+	// lookup parameters refer to objects not managed by monaco, so they can not reference other configs
+	return []parameter.ParameterReference{}
+}
+
+func (p *LookupParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	if context.ObjectLookup == nil {
+		return nil, parameter.NewParameterResolveValueError(context, "objects can not be looked up in this context")
+	}
+
+	val, err := context.ObjectLookup.LookupObject(p.Query)
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, err.Error())
+	}
+
+	if s, ok := val.(string); ok {
+		return template.EscapeSpecialCharactersInValue(s, template.FullStringEscapeFunction)
+	}
+	return val, nil
+}
+
+// parseLookupParameter parses a LookupParameter from the given context. Either `api` and `name`, or `schema` with an
+// optional `scope` and `filter` are required. `property` is optional and defaults to DefaultProperty.
+func parseLookupParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	query := parameter.ObjectQuery{
+		API:      toString(context.Value["api"]),
+		Name:     toString(context.Value["name"]),
+		Schema:   toString(context.Value["schema"]),
+		Scope:    toString(context.Value["scope"]),
+		Property: toString(context.Value["property"]),
+	}
+	if query.Property == "" {
+		query.Property = DefaultProperty
+	}
+
+	if f, ok := context.Value["filter"]; ok {
+		filter, ok := f.(map[interface{}]interface{})
+		if !ok {
+			return nil, parameter.NewParameterParserError(context, "property `filter` must be a map of value paths to expected values")
+		}
+		query.Filter = make(map[string]string, len(filter))
+		for path, value := range maps.ToStringMap(filter) {
+			query.Filter[path] = stringutils.ToString(value)
+		}
+	}
+
+	switch {
+	case query.API != "" && query.Schema != "":
+		return nil, parameter.NewParameterParserError(context, "only one of `api` and `schema` may be set")
+	case query.API != "":
+		if query.Name == "" {
+			return nil, parameter.NewParameterParserError(context, "missing property `name` to look up an object of a classic API")
+		}
+		if query.Scope != "" || query.Filter != nil {
+			return nil, parameter.NewParameterParserError(context, "`scope` and `filter` can only be used to look up settings objects")
+		}
+		if query.Property != "id" && query.Property != "name" {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("unknown property `%s` of classic API objects, expected `id` or `name`", query.Property))
+		}
+	case query.Schema != "":
+		if query.Name != "" {
+			return nil, parameter.NewParameterParserError(context, "`name` can only be used to look up objects of classic APIs, use `filter` for settings objects")
+		}
+		if query.Property != "id" && query.Property != "scope" && !isSettingsValueProperty(query.Property) {
+			return nil, parameter.NewParameterParserError(context, fmt.Sprintf("unknown property `%s` of settings objects, expected `id`, `scope` or `%s<path>`", query.Property, SettingsValuePropertyPrefix))
+		}
+		if isSettingsValueProperty(query.Property) {
+			if _, err := entities.ParsePropertyPath(strings.TrimPrefix(query.Property, SettingsValuePropertyPrefix)); err != nil {
+				return nil, parameter.NewParameterParserError(context, err.Error())
+			}
+		}
+		for path := range query.Filter {
+			if _, err := entities.ParsePropertyPath(path); err != nil {
+				return nil, parameter.NewParameterParserError(context, fmt.Sprintf("invalid `filter`: %s", err))
+			}
+		}
+	default:
+		return nil, parameter.NewParameterParserError(context, "missing property `api` or `schema` of the object to look up")
+	}
+
+	return &LookupParameter{Query: query}, nil
+}
+
+func isSettingsValueProperty(property string) bool {
+	return strings.HasPrefix(property, SettingsValuePropertyPrefix) && len(property) > len(SettingsValuePropertyPrefix)
+}
+
+func writeLookupParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	lookupParam, ok := context.Parameter.(*LookupParameter)
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `LookupParameter`")
+	}
+
+	query := lookupParam.Query
+	result := make(map[string]interface{})
+	for key, value := range map[string]string{"api": query.API, "name": query.Name, "schema": query.Schema, "scope": query.Scope} {
+		if value != "" {
+			result[key] = value
+		}
+	}
+	if query.Property != DefaultProperty {
+		result["property"] = query.Property
+	}
+	if len(query.Filter) > 0 {
+		filter := make(map[string]interface{}, len(query.Filter))
+		for path, value := range query.Filter {
+			filter[path] = value
+		}
+		result["filter"] = filter
+	}
+
+	return result, nil
+}
+
+func toString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return stringutils.ToString(v)
+}
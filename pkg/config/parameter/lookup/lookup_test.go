@@ -0,0 +1,161 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lookup
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+type objectLookupFunc func(query parameter.ObjectQuery) (any, error)
+
+func (f objectLookupFunc) LookupObject(query parameter.ObjectQuery) (any, error) {
+	return f(query)
+}
+
+func TestParseLookupParameter(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		want    parameter.ObjectQuery
+		wantErr string
+	}{
+		{
+			name:  "classic API object by name",
+			value: map[string]interface{}{"api": "management-zone", "name": "Team A"},
+			want:  parameter.ObjectQuery{API: "management-zone", Name: "Team A", Property: "id"},
+		},
+		{
+			name: "settings object by filter",
+			value: map[string]interface{}{
+				"schema":   "builtin:alerting.profile",
+				"scope":    "environment",
+				"filter":   map[interface{}]interface{}{"name": "Default", "enabled": true},
+				"property": "value.name",
+			},
+			want: parameter.ObjectQuery{
+				Schema:   "builtin:alerting.profile",
+				Scope:    "environment",
+				Filter:   map[string]string{"name": "Default", "enabled": "true"},
+				Property: "value.name",
+			},
+		},
+		{
+			name:    "missing api and schema",
+			value:   map[string]interface{}{"name": "Team A"},
+			wantErr: "missing property `api` or `schema`",
+		},
+		{
+			name:    "both api and schema",
+			value:   map[string]interface{}{"api": "management-zone", "name": "Team A", "schema": "builtin:alerting.profile"},
+			wantErr: "only one of `api` and `schema`",
+		},
+		{
+			name:    "classic API object without name",
+			value:   map[string]interface{}{"api": "management-zone"},
+			wantErr: "missing property `name`",
+		},
+		{
+			name:    "unknown property of settings object",
+			value:   map[string]interface{}{"schema": "builtin:alerting.profile", "property": "name"},
+			wantErr: "unknown property `name`",
+		},
+		{
+			name:    "invalid value path of settings object",
+			value:   map[string]interface{}{"schema": "builtin:alerting.profile", "property": "value.rules[x]"},
+			wantErr: "invalid property path",
+		},
+		{
+			name:    "invalid filter path",
+			value:   map[string]interface{}{"schema": "builtin:alerting.profile", "filter": map[interface{}]interface{}{"rules..name": "x"}},
+			wantErr: "invalid `filter`",
+		},
+		{
+			name:    "filter is not a map",
+			value:   map[string]interface{}{"schema": "builtin:alerting.profile", "filter": "name"},
+			wantErr: "property `filter` must be a map",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLookupParameter(parameter.ParameterParserContext{Value: tt.value})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, &LookupParameter{Query: tt.want}, got)
+		})
+	}
+}
+
+func TestWriteLookupParameter_RoundTrips(t *testing.T) {
+	param := &LookupParameter{Query: parameter.ObjectQuery{
+		Schema:   "builtin:alerting.profile",
+		Filter:   map[string]string{"name": "Default"},
+		Property: "id",
+	}}
+
+	written, err := writeLookupParameter(parameter.ParameterWriterContext{Parameter: param})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"schema": "builtin:alerting.profile",
+		"filter": map[string]interface{}{"name": "Default"},
+	}, written)
+
+	written["filter"] = map[interface{}]interface{}{"name": "Default"}
+	parsed, err := parseLookupParameter(parameter.ParameterParserContext{Value: written})
+	require.NoError(t, err)
+	assert.Equal(t, param, parsed)
+}
+
+func TestResolveValue(t *testing.T) {
+	param := &LookupParameter{Query: parameter.ObjectQuery{API: "management-zone", Name: "Team A", Property: "id"}}
+
+	t.Run("resolves the looked up value", func(t *testing.T) {
+		got, err := param.ResolveValue(parameter.ResolveContext{
+			ObjectLookup: objectLookupFunc(func(query parameter.ObjectQuery) (any, error) {
+				assert.Equal(t, param.Query, query)
+				return "1234", nil
+			}),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1234", got)
+	})
+
+	t.Run("returns error if lookup fails", func(t *testing.T) {
+		_, err := param.ResolveValue(parameter.ResolveContext{
+			ObjectLookup: objectLookupFunc(func(parameter.ObjectQuery) (any, error) {
+				return nil, errors.New("no object found")
+			}),
+		})
+		assert.ErrorContains(t, err, "no object found")
+		assert.ErrorAs(t, err, &parameter.ParameterResolveValueError{})
+	})
+
+	t.Run("returns error without object lookup", func(t *testing.T) {
+		_, err := param.ResolveValue(parameter.ResolveContext{})
+		assert.Error(t, err)
+	})
+}
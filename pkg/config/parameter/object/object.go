@@ -0,0 +1,288 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	internalMaps "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+// ObjectParameterType specifies the type of the parameter used in config files
+const ObjectParameterType = "object"
+
+var ObjectParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeObjectParameter,
+	Deserializer: parseObjectParameter,
+}
+
+// ObjectParameter is a structured object, whose properties are parameters themselves, e.g.
+//
+//	tags:
+//	  type: object
+//	  properties:
+//	    team: team-a
+//	    zone:
+//	      type: reference
+//	      configType: management-zone
+//	      configId: zone
+//	      property: id
+//
+// Properties defined as map with a `type` are parameters of that type, other maps are nested objects, and any other
+// value is a plain value. Maps containing a `type` property need to be defined as `value` parameter.
+// The object resolves to its JSON representation, so it can be used in templates like list parameters.
+type ObjectParameter struct {
+	Properties map[string]parameter.Parameter
+}
+
+func New(properties map[string]parameter.Parameter) *ObjectParameter {
+	return &ObjectParameter{Properties: properties}
+}
+
+// this forces the compiler to check if ObjectParameter is of type Parameter
+var _ parameter.Parameter = (*ObjectParameter)(nil)
+
+func (p *ObjectParameter) GetType() string {
+	return ObjectParameterType
+}
+
+func (p *ObjectParameter) GetReferences() []parameter.ParameterReference {
+	var references []parameter.ParameterReference
+	for _, key := range slices.Sorted(maps.Keys(p.Properties)) {
+		for _, ref := range p.Properties[key].GetReferences() {
+			if !slices.Contains(references, ref) {
+				references = append(references, ref)
+			}
+		}
+	}
+	return references
+}
+
+func (p *ObjectParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	object, masked, err := p.resolve(context, "")
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(object); err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to encode object: %v", err))
+	}
+	result := string(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+
+	// an object containing secrets is a secret itself
+	if masked {
+		return secret.MaskedString(result), nil
+	}
+	return result, nil
+}
+
+// resolve resolves all properties recursively and returns the object, and whether it contains secrets.
+func (p *ObjectParameter) resolve(context parameter.ResolveContext, path string) (map[string]any, bool, error) {
+	object := make(map[string]any, len(p.Properties))
+	masked := false
+	for key, property := range p.Properties {
+		propertyPath := joinPath(path, key)
+
+		if nested, ok := property.(*ObjectParameter); ok {
+			resolved, m, err := nested.resolve(context, propertyPath)
+			if err != nil {
+				return nil, false, err
+			}
+			object[key] = resolved
+			masked = masked || m
+			continue
+		}
+
+		resolved, m, err := resolveProperty(context, property)
+		if err != nil {
+			return nil, false, parameter.NewParameterResolveValueError(context, fmt.Sprintf("failed to resolve property %q: %v", propertyPath, err))
+		}
+		object[key] = resolved
+		masked = masked || m
+	}
+	return object, masked, nil
+}
+
+// resolveProperty resolves a property to a value that can be encoded as JSON. Values of other parameters are resolved
+// for use in templates, so their escaping is reverted and lists are decoded.
+func resolveProperty(context parameter.ResolveContext, property parameter.Parameter) (any, bool, error) {
+	if v, ok := property.(*value.ValueParameter); ok {
+		return template.NormalizeValue(v.Value, nil), false, nil
+	}
+
+	resolved, err := property.ResolveValue(context)
+	if err != nil {
+		return nil, false, err
+	}
+
+	unmasked, masked := secret.Unmask(resolved)
+	s, isString := unmasked.(string)
+	if !isString {
+		return template.NormalizeValue(unmasked, template.UnescapeStringValue), masked, nil
+	}
+
+	if property.GetType() == listParam.ListParameterType || property.GetType() == ObjectParameterType {
+		var decoded any
+		if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+			return decoded, masked, nil
+		}
+	}
+
+	return template.UnescapeStringValue(s), masked, nil
+}
+
+// parseObjectParameter parses an ObjectParameter from the given context. It requires the map `properties`.
+func parseObjectParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	properties, ok := context.Value["properties"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `properties`")
+	}
+
+	propertyMap, ok := toStringMap(properties)
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "malformed property `properties` - expected map")
+	}
+
+	return parseProperties(context, propertyMap, "")
+}
+
+func parseProperties(context parameter.ParameterParserContext, properties map[string]any, path string) (*ObjectParameter, error) {
+	result := make(map[string]parameter.Parameter, len(properties))
+	for key, v := range properties {
+		property, err := parseProperty(context, v, joinPath(path, key))
+		if err != nil {
+			return nil, err
+		}
+		result[key] = property
+	}
+	return New(result), nil
+}
+
+func parseProperty(context parameter.ParameterParserContext, v any, path string) (parameter.Parameter, error) {
+	m, isMap := toStringMap(v)
+	if !isMap {
+		return value.New(v), nil
+	}
+
+	t, isTyped := m["type"]
+	if !isTyped {
+		return parseProperties(context, m, path)
+	}
+
+	serde, found := context.ParametersSerDe[fmt.Sprint(t)]
+	if !found {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("property %q is of unknown parameter type `%v` - maps containing a `type` property must be defined as `value` parameter", path, t))
+	}
+
+	subContext := context
+	subContext.Value = m
+	property, err := serde.Deserializer(subContext)
+	if err != nil {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("malformed property %q: %v", path, err))
+	}
+	return property, nil
+}
+
+func writeObjectParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	objectParam, ok := context.Parameter.(*ObjectParameter)
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ObjectParameter`")
+	}
+
+	properties, err := writeProperties(context, objectParam, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"properties": properties,
+	}, nil
+}
+
+// writeProperties writes the properties of the object in the form they are parsed from: Nested objects and plain
+// values in their short form where possible, other parameters in their full form.
+func writeProperties(context parameter.ParameterWriterContext, object *ObjectParameter, path string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(object.Properties))
+	for key, property := range object.Properties {
+		propertyPath := joinPath(path, key)
+
+		switch p := property.(type) {
+		case *ObjectParameter:
+			properties, err := writeProperties(context, p, propertyPath)
+			if err != nil {
+				return nil, err
+			}
+			if _, hasType := p.Properties["type"]; hasType {
+				result[key] = map[string]interface{}{"type": ObjectParameterType, "properties": properties}
+			} else {
+				result[key] = properties
+			}
+
+		case *value.ValueParameter:
+			if _, isMap := toStringMap(p.Value); isMap {
+				result[key] = map[string]interface{}{"type": value.ValueParameterType, "value": p.Value}
+			} else {
+				result[key] = p.Value
+			}
+
+		default:
+			serde, found := context.ParametersSerDe[property.GetType()]
+			if !found {
+				return nil, parameter.NewParameterWriterError(context, fmt.Sprintf("property %q: no serde found for type `%s`", propertyPath, property.GetType()))
+			}
+
+			subContext := context
+			subContext.Parameter = property
+			written, err := serde.Serializer(subContext)
+			if err != nil {
+				return nil, parameter.NewParameterWriterError(context, fmt.Sprintf("property %q: %v", propertyPath, err))
+			}
+			written["type"] = property.GetType()
+			result[key] = written
+		}
+	}
+	return result, nil
+}
+
+func toStringMap(v any) (map[string]any, bool) {
+	switch m := v.(type) {
+	case map[any]any:
+		return internalMaps.ToStringMap(m), true
+	case map[string]any:
+		return m, true
+	}
+	return nil, false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,239 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+var testSerDe = map[string]parameter.ParameterSerDe{
+	value.ValueParameterType:                  value.ValueParameterSerde,
+	refParam.ReferenceParameterType:           refParam.ReferenceParameterSerde,
+	envParam.EnvironmentVariableParameterType: envParam.EnvironmentVariableParameterSerde,
+	listParam.ListParameterType:               listParam.ListParameterSerde,
+	ObjectParameterType:                       ObjectParameterSerde,
+}
+
+var testCoordinate = coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "board"}
+
+func newParserContext(properties any) parameter.ParameterParserContext {
+	return parameter.ParameterParserContext{
+		Coordinate:      testCoordinate,
+		ParameterName:   "tags",
+		Value:           map[string]any{"properties": properties},
+		ParametersSerDe: testSerDe,
+	}
+}
+
+func TestParseObjectParameter(t *testing.T) {
+	param, err := parseObjectParameter(newParserContext(map[any]any{
+		"team":   "team-a",
+		"weight": 3,
+		"labels": []any{"a", "b"},
+		"zone": map[any]any{
+			"type":       "reference",
+			"configType": "management-zone",
+			"configId":   "zone",
+			"property":   "id",
+		},
+		"nested": map[any]any{
+			"region": map[any]any{"type": "environment", "name": "REGION"},
+		},
+		"raw": map[any]any{
+			"type":  "value",
+			"value": map[any]any{"type": "CUSTOM"},
+		},
+	}))
+	require.NoError(t, err)
+
+	zone := refParam.New("project", "management-zone", "zone", "id")
+	assert.Equal(t, New(map[string]parameter.Parameter{
+		"team":   value.New("team-a"),
+		"weight": value.New(3),
+		"labels": value.New([]any{"a", "b"}),
+		"zone":   zone,
+		"nested": New(map[string]parameter.Parameter{
+			"region": envParam.New("REGION"),
+		}),
+		"raw": value.New(map[any]any{"type": "CUSTOM"}),
+	}), param)
+
+	assert.Equal(t, []parameter.ParameterReference{{Config: zone.Config, Property: "id"}}, param.GetReferences())
+}
+
+func TestParseObjectParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		context parameter.ParameterParserContext
+		wantErr string
+	}{
+		{
+			name:    "missing properties",
+			context: parameter.ParameterParserContext{Value: map[string]any{}},
+			wantErr: "missing property `properties`",
+		},
+		{
+			name:    "properties is not a map",
+			context: newParserContext([]any{"a"}),
+			wantErr: "expected map",
+		},
+		{
+			name:    "unknown parameter type",
+			context: newParserContext(map[any]any{"nested": map[any]any{"rule": map[any]any{"type": "CUSTOM"}}}),
+			wantErr: "property \"nested.rule\" is of unknown parameter type `CUSTOM`",
+		},
+		{
+			name:    "malformed nested parameter",
+			context: newParserContext(map[any]any{"zone": map[any]any{"type": "reference"}}),
+			wantErr: "malformed property \"zone\"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseObjectParameter(tt.context)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveValue(t *testing.T) {
+	t.Setenv("REGION", "eu \"west\"")
+
+	zoneCoordinate := coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "zone"}
+	entityMap := entities.New()
+	entityMap.Put(entities.ResolvedEntity{Coordinate: zoneCoordinate, Properties: parameter.Properties{"id": "zone-id"}})
+
+	param := New(map[string]parameter.Parameter{
+		"team":   value.New("a <b> \"c\""),
+		"weight": value.New(3),
+		"zone":   refParam.NewWithCoordinate(zoneCoordinate, "id"),
+		"labels": listParam.New([]value.ValueParameter{{Value: "x"}, {Value: "y"}}),
+		"nested": New(map[string]parameter.Parameter{
+			"region": envParam.New("REGION"),
+			"rule":   value.New(map[any]any{"type": "CUSTOM"}),
+		}),
+	})
+
+	result, err := param.ResolveValue(parameter.ResolveContext{PropertyResolver: entityMap, ConfigCoordinate: testCoordinate, ParameterName: "tags"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"labels":["x","y"],"nested":{"region":"eu \"west\"","rule":{"type":"CUSTOM"}},"team":"a <b> \"c\"","weight":3,"zone":"zone-id"}`, result)
+}
+
+func TestResolveValue_MasksSecrets(t *testing.T) {
+	param := New(map[string]parameter.Parameter{
+		"token": &parameter.DummyParameter{Value: secret.MaskedString("s3cr3t")},
+	})
+
+	result, err := param.ResolveValue(parameter.ResolveContext{ParameterName: "tags"})
+
+	require.NoError(t, err)
+	require.IsType(t, secret.MaskedString(""), result)
+	assert.Equal(t, `{"token":"s3cr3t"}`, result.(secret.MaskedString).Value())
+}
+
+func TestResolveValue_ReportsPathOfFailedProperty(t *testing.T) {
+	param := New(map[string]parameter.Parameter{
+		"nested": New(map[string]parameter.Parameter{
+			"region": envParam.New("UNDEFINED_OBJECT_TEST_VARIABLE"),
+		}),
+	})
+
+	_, err := param.ResolveValue(parameter.ResolveContext{ParameterName: "tags"})
+
+	var resolveErr parameter.ParameterResolveValueError
+	require.ErrorAs(t, err, &resolveErr)
+	assert.Equal(t, "tags", resolveErr.ParameterName)
+	assert.Contains(t, resolveErr.Reason, "failed to resolve property \"nested.region\"")
+}
+
+func TestWriteObjectParameter_RoundTrip(t *testing.T) {
+	properties := map[any]any{
+		"team": "team-a",
+		"zone": map[any]any{
+			"type":       "reference",
+			"configType": "management-zone",
+			"configId":   "zone",
+			"property":   "id",
+		},
+		"nested": map[any]any{
+			"weight": 3,
+		},
+		"typed": map[any]any{
+			"type": "object",
+			"properties": map[any]any{
+				"type": "CUSTOM",
+			},
+		},
+		"raw": map[any]any{
+			"type":  "value",
+			"value": map[any]any{"type": "CUSTOM"},
+		},
+	}
+
+	param, err := parseObjectParameter(newParserContext(properties))
+	require.NoError(t, err)
+
+	written, err := writeObjectParameter(parameter.ParameterWriterContext{
+		Coordinate:      testCoordinate,
+		ParameterName:   "tags",
+		Parameter:       param,
+		ParametersSerDe: testSerDe,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"team": "team-a",
+		"zone": map[string]any{
+			"type":       "reference",
+			"configType": "management-zone",
+			"configId":   "zone",
+			"property":   "id",
+		},
+		"nested": map[string]any{
+			"weight": 3,
+		},
+		"typed": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"type": "CUSTOM",
+			},
+		},
+		"raw": map[string]any{
+			"type":  "value",
+			"value": map[any]any{"type": "CUSTOM"},
+		},
+	}, written["properties"])
+
+	reparsed, err := parseObjectParameter(newParserContext(written["properties"]))
+	require.NoError(t, err)
+	assert.Equal(t, param, reparsed)
+}
@@ -32,10 +32,53 @@ type PropertyResolver interface {
 	GetResolvedProperty(coordinate coordinate.Coordinate, propertyName string) (any, bool)
 }
 
+// ObjectLookup is used in parameter resolution to find objects that exist in the environment deployed to, but are not
+// managed by monaco
+type ObjectLookup interface {
+	// LookupObject returns the property of the single object matching the given query
+	LookupObject(query ObjectQuery) (any, error)
+}
+
+// ObjectQuery identifies a single object in an environment. Either API and Name, or Schema must be set.
+type ObjectQuery struct {
+	// API is the ID of the classic config API to search, for objects named Name
+	API  string
+	Name string
+
+	// Schema is the ID of the settings schema to search, for objects in Scope matching Filter
+	Schema string
+	Scope  string
+	// Filter maps dot-separated paths within the value of a settings object to their expected value
+	Filter map[string]string
+
+	// Property is the property of the found object to return
+	Property string
+}
+
+// MonitoredEntityLookup is used in parameter resolution to find monitored entities (e.g. hosts or services) in the
+// environment deployed to
+type MonitoredEntityLookup interface {
+	// FindMonitoredEntities returns the IDs of all monitored entities matching the given entity selector
+	FindMonitoredEntities(entitySelector string) ([]string, error)
+}
+
+// PlaceholderLookup is implemented by ObjectLookup and MonitoredEntityLookup implementations, which may return
+// placeholders instead of looking up actual objects and monitored entities, e.g. in dry-run mode
+type PlaceholderLookup interface {
+	// ReturnsPlaceholders returns whether the looked up values are placeholders
+	ReturnsPlaceholders() bool
+}
+
 // ResolveContext used to give some more information on the resolving phase
 type ResolveContext struct {
 	PropertyResolver PropertyResolver
 
+	// ObjectLookup finds objects in the environment deployed to. It may be nil if objects can not be looked up.
+	ObjectLookup ObjectLookup
+
+	// MonitoredEntityLookup finds monitored entities in the environment deployed to. It may be nil if entities can not be looked up.
+	MonitoredEntityLookup MonitoredEntityLookup
+
 	// coordinates of the current config
 	ConfigCoordinate coordinate.Coordinate
 
@@ -91,6 +134,8 @@ type ParameterParserContext struct {
 	Fs               afero.Fs
 	Value            map[string]interface {
 	}
+	// ParametersSerDe holds the serdes of all known parameter types, for parameters containing other parameters
+	ParametersSerDe map[string]ParameterSerDe
 }
 
 type ParameterParserError struct {
@@ -203,6 +248,8 @@ type ParameterWriterContext struct {
 	ParameterName string
 	// current value to parse
 	Parameter Parameter
+	// ParametersSerDe holds the serdes of all known parameter types, for parameters containing other parameters
+	ParametersSerDe map[string]ParameterSerDe
 }
 
 // function loading a parameter from a given context
@@ -182,6 +182,11 @@ func parseReferenceParameter(context parameter.ParameterParserContext) (paramete
 		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("missing `%s` - please specifiy which %s should be referenced", propertyField, propertyField))
 	}
 
+	// the property may be a path to a nested value, e.g. `rules[0].id`
+	if _, err := entities.ParsePropertyPath(property); err != nil {
+		return nil, parameter.NewParameterParserError(context, err.Error())
+	}
+
 	// ensure that we do not have "holes" in the reference definition
 	if projectSet && (!typeSet || !configSet) {
 		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("`%s` is set, but either `%s` or `%s` isn't! please specify `%s` and `%s`", projectField, typeField, idField, typeField, idField))
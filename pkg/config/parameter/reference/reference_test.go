@@ -97,6 +97,30 @@ func TestParseReferenceParameterShouldFailIfPropertyIsMissing(t *testing.T) {
 	require.Error(t, err, "should return error")
 }
 
+func TestParseReferenceParameterWithPropertyPath(t *testing.T) {
+	param, err := parseReferenceParameter(parameter.ParameterParserContext{
+		Value: map[string]interface{}{
+			"project":    "projectB",
+			"configType": "workflow",
+			"configId":   "workflow",
+			"property":   "tasks[0].id",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "tasks[0].id", param.(*ReferenceParameter).Property)
+}
+
+func TestParseReferenceParameterShouldFailOnInvalidPropertyPath(t *testing.T) {
+	_, err := parseReferenceParameter(parameter.ParameterParserContext{
+		Value: map[string]interface{}{
+			"property": "tasks[0.id",
+		},
+	})
+
+	assert.ErrorContains(t, err, "invalid property path")
+}
+
 func TestParseReferenceParameterShouldFailIfProjectIsSetButApiIsNot(t *testing.T) {
 	project := "projectB"
 	config := "alerting"
@@ -273,6 +297,26 @@ func TestResolveComplexValueMapInSameConfig(t *testing.T) {
 	assert.Equal(t, "value", result)
 }
 
+func TestResolveValueFromResponse(t *testing.T) {
+	referenceCoordinate := coordinate.Coordinate{Project: "projectB", Type: "workflow", ConfigId: "workflow"}
+	fixture := NewWithCoordinate(referenceCoordinate, "tasks[1].id")
+
+	entityMap := entities.New()
+	entityMap.Put(entities.ResolvedEntity{
+		Coordinate: referenceCoordinate,
+		Properties: map[string]any{"id": "workflow-id"},
+		Response:   map[string]any{"tasks": []any{map[string]any{"id": "task-1"}, map[string]any{"id": "task-2"}}},
+	})
+
+	result, err := fixture.ResolveValue(parameter.ResolveContext{
+		ConfigCoordinate: coordinate.Coordinate{Project: "projectA", Type: "dashboard", ConfigId: "super-important"},
+		PropertyResolver: entityMap,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "task-2", result)
+}
+
 func TestResolveComplexValueNestedMap(t *testing.T) {
 	project := "projectB"
 	configType := "alerting-profile"
@@ -0,0 +1,272 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	secretutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	stringutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// SecretParameterType specifies the type of the parameter used in config files
+const SecretParameterType = "secret"
+
+// SecretNameEnvKey is the environment variable holding the name of the requested secret when a helper command is run
+const SecretNameEnvKey = "MONACO_SECRET_NAME"
+
+var SecretParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeSecretParameter,
+	Deserializer: parseSecretParameter,
+}
+
+// SecretParameter loads a sensitive value from one of the following sources:
+//   - File: a dotenv-style file containing `NAME=value` lines
+//   - Dir: a directory containing one file per secret, named like the secret (e.g. a mounted Kubernetes secret)
+//   - Command: a helper command printing the secret to stdout
+//
+// Each Command is only run once per execution of monaco, and canceled if it takes longer than secret.CommandTimeout.
+//
+// The resolved value is a secret.MaskedString, so it does not show up in logs or reports.
+type SecretParameter struct {
+	// Name of the secret. It is required for File and Dir, and passed to Command as SecretNameEnvKey if set.
+	Name string
+
+	// File, Dir and Command define the source of the secret. Exactly one of them is set.
+	// Relative paths are relative to the WorkingDirectory, which is also the directory the Command is run in.
+	File    string
+	Dir     string
+	Command []string
+
+	Fs               afero.Fs
+	WorkingDirectory string
+}
+
+// this forces the compiler to check if SecretParameter is of type Parameter
+var _ parameter.Parameter = (*SecretParameter)(nil)
+
+func (p *SecretParameter) GetType() string {
+	return SecretParameterType
+}
+
+func (p *SecretParameter) GetReferences() []parameter.ParameterReference {
+	// secret parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *SecretParameter) ResolveValue(context parameter.ResolveContext) (interface{}, error) {
+	var val string
+	var err error
+
+	switch {
+	case p.File != "":
+		val, err = p.readDotenvFile()
+	case p.Dir != "":
+		val, err = p.readSecretDir()
+	default:
+		val, err = p.runCommand()
+	}
+	if err != nil {
+		return nil, parameter.NewParameterResolveValueError(context, err.Error())
+	}
+
+	escaped, err := template.EscapeSpecialCharactersInValue(val, template.FullStringEscapeFunction)
+	if err != nil {
+		return nil, err
+	}
+	return secretutils.MaskedString(stringutils.ToString(escaped)), nil
+}
+
+func (p *SecretParameter) path(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(p.WorkingDirectory, path)
+}
+
+func (p *SecretParameter) readDotenvFile() (string, error) {
+	content, err := afero.ReadFile(p.Fs, p.path(p.File))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	values, err := parseDotenv(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse secrets file %q: %w", p.File, err)
+	}
+
+	val, found := values[p.Name]
+	if !found {
+		return "", fmt.Errorf("secret `%s` not found in secrets file %q", p.Name, p.File)
+	}
+	return val, nil
+}
+
+func (p *SecretParameter) readSecretDir() (string, error) {
+	content, err := afero.ReadFile(p.Fs, filepath.Join(p.path(p.Dir), p.Name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret `%s` from directory %q: %w", p.Name, p.Dir, err)
+	}
+	return trimTrailingNewline(string(content)), nil
+}
+
+func (p *SecretParameter) runCommand() (string, error) {
+	val, err := secretutils.RunCommand(context.Background(), secretutils.Command{
+		Args: p.Command,
+		Dir:  p.WorkingDirectory,
+		Env:  []string{SecretNameEnvKey + "=" + p.Name},
+	})
+	if err != nil {
+		return "", fmt.Errorf("secret helper %w", err)
+	}
+	return val, nil
+}
+
+func trimTrailingNewline(s string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")
+}
+
+// parseDotenv parses the content of a dotenv-style file. Each non-empty line that is not a comment (starting with `#`)
+// has to be of the form `NAME=value`, optionally prefixed with `export`. Values may be enclosed in single quotes,
+// which are used literally, or in double quotes, which support Go escape sequences like `\n`.
+func parseDotenv(content []byte) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("line %d: expected `NAME=value`", lineNumber)
+		}
+
+		value, err := unquote(strings.TrimSpace(value))
+		if err != nil {
+			// the value is not part of the error, as it is sensitive
+			return nil, fmt.Errorf("line %d: malformed value of `%s`", lineNumber, name)
+		}
+		values[name] = value
+	}
+	return values, scanner.Err()
+}
+
+func unquote(value string) (string, error) {
+	if len(value) < 2 {
+		return value, nil
+	}
+	switch {
+	case value[0] == '"' && value[len(value)-1] == '"':
+		return strconv.Unquote(value)
+	case value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1], nil
+	case value[0] == '"' || value[0] == '\'':
+		return "", errors.New("unterminated quote")
+	}
+	return value, nil
+}
+
+// parseSecretParameter parses a SecretParameter from a given context. Exactly one of `file`, `dir` and `command` is
+// required. `name` is required for `file` and `dir`, and optional for `command`.
+func parseSecretParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	p := &SecretParameter{
+		Fs:               context.Fs,
+		WorkingDirectory: context.WorkingDirectory,
+	}
+
+	for key, target := range map[string]*string{"name": &p.Name, "file": &p.File, "dir": &p.Dir} {
+		if v, ok := context.Value[key]; ok {
+			s, ok := v.(string)
+			if !ok || s == "" {
+				return nil, parameter.NewParameterParserError(context, fmt.Sprintf("property `%s` must be a non-empty string", key))
+			}
+			*target = s
+		}
+	}
+
+	if c, ok := context.Value["command"]; ok {
+		command, ok := c.([]interface{})
+		if !ok || len(command) == 0 {
+			return nil, parameter.NewParameterParserError(context, "property `command` must be a non-empty list of the program and its arguments")
+		}
+		for _, arg := range command {
+			p.Command = append(p.Command, stringutils.ToString(arg))
+		}
+	}
+
+	sources := 0
+	for _, set := range []bool{p.File != "", p.Dir != "", p.Command != nil} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, parameter.NewParameterParserError(context, "exactly one of `file`, `dir` or `command` is required")
+	}
+
+	if p.Command == nil && p.Name == "" {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+	if p.Command == nil && p.Fs == nil {
+		return nil, parameter.NewParameterParserError(context, "missing filesystem handle to load parameter")
+	}
+
+	return p, nil
+}
+
+func writeSecretParameter(context parameter.ParameterWriterContext) (map[string]interface{}, error) {
+	secretParam, ok := context.Parameter.(*SecretParameter)
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `SecretParameter`")
+	}
+
+	result := make(map[string]interface{})
+	if secretParam.Name != "" {
+		result["name"] = secretParam.Name
+	}
+
+	switch {
+	case secretParam.File != "":
+		result["file"] = secretParam.File
+	case secretParam.Dir != "":
+		result["dir"] = secretParam.Dir
+	default:
+		command := make([]interface{}, len(secretParam.Command))
+		for i, arg := range secretParam.Command {
+			command[i] = arg
+		}
+		result["command"] = command
+	}
+
+	return result, nil
+}
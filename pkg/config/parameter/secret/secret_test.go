@@ -0,0 +1,191 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	secretutils "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+func TestParseSecretParameter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		want    *SecretParameter
+		wantErr string
+	}{
+		{
+			name:  "dotenv file",
+			value: map[string]interface{}{"name": "API_KEY", "file": "../secrets.env"},
+			want:  &SecretParameter{Name: "API_KEY", File: "../secrets.env", Fs: fs, WorkingDirectory: "project"},
+		},
+		{
+			name:  "secret directory",
+			value: map[string]interface{}{"name": "api-key", "dir": "/var/run/secrets/monaco"},
+			want:  &SecretParameter{Name: "api-key", Dir: "/var/run/secrets/monaco", Fs: fs, WorkingDirectory: "project"},
+		},
+		{
+			name:  "helper command without name",
+			value: map[string]interface{}{"command": []interface{}{"pass", "show", "api-key"}},
+			want:  &SecretParameter{Command: []string{"pass", "show", "api-key"}, Fs: fs, WorkingDirectory: "project"},
+		},
+		{
+			name:    "no source",
+			value:   map[string]interface{}{"name": "API_KEY"},
+			wantErr: "exactly one of `file`, `dir` or `command` is required",
+		},
+		{
+			name:    "several sources",
+			value:   map[string]interface{}{"name": "API_KEY", "file": "secrets.env", "dir": "secrets"},
+			wantErr: "exactly one of `file`, `dir` or `command` is required",
+		},
+		{
+			name:    "file without name",
+			value:   map[string]interface{}{"file": "secrets.env"},
+			wantErr: "missing property `name`",
+		},
+		{
+			name:    "command is no list",
+			value:   map[string]interface{}{"command": "pass show api-key"},
+			wantErr: "property `command` must be a non-empty list",
+		},
+		{
+			name:    "name is no string",
+			value:   map[string]interface{}{"name": 42, "file": "secrets.env"},
+			wantErr: "property `name` must be a non-empty string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSecretParameter(parameter.ParameterParserContext{Fs: fs, WorkingDirectory: "project", Value: tt.value})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriteSecretParameter_RoundTrips(t *testing.T) {
+	for _, p := range []*SecretParameter{
+		{Name: "API_KEY", File: "secrets.env"},
+		{Name: "api-key", Dir: "secrets"},
+		{Name: "api-key", Command: []string{"pass", "show", "api-key"}},
+	} {
+		written, err := writeSecretParameter(parameter.ParameterWriterContext{Parameter: p})
+		require.NoError(t, err)
+
+		parsed, err := parseSecretParameter(parameter.ParameterParserContext{Fs: afero.NewMemMapFs(), Value: written})
+		require.NoError(t, err)
+		assert.Equal(t, p.Name, parsed.(*SecretParameter).Name)
+		assert.Equal(t, p.File, parsed.(*SecretParameter).File)
+		assert.Equal(t, p.Dir, parsed.(*SecretParameter).Dir)
+		assert.Equal(t, p.Command, parsed.(*SecretParameter).Command)
+	}
+}
+
+func TestParseDotenv(t *testing.T) {
+	content := `
+# comment
+PLAIN=value
+export EXPORTED=exported
+SPACED = spaced value
+SINGLE='literal \n'
+DOUBLE="line\nbreak \"quoted\""
+EMPTY=
+WITH_EQUALS=a=b
+`
+	got, err := parseDotenv([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PLAIN":       "value",
+		"EXPORTED":    "exported",
+		"SPACED":      "spaced value",
+		"SINGLE":      `literal \n`,
+		"DOUBLE":      "line\nbreak \"quoted\"",
+		"EMPTY":       "",
+		"WITH_EQUALS": "a=b",
+	}, got)
+
+	_, err = parseDotenv([]byte("NO_VALUE"))
+	assert.ErrorContains(t, err, "line 1")
+
+	_, err = parseDotenv([]byte("UNTERMINATED=\"s3cr3t"))
+	assert.ErrorContains(t, err, "line 1: malformed value of `UNTERMINATED`")
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func TestResolveValue(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("project", "secrets.env"), []byte("API_KEY=\"s3cr3t \\\"quoted\\\"\"\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("secrets", "api-key"), []byte("from-dir\n"), 0644))
+
+	t.Run("resolves masked value from dotenv file", func(t *testing.T) {
+		p := &SecretParameter{Name: "API_KEY", File: "secrets.env", Fs: fs, WorkingDirectory: "project"}
+		got, err := p.ResolveValue(parameter.ResolveContext{})
+		require.NoError(t, err)
+		assert.Equal(t, secretutils.MaskedString(`s3cr3t \"quoted\"`), got, "value is expected to be escaped for JSON")
+	})
+
+	t.Run("returns error if secret is missing in dotenv file", func(t *testing.T) {
+		p := &SecretParameter{Name: "OTHER", File: "secrets.env", Fs: fs, WorkingDirectory: "project"}
+		_, err := p.ResolveValue(parameter.ResolveContext{})
+		assert.ErrorContains(t, err, "secret `OTHER` not found")
+	})
+
+	t.Run("resolves masked value from secret directory", func(t *testing.T) {
+		p := &SecretParameter{Name: "api-key", Dir: "../secrets", Fs: fs, WorkingDirectory: "project"}
+		got, err := p.ResolveValue(parameter.ResolveContext{})
+		require.NoError(t, err)
+		assert.Equal(t, secretutils.MaskedString("from-dir"), got)
+	})
+
+	t.Run("resolves masked value from helper command", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("helper command in this test requires a POSIX shell")
+		}
+
+		p := &SecretParameter{Name: "api-key", Command: []string{"sh", "-c", `echo "value-of-$MONACO_SECRET_NAME"`}, WorkingDirectory: t.TempDir()}
+		got, err := p.ResolveValue(parameter.ResolveContext{})
+		require.NoError(t, err)
+		assert.Equal(t, secretutils.MaskedString("value-of-api-key"), got)
+	})
+
+	t.Run("returns error if helper command fails", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("helper command in this test requires a POSIX shell")
+		}
+
+		p := &SecretParameter{Command: []string{"sh", "-c", "echo s3cr3t; echo denied >&2; exit 1"}, WorkingDirectory: t.TempDir()}
+		_, err := p.ResolveValue(parameter.ResolveContext{})
+		assert.ErrorContains(t, err, "denied")
+		assert.NotContains(t, err.Error(), "s3cr3t")
+	})
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	entitySelectorParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/entityselector"
+	lookupParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
 )
 
 // resolveValues validates and resolves the given sorted parameters into actual values
@@ -28,6 +30,10 @@ func resolveValues(c *Config, entities EntityLookup, parameters []parameter.Name
 	var errors []error
 
 	properties := make(parameter.Properties)
+	objectLookup, _ := entities.(parameter.ObjectLookup)
+	monitoredEntityLookup, _ := entities.(parameter.MonitoredEntityLookup)
+	placeholderLookup, _ := entities.(parameter.PlaceholderLookup)
+	returnsPlaceholders := placeholderLookup != nil && placeholderLookup.ReturnsPlaceholders()
 
 	for _, container := range parameters {
 		name := container.Name
@@ -40,20 +46,32 @@ func resolveValues(c *Config, entities EntityLookup, parameters []parameter.Name
 			continue
 		}
 
-		val, err := param.ResolveValue(parameter.ResolveContext{
+		resolveContext := parameter.ResolveContext{
 			PropertyResolver:        entities,
+			ObjectLookup:            objectLookup,
+			MonitoredEntityLookup:   monitoredEntityLookup,
 			ConfigCoordinate:        c.Coordinate,
 			Group:                   c.Group,
 			Environment:             c.Environment,
 			ParameterName:           name,
 			ResolvedParameterValues: properties,
-		})
+		}
+
+		val, err := param.ResolveValue(resolveContext)
 
 		if err != nil {
 			errors = append(errors, err)
 			continue
 		}
 
+		// placeholders of looked up values, e.g. in dry-run mode, do not satisfy the constraints of the actual values
+		if constraint, found := c.ParameterConstraints[name]; found && !(returnsPlaceholders && isLookupParameter(param)) {
+			if err := constraint.Check(val); err != nil {
+				errors = append(errors, parameter.NewParameterResolveValueError(resolveContext, err.Error()))
+				continue
+			}
+		}
+
 		if name == NameParameter {
 			properties[name] = strings.ToString(val)
 		} else {
@@ -70,6 +88,12 @@ func resolveValues(c *Config, entities EntityLookup, parameters []parameter.Name
 	return properties, nil
 }
 
+// isLookupParameter returns whether the given parameter resolves to a value looked up in the environment.
+func isLookupParameter(p parameter.Parameter) bool {
+	t := p.GetType()
+	return t == lookupParam.LookupParameterType || t == entitySelectorParam.EntitySelectorParameterType
+}
+
 func validateParameterReferences(configCoordinates coordinate.Coordinate, group string, environment string, entityLookup EntityLookup, paramName string, param parameter.Parameter) (errs []error) {
 
 	for _, ref := range param.GetReferences() {
@@ -78,7 +102,7 @@ func validateParameterReferences(configCoordinates coordinate.Coordinate, group
 		// the parameters
 		if ref.Config == configCoordinates {
 			// parameters referencing themselves makes no sense
-			if ref.Property == paramName {
+			if ref.Property == paramName || referencedParameterName(ref.Property) == paramName {
 				errs = append(errs, newParamsRefErr(configCoordinates, group, environment, paramName, ref, "parameter referencing itself"))
 			}
 
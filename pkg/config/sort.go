@@ -107,7 +107,7 @@ func parameterReference(sourceParam parameter.NamedParameter, config coordinate.
 		if vp, ok := targetParam.Parameter.(*value.ValueParameter); ok {
 			match = searchValueParameterForKey(ref.Property, targetParam.Name, vp)
 		} else {
-			match = ref.Property == targetParam.Name
+			match = ref.Property == targetParam.Name || referencedParameterName(ref.Property) == targetParam.Name
 		}
 
 		if match {
@@ -118,15 +118,23 @@ func parameterReference(sourceParam parameter.NamedParameter, config coordinate.
 }
 
 func searchValueParameterForKey(key string, paramName string, param *value.ValueParameter) bool {
-	if vpm, ok := param.Value.(map[any]any); ok {
-		if first, rest, found := strings.Cut(key, "."); found && first == paramName {
-			_, isRef := entities.ResolvePropValue(rest, vpm)
-			return isRef
-		}
+	if path, err := entities.ParsePropertyPath(key); err == nil && len(path) > 1 && path[0].Key == paramName {
+		_, isRef := path[1:].Resolve(param.Value)
+		return isRef
 	}
 	return key == paramName
 }
 
+// referencedParameterName returns the name of the parameter a reference to a nested property refers to, e.g. `rules`
+// for `rules[0].id`.
+func referencedParameterName(property string) string {
+	path, err := entities.ParsePropertyPath(property)
+	if err != nil || len(path) == 0 || path[0].IsIndex {
+		return ""
+	}
+	return path[0].Key
+}
+
 func logDependency(prefix string, depending string, dependedOn string) {
 	log.Debug("%s: %s has dependency on %s", prefix, depending, dependedOn)
 }
@@ -19,10 +19,13 @@ import (
 	"fmt"
 	"strings"
 	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 )
 
 // Render tries to render a given template with the given properties and returns the
 // resulting string. if any error occurs during rendering, an error is returned.
+// Masked secrets within the properties are rendered with their actual value.
 func Render(template Template, properties map[string]interface{}) (string, error) {
 	content, err := template.Content()
 	if err != nil {
@@ -41,7 +44,8 @@ func Render(template Template, properties map[string]interface{}) (string, error
 
 	result := bytes.Buffer{}
 
-	err = parsedTemplate.Execute(&result, properties)
+	unmaskedProperties, _ := secret.Unmask(properties)
+	err = parsedTemplate.Execute(&result, unmaskedProperties)
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
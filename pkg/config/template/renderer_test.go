@@ -22,6 +22,8 @@ import (
 	"reflect"
 	"testing"
 	templ "text/template" // nosemgrep: go.lang.security.audit.xss.import-text-template.import-text-template
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 )
 
 const (
@@ -107,6 +109,15 @@ func TestRender(t *testing.T) {
 			`{ "key": the-key }`,
 			false,
 		},
+		{
+			"renders masked secrets with their actual value",
+			&InMemoryTemplate{
+				content: simpleTemplateString,
+			},
+			map[string]interface{}{"val": secret.MaskedString("s3cr3t")},
+			`{ "key": s3cr3t }`,
+			false,
+		},
 		{
 			"renders simple template containing three subsequent {",
 			&InMemoryTemplate{
@@ -42,6 +42,9 @@ type WriterContext struct {
 	OutputFolder    string
 	ProjectFolder   string
 	ParametersSerde map[string]parameter.ParameterSerDe
+	// ExtractSharedParameters writes parameters all configs define with identical values to the shared parameters file
+	// of the project, instead of repeating them in every config
+	ExtractSharedParameters bool
 }
 
 type serializerContext struct {
@@ -74,6 +77,13 @@ type configTemplate struct {
 }
 
 func WriteConfigs(context *WriterContext, configs []config.Config) []error {
+	if context.ExtractSharedParameters {
+		var err error
+		if configs, err = writeSharedParameters(context, configs); err != nil {
+			return []error{err}
+		}
+	}
+
 	definitions, templates, errs := toTopLevelDefinitions(context, configs)
 
 	if len(errs) > 0 {
@@ -754,6 +764,8 @@ func newParameterSerializerContext(context *detailedSerializerContext, name stri
 		Environment:   context.environmentDetails.environment,
 		ParameterName: name,
 		Parameter:     param,
+
+		ParametersSerDe: context.ParametersSerde,
 	}
 }
 
@@ -0,0 +1,125 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+)
+
+// writeSharedParameters writes the parameters all configs define with identical values to the shared parameters file
+// of the project, and returns the configs without these parameters. Nothing is written if no parameters are shared.
+func writeSharedParameters(context *WriterContext, configs []config.Config) ([]config.Config, error) {
+	shared, err := findSharedParameters(context, configs)
+	if err != nil {
+		return nil, err
+	}
+	if len(shared) == 0 {
+		return configs, nil
+	}
+
+	data, err := yaml.Marshal(persistence.SharedParametersDefinition{Parameters: shared})
+	if err != nil {
+		return nil, newConfigWriterError(context, err)
+	}
+
+	projectFolder := filepath.Join(context.OutputFolder, context.ProjectFolder)
+	if err := context.Fs.MkdirAll(projectFolder, 0777); err != nil {
+		return nil, newConfigWriterError(context, err)
+	}
+	if err := afero.WriteFile(context.Fs, filepath.Join(projectFolder, persistence.SharedParametersFileName), data, 0664); err != nil {
+		return nil, newConfigWriterError(context, err)
+	}
+
+	reduced := make([]config.Config, len(configs))
+	for i, c := range configs {
+		parameters := make(config.Parameters, len(c.Parameters))
+		for name, param := range c.Parameters {
+			if _, found := shared[name]; !found {
+				parameters[name] = param
+			}
+		}
+		c.Parameters = parameters
+		reduced[i] = c
+	}
+	return reduced, nil
+}
+
+// findSharedParameters returns the serialized parameters all configs define with identical values. Parameters are
+// compared in their serialized form, as e.g. references are serialized relative to the config they belong to.
+// Parameters are only shared between at least two configs.
+func findSharedParameters(context *WriterContext, configs []config.Config) (map[string]persistence.ConfigParameter, error) {
+	coordinates := make(map[coordinate.Coordinate]struct{})
+	for _, c := range configs {
+		coordinates[c.Coordinate] = struct{}{}
+	}
+	if len(coordinates) < 2 {
+		return nil, nil
+	}
+
+	var shared map[string]persistence.ConfigParameter
+	for i, c := range configs {
+		detailedContext := &detailedSerializerContext{
+			serializerContext:  &serializerContext{WriterContext: context, config: c.Coordinate},
+			environmentDetails: environmentDetails{group: c.Group, environment: c.Environment},
+		}
+
+		serialized := make(map[string]persistence.ConfigParameter)
+		for name, param := range c.Parameters {
+			if !isShareableParameter(name) {
+				continue
+			}
+			if _, found := shared[name]; i > 0 && !found {
+				continue
+			}
+
+			definition, err := toParameterDefinition(detailedContext, name, param)
+			if err != nil {
+				return nil, err
+			}
+			serialized[name] = definition
+		}
+
+		if i == 0 {
+			shared = serialized
+			continue
+		}
+
+		for name, definition := range shared {
+			if other, found := serialized[name]; !found || !reflect.DeepEqual(definition, other) {
+				delete(shared, name)
+			}
+		}
+	}
+	return shared, nil
+}
+
+// isShareableParameter returns whether a parameter may be defined in the shared parameters file, which is not the case
+// for parameters with a special meaning.
+func isShareableParameter(name string) bool {
+	return !slices.Contains(config.ReservedParameterNames, name) &&
+		name != config.InsertAfterParameter &&
+		name != config.NonUniqueNameConfigDuplicationParameter
+}
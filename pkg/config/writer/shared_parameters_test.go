@@ -0,0 +1,126 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package writer
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+func newSharedParametersTestConfig(configType, configId string, parameters config.Parameters) config.Config {
+	parameters[config.NameParameter] = value.New(configId)
+	return config.Config{
+		Template:   template.NewInMemoryTemplateWithPath("project/"+configType+"/"+configId+".json", "{}"),
+		Coordinate: coordinate.Coordinate{Project: "project", Type: configType, ConfigId: configId},
+		Type:       config.ClassicApiType{Api: configType},
+		Parameters: parameters,
+	}
+}
+
+func TestWriteConfigs_ExtractsSharedParameters(t *testing.T) {
+	zoneReference := func() parameter.Parameter {
+		return refParam.New("project", "management-zone", "zone", "id")
+	}
+	configs := []config.Config{
+		newSharedParametersTestConfig("alerting-profile", "a", config.Parameters{
+			"owner":     value.New("team-a"),
+			"zone":      zoneReference(),
+			"threshold": value.New(1),
+		}),
+		newSharedParametersTestConfig("alerting-profile", "b", config.Parameters{
+			"owner":     value.New("team-a"),
+			"zone":      zoneReference(),
+			"threshold": value.New(2),
+		}),
+		newSharedParametersTestConfig("dashboard", "c", config.Parameters{
+			"owner": value.New("team-a"),
+			"zone":  zoneReference(),
+		}),
+	}
+
+	fs := testutils.TempFs(t)
+	errs := WriteConfigs(&WriterContext{
+		Fs:                      fs,
+		OutputFolder:            "test",
+		ProjectFolder:           "project",
+		ParametersSerde:         config.DefaultParameterParsers,
+		ExtractSharedParameters: true,
+	}, configs)
+	require.Empty(t, errs)
+
+	content, err := afero.ReadFile(fs, "test/project/_parameters.yaml")
+	require.NoError(t, err)
+	var shared persistence.SharedParametersDefinition
+	require.NoError(t, yaml.Unmarshal(content, &shared))
+	assert.Equal(t, map[string]persistence.ConfigParameter{
+		"owner": "team-a",
+		"zone": map[any]any{
+			"type":       "reference",
+			"configType": "management-zone",
+			"configId":   "zone",
+			"property":   "id",
+		},
+	}, shared.Parameters)
+
+	content, err = afero.ReadFile(fs, "test/project/alerting-profile/config.yaml")
+	require.NoError(t, err)
+	var profiles persistence.TopLevelDefinition
+	require.NoError(t, yaml.Unmarshal(content, &profiles))
+	require.Len(t, profiles.Configs, 2)
+	for _, c := range profiles.Configs {
+		assert.Contains(t, c.Config.Parameters, "threshold")
+		assert.NotContains(t, c.Config.Parameters, "owner")
+		assert.NotContains(t, c.Config.Parameters, "zone")
+	}
+
+	assert.Contains(t, configs[0].Parameters, "owner", "configs passed to the writer must not be modified")
+}
+
+func TestWriteConfigs_DoesNotExtractSharedParametersOfSingleConfig(t *testing.T) {
+	configs := []config.Config{
+		newSharedParametersTestConfig("alerting-profile", "a", config.Parameters{"owner": value.New("team-a")}),
+	}
+
+	fs := testutils.TempFs(t)
+	errs := WriteConfigs(&WriterContext{
+		Fs:                      fs,
+		OutputFolder:            "test",
+		ProjectFolder:           "project",
+		ParametersSerde:         config.DefaultParameterParsers,
+		ExtractSharedParameters: true,
+	}, configs)
+	require.Empty(t, errs)
+
+	exists, err := afero.Exists(fs, "test/project/_parameters.yaml")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
 )
 
@@ -155,3 +156,40 @@ func DeleteAll(ctx context.Context, c client.SettingsClient) error {
 
 	return nil
 }
+
+// FindOrphans collects pointers to all settings objects monaco deployed, for which orphaned returns true when called
+// with the coordinate restored from the object's external ID. Objects which are not deletable are ignored.
+func FindOrphans(ctx context.Context, c client.SettingsClient, orphaned func(coordinate.Coordinate) bool) ([]pointer.DeletePointer, error) {
+	schemas, err := c.ListSchemas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch settings schemas: %w", err)
+	}
+
+	var orphans []pointer.DeletePointer
+	for _, s := range schemas {
+		filter := func(o dtclient.DownloadSettingsObject) bool {
+			coord, ok := idutils.ParseExternalIDForSettingsObject(o.ExternalId)
+			return ok && coord.Type == s.SchemaId && orphaned(coord)
+		}
+
+		settingsObjects, err := c.List(ctx, s.SchemaId, dtclient.ListSettingsOptions{DiscardValue: true, Filter: filter})
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect objects for schema %q: %w", s.SchemaId, err)
+		}
+
+		for _, settingsObject := range settingsObjects {
+			if !settingsObject.IsDeletable() {
+				continue
+			}
+
+			coord, _ := idutils.ParseExternalIDForSettingsObject(settingsObject.ExternalId)
+			orphans = append(orphans, pointer.DeletePointer{
+				Project:    coord.Project,
+				Type:       coord.Type,
+				Identifier: coord.ConfigId,
+			})
+		}
+	}
+
+	return orphans, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package delete
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/internal/setting"
+)
+
+// ErrPruneClientUnavailable is returned by Orphans if the client required to find orphaned objects is unavailable.
+var ErrPruneClientUnavailable = errors.New("settings client unavailable")
+
+// Orphans collects DeleteEntries for all objects in the environment, which monaco deployed as part of one of the
+// given projects, but whose configuration is not one of the given existing coordinates anymore.
+//
+// Only Settings 2.0 objects are considered, as their external IDs are the only ones the coordinate of the
+// configuration can be restored from. Other types, like Documents or Automations, carry IDs derived from a hash of
+// the coordinate, so it is not possible to tell which project they belong to.
+func Orphans(ctx context.Context, clients client.ClientSet, projects []string, existing []coordinate.Coordinate) (DeleteEntries, error) {
+	if clients.SettingsClient == nil {
+		return nil, ErrPruneClientUnavailable
+	}
+
+	existingCoordinates := make(map[coordinate.Coordinate]struct{}, len(existing))
+	for _, c := range existing {
+		existingCoordinates[c] = struct{}{}
+	}
+
+	orphaned := func(c coordinate.Coordinate) bool {
+		if !slices.Contains(projects, c.Project) {
+			return false
+		}
+		_, found := existingCoordinates[c]
+		return !found
+	}
+
+	pointers, err := setting.FindOrphans(ctx, clients.SettingsClient, orphaned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orphaned settings objects: %w", err)
+	}
+
+	entries := make(DeleteEntries)
+	for _, p := range pointers {
+		entries[p.Type] = append(entries[p.Type], p)
+	}
+	return entries, nil
+}
@@ -0,0 +1,76 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package delete_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+)
+
+func TestOrphans(t *testing.T) {
+	existing := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "existing"}
+	removed := coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "removed"}
+	otherProject := coordinate.Coordinate{Project: "other", Type: "builtin:alerting.profile", ConfigId: "removed"}
+
+	externalID := func(c coordinate.Coordinate) string {
+		id, err := idutils.GenerateExternalIDForSettingsObject(c)
+		require.NoError(t, err)
+		return id
+	}
+	deletable := &dtclient.SettingsResourceContext{Operations: []string{dtclient.DeleteOperation}}
+
+	c := client.NewMockSettingsClient(gomock.NewController(t))
+	c.EXPECT().ListSchemas(gomock.Any()).Return(dtclient.SchemaList{{SchemaId: "builtin:alerting.profile"}}, nil)
+	c.EXPECT().List(gomock.Any(), "builtin:alerting.profile", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, opts dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+		var result []dtclient.DownloadSettingsObject
+		for _, o := range []dtclient.DownloadSettingsObject{
+			{ObjectId: "1", ExternalId: externalID(existing), ResourceContext: deletable},
+			{ObjectId: "2", ExternalId: externalID(removed), ResourceContext: deletable},
+			{ObjectId: "3", ExternalId: externalID(otherProject), ResourceContext: deletable},
+			{ObjectId: "4", ExternalId: "not-managed-by-monaco", ResourceContext: deletable},
+		} {
+			if opts.Filter(o) {
+				result = append(result, o)
+			}
+		}
+		return result, nil
+	})
+
+	entries, err := delete.Orphans(t.Context(), client.ClientSet{SettingsClient: c}, []string{"project"}, []coordinate.Coordinate{existing})
+	require.NoError(t, err)
+	assert.Equal(t, delete.DeleteEntries{
+		"builtin:alerting.profile": {
+			{Project: "project", Type: "builtin:alerting.profile", Identifier: "removed"},
+		},
+	}, entries)
+}
+
+func TestOrphans_FailsWithoutSettingsClient(t *testing.T) {
+	_, err := delete.Orphans(t.Context(), client.ClientSet{}, []string{"project"}, nil)
+	assert.ErrorIs(t, err, delete.ErrPruneClientUnavailable)
+}
@@ -16,9 +16,11 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gonum "gonum.org/v1/gonum/graph"
@@ -34,9 +36,13 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/validate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
@@ -59,6 +65,35 @@ type DeployConfigsOptions struct {
 	// DryRun states that the deployment shall just run in dry-run mode, meaning
 	// that actual deployment of the configuration to a tenant will be skipped
 	DryRun bool
+	// Plan states that the deployment shall just run in plan mode, if set. Instead of deploying
+	// configurations, the remote objects they would be deployed to are looked up and compared
+	// to the rendered configurations. The outcome for each configuration is recorded in the Plan.
+	Plan *plan.Plan
+	// AppliedPlan states that the deployment shall deploy the payloads recorded in the given plan,
+	// instead of the rendered configurations, if set. Configurations whose remote object changed
+	// since the plan was computed are not deployed.
+	AppliedPlan *plan.Plan
+	// Selector restricts the deployment to the selected configurations, if set. Configurations
+	// the selected ones depend on are deployed as well, so that all references can be resolved.
+	Selector ConfigSelector
+	// Changed restricts the deployment to the selected changed configurations and all configurations
+	// depending on them, if set. It is applied before Selector.
+	Changed ConfigSelector
+	// MaxParallelEnvironments is the maximum number of environments deployed to in parallel. If it
+	// is not set, the limit defined by the MONACO_PARALLEL_ENVIRONMENTS environment variable is used.
+	MaxParallelEnvironments int
+	// SnapshotStore records the state of each remote object before it is changed, if set. The
+	// recorded state allows to roll back the deployment using Rollback.
+	SnapshotStore *snapshot.Store
+	// Hooks runs the commands defined to run before and after deploying to an environment, if set.
+	// If a pre-deploy hook fails, the environment is not deployed to.
+	Hooks *hook.Runner
+}
+
+// stopOnError returns whether a deployment shall stop after the first error. Dry-runs and plans
+// never change an environment, so they always continue to report as many errors as possible.
+func (o DeployConfigsOptions) stopOnError() bool {
+	return !o.ContinueOnErr && !o.DryRun && o.Plan == nil
 }
 
 var (
@@ -79,6 +114,56 @@ func getDeploymentLimiterFromContext(ctx context.Context) *rest.ConcurrentReques
 	return nil
 }
 
+type ctxDryRunKey struct{}
+
+func newContextWithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ctxDryRunKey{}, true)
+}
+
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(ctxDryRunKey{}).(bool)
+	return dryRun
+}
+
+type ctxPlanKey struct{}
+
+func newContextWithPlan(ctx context.Context, p *plan.Plan) context.Context {
+	return context.WithValue(ctx, ctxPlanKey{}, p)
+}
+
+func getPlanFromContext(ctx context.Context) *plan.Plan {
+	if p, ok := ctx.Value(ctxPlanKey{}).(*plan.Plan); ok {
+		return p
+	}
+	return nil
+}
+
+type ctxAppliedPlanKey struct{}
+
+func newContextWithAppliedPlan(ctx context.Context, p *plan.Plan) context.Context {
+	return context.WithValue(ctx, ctxAppliedPlanKey{}, p)
+}
+
+func getAppliedPlanFromContext(ctx context.Context) *plan.Plan {
+	if p, ok := ctx.Value(ctxAppliedPlanKey{}).(*plan.Plan); ok {
+		return p
+	}
+	return nil
+}
+
+type ctxSnapshotStoreKey struct{}
+
+func newContextWithSnapshotStore(ctx context.Context, s *snapshot.Store) context.Context {
+	return context.WithValue(ctx, ctxSnapshotStoreKey{}, s)
+}
+
+func getSnapshotStoreFromContext(ctx context.Context) *snapshot.Store {
+	if s, ok := ctx.Value(ctxSnapshotStoreKey{}).(*snapshot.Store); ok {
+		return s
+	}
+	return nil
+}
+
 func DeployForAllEnvironments(ctx context.Context, projects []project.Project, environmentClients dynatrace.EnvironmentClients, opts DeployConfigsOptions) error {
 	maxConcurrentDeployments := environment.GetEnvValueIntLog(environment.ConcurrentDeploymentsEnvKey)
 	if maxConcurrentDeployments > 0 {
@@ -86,12 +171,24 @@ func DeployForAllEnvironments(ctx context.Context, projects []project.Project, e
 		limiter := rest.NewConcurrentRequestLimiter(maxConcurrentDeployments)
 		ctx = newContextWithDeploymentLimiter(ctx, limiter)
 	}
+	if opts.DryRun {
+		ctx = newContextWithDryRun(ctx)
+	}
+	if opts.Plan != nil {
+		ctx = newContextWithPlan(ctx, opts.Plan)
+	}
+	if opts.AppliedPlan != nil {
+		ctx = newContextWithAppliedPlan(ctx, opts.AppliedPlan)
+	}
+	if opts.SnapshotStore != nil {
+		ctx = newContextWithSnapshotStore(ctx, opts.SnapshotStore)
+	}
 	deploymentErrs := make(deployErrors.EnvironmentDeploymentErrors)
 
 	// note: Currently the validation works 'environment-independent', but that might be something we should reconsider to improve error messages
 	if validationErrs := validate.Validate(projects); validationErrs != nil {
 		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, validationErrs, "", nil)
-		if !opts.ContinueOnErr && !opts.DryRun {
+		if opts.stopOnError() {
 			return validationErrs
 		}
 		errors.As(validationErrs, &deploymentErrs)
@@ -101,11 +198,33 @@ func DeployForAllEnvironments(ctx context.Context, projects []project.Project, e
 
 	envNames := environmentClients.Names()
 	g := graph.New(projects, envNames)
+	if opts.Changed != nil {
+		for _, env := range envNames {
+			if err := g.SelectWithDependents(env, func(c config.Config) bool { return opts.Changed(env, c) }); err != nil {
+				reporter.ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+		}
+	}
+	if opts.Selector != nil {
+		for _, env := range envNames {
+			if err := g.SelectWithDependencies(env, func(c config.Config) bool { return opts.Selector(env, c) }); err != nil {
+				reporter.ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+		}
+	}
 	envConfigs, err := getSortedEnvConfigs(g, envNames)
 	if err != nil {
 		reporter.ReportLoading(report.StateError, err, "", nil)
 		return err
 	}
+	if opts.AppliedPlan != nil {
+		if err := opts.AppliedPlan.Validate(deployedCoordinates(envConfigs)); err != nil {
+			reporter.ReportLoading(report.StateError, err, "", nil)
+			return err
+		}
+	}
 
 	projectString := "project"
 	if len(projects) > 1 {
@@ -114,24 +233,53 @@ func DeployForAllEnvironments(ctx context.Context, projects []project.Project, e
 	reporter.ReportInfo(fmt.Sprintf("%d %v validated", len(projects), projectString))
 	defer reporter.ReportInfo("Deployment finished")
 
+	maxParallelEnvironments := opts.MaxParallelEnvironments
+	if maxParallelEnvironments <= 0 {
+		maxParallelEnvironments = max(environment.GetEnvValueIntLog(environment.ParallelEnvironmentsEnvKey), 1)
+	}
+	if maxParallelEnvironments > 1 {
+		log.InfoContext(ctx, "Deploying to up to %d environments in parallel", maxParallelEnvironments)
+	}
+
+	var (
+		errsMutex sync.Mutex
+		failed    atomic.Bool
+		wg        sync.WaitGroup
+		slots     = make(chan struct{}, maxParallelEnvironments)
+	)
 	for env, clientSet := range environmentClients {
 		sortedConfigs, ok := envConfigs[env.Name]
 		if !ok {
+			wg.Wait()
 			return fmt.Errorf("failed to get independently sorted configs for environment %q", env.Name)
 		}
-		ctx = newContextWithEnvironment(ctx, env)
 
-		if depErr := Deploy(ctx, clientSet, projects, sortedConfigs, env.Name); depErr != nil {
-			log.WithFields(field.Environment(env.Name, env.Group), field.Error(depErr)).ErrorContext(ctx, "Deployment failed for environment '%s': %v", env.Name, depErr)
-			deploymentErrs = deploymentErrs.Append(env.Name, depErr)
+		slots <- struct{}{}
+		if failed.Load() && opts.stopOnError() {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			envCtx := newContextWithEnvironment(ctx, env)
+			envCtx = report.NewContextWithReporter(envCtx, report.ForEnvironment(reporter, env.Name))
+
+			if depErr := deployWithHooks(envCtx, opts.Hooks, env, clientSet, projects, sortedConfigs); depErr != nil {
+				log.WithFields(field.Environment(env.Name, env.Group), field.Error(depErr)).ErrorContext(envCtx, "Deployment failed for environment '%s': %v", env.Name, depErr)
 
-			if !opts.ContinueOnErr && !opts.DryRun {
-				return deploymentErrs
+				errsMutex.Lock()
+				deploymentErrs = deploymentErrs.Append(env.Name, depErr)
+				errsMutex.Unlock()
+				failed.Store(true)
+			} else {
+				log.WithFields(field.Environment(env.Name, env.Group)).InfoContext(envCtx, "Deployment successful for environment '%s'", env.Name)
 			}
-		} else {
-			log.WithFields(field.Environment(env.Name, env.Group)).InfoContext(ctx, "Deployment successful for environment '%s'", env.Name)
-		}
+		}()
 	}
+	wg.Wait()
 
 	if len(deploymentErrs) != 0 {
 		return deploymentErrs
@@ -153,13 +301,48 @@ func createDeployables(clientSet *client.ClientSet) resource.Deployables {
 	}
 }
 
+func createPlannables(clientSet *client.ClientSet) resource.Plannables {
+	return resource.Plannables{
+		config.ServiceLevelObjectiveID: slo.NewPlanAPI(clientSet.ServiceLevelObjectiveClient),
+		config.SegmentID:               segment.NewPlanAPI(clientSet.SegmentClient),
+		config.ClassicApiTypeID:        classic.NewPlanAPI(clientSet.ConfigClient, api.NewAPIs()),
+		config.SettingsTypeID:          settings.NewPlanAPI(clientSet.SettingsClient),
+		config.OpenPipelineTypeID:      openpipeline.NewPlanAPI(clientSet.OpenPipelineClient),
+		config.AutomationTypeID:        automation.NewPlanAPI(clientSet.AutClient),
+		config.DocumentTypeID:          document.NewPlanAPI(clientSet.DocumentClient),
+		config.BucketTypeID:            bucket.NewPlanAPI(clientSet.BucketClient),
+	}
+}
+
 func Deploy(ctx context.Context, clientSet *client.ClientSet, projects []project.Project, sortedConfigs []graph.SortedComponent, environment string) error {
+	return deployEnvironment(ctx, clientSet, projects, sortedConfigs, environment, entities.New())
+}
+
+// deployEnvironment deploys the given configs to an environment, and puts all successfully deployed entities into the
+// given map.
+func deployEnvironment(ctx context.Context, clientSet *client.ClientSet, projects []project.Project, sortedConfigs []graph.SortedComponent, environment string, resolvedEntities *entities.EntityMap) error {
 	preloadCaches(ctx, projects, clientSet, environment)
 	defer clearCaches(clientSet)
+	ctx = newContextWithObjectLookup(ctx, newObjectLookup(clientSet, api.NewAPIs()))
+
+	if p := getPlanFromContext(ctx); p != nil {
+		log.InfoContext(ctx, "Planning deployment of configurations to environment %q...", environment)
+		return deployComponents(ctx, sortedConfigs, plan.NewDeployables(createPlannables(clientSet), p), resolvedEntities)
+	}
+
 	deployables := createDeployables(clientSet)
+	if s := getSnapshotStoreFromContext(ctx); s != nil {
+		deployables = snapshot.NewDeployables(deployables, createPlannables(clientSet), s)
+	}
+
+	if p := getAppliedPlanFromContext(ctx); p != nil {
+		log.InfoContext(ctx, "Applying plan to environment %q...", environment)
+		return deployComponents(ctx, sortedConfigs, plan.NewApplyingDeployables(deployables, createPlannables(clientSet), p), resolvedEntities)
+	}
+
 	log.InfoContext(ctx, "Deploying configurations to environment %q...", environment)
 
-	return deployComponents(ctx, sortedConfigs, deployables)
+	return deployComponents(ctx, sortedConfigs, deployables, resolvedEntities)
 }
 
 // getSortedEnvConfigs sorts the config graphs and checks for certain errors like cyclic dependencies
@@ -175,7 +358,24 @@ func getSortedEnvConfigs(g graph.ConfigGraphPerEnvironment, envNames []string) (
 	return envConfigs, nil
 }
 
-func deployComponents(ctx context.Context, components []graph.SortedComponent, deployables resource.Deployables) error {
+// deployedCoordinates returns the coordinates of the configs that are deployed to each environment. Skipped configs
+// are not deployed, and thus not part of a plan.
+func deployedCoordinates(envConfigs map[string][]graph.SortedComponent) map[string][]coordinate.Coordinate {
+	coordinates := make(map[string][]coordinate.Coordinate, len(envConfigs))
+	for env, components := range envConfigs {
+		coordinates[env] = []coordinate.Coordinate{}
+		for _, component := range components {
+			for _, n := range component.SortedNodes {
+				if c := n.(graph.ConfigNode).Config; !c.Skip {
+					coordinates[env] = append(coordinates[env], c.Coordinate)
+				}
+			}
+		}
+	}
+	return coordinates
+}
+
+func deployComponents(ctx context.Context, components []graph.SortedComponent, deployables resource.Deployables, resolvedEntities *entities.EntityMap) error {
 	log.InfoContext(ctx, "Deploying %d independent configuration sets in parallel...", len(components))
 	errCount := 0
 	errChan := make(chan error, len(components))
@@ -183,7 +383,7 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, d
 	// Iterate over components and launch a goroutine for each component deployment.
 	for i := range components {
 		go func(ctx context.Context, component graph.SortedComponent) {
-			errChan <- deployGraph(ctx, component.Graph, deployables)
+			errChan <- deployGraph(ctx, component.Graph, deployables, resolvedEntities)
 		}(context.WithValue(ctx, log.CtxGraphComponentId{}, log.CtxValGraphComponentId(i)), components[i])
 	}
 
@@ -206,11 +406,11 @@ func deployComponents(ctx context.Context, components []graph.SortedComponent, d
 	return nil
 }
 
-func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, deployables resource.Deployables) error {
+func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, deployables resource.Deployables, resolvedEntities *entities.EntityMap) error {
 	g := simple.NewDirectedGraph()
 	gonum.Copy(g, configGraph)
-	resolvedEntities := entities.New()
 	errCount := 0
+	waits := newDependentWaits()
 
 	errChan := make(chan error)
 	for configGraph.Nodes().Len() != 0 {
@@ -221,7 +421,14 @@ func deployGraph(ctx context.Context, configGraph *simple.DirectedGraph, deploya
 			time.Sleep(api.NewAPIs()[node.Config.Coordinate.Type].DeployWaitDuration)
 
 			go func(ctx context.Context, node graph.ConfigNode) {
-				errChan <- deployNode(ctx, node, configGraph, deployables, resolvedEntities)
+				waits.wait(ctx, node.ID())
+				err := deployNode(ctx, node, configGraph, deployables, resolvedEntities)
+				if err == nil {
+					lock.Lock()
+					waits.deployed(ctx, node, configGraph.From(node.ID()))
+					lock.Unlock()
+				}
+				errChan <- err
 			}(context.WithValue(ctx, log.CtxKeyCoord{}, node.Config.Coordinate), node)
 		}
 
@@ -267,6 +474,13 @@ func deployNode(ctx context.Context, n graph.ConfigNode, configGraph graph.Confi
 		return nil
 	}
 
+	lock.Lock()
+	if !responseReferenced(n, configGraph.From(n.ID())) {
+		// the response is only kept if needed, as it may be large and all resolved entities are kept in memory
+		resolvedEntity.Response = nil
+	}
+	lock.Unlock()
+
 	resolvedEntities.Put(resolvedEntity)
 	report.GetReporterFromContextOrDiscard(ctx).ReportDeployment(n.Config.Coordinate, report.StateSuccess, details, nil)
 	log.WithFields(field.StatusDeployed()).InfoContext(ctx, "Deployment successful")
@@ -328,7 +542,7 @@ func deployConfig(ctx context.Context, c *config.Config, deployables resource.De
 		return entities.ResolvedEntity{}, errSkip // fake resolved entity that "old" deploy creates is never needed, as we don't even try to deploy dependencies of skipped configs (so no reference will ever be attempted to resolve)
 	}
 
-	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	properties, errs := c.ResolveParameterValues(newEntityLookup(ctx, resolvedEntities))
 	if len(errs) > 0 {
 		err := multierror.New(errs...)
 		log.WithFields(field.Error(err), field.StatusDeploymentFailed()).ErrorContext(ctx, "Invalid configuration - failed to resolve parameter values: %v", err)
@@ -347,7 +561,9 @@ func deployConfig(ctx context.Context, c *config.Config, deployables resource.De
 	var resolvedEntity entities.ResolvedEntity
 	var deployErr error
 	if deployable, ok := deployables[c.Type.ID()]; ok {
-		resolvedEntity, deployErr = deployable.Deploy(ctx, properties, renderedConfig, c)
+		resolvedEntity, deployErr = deployWithPolicy(ctx, c.Deployment, func(ctx context.Context) (entities.ResolvedEntity, error) {
+			return deployable.Deploy(ctx, properties, renderedConfig, c)
+		})
 	} else {
 		deployErr = ErrUnknownConfigType{configType: c.Type.ID()}
 	}
@@ -362,6 +578,14 @@ func deployConfig(ctx context.Context, c *config.Config, deployables resource.De
 		log.WithFields(field.Error(deployErr)).ErrorContext(ctx, "Deployment failed - Monaco Error: %v", deployErr)
 		return entities.ResolvedEntity{}, deployErr
 	}
+
+	// if the API response is not available, references to nested values are resolved from the rendered payload
+	if resolvedEntity.Response == nil {
+		var payload any
+		if err := json.Unmarshal([]byte(renderedConfig), &payload); err == nil {
+			resolvedEntity.Response = payload
+		}
+	}
 	return resolvedEntity, nil
 }
 
@@ -20,12 +20,16 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	internalenvironment "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 
@@ -40,12 +44,16 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
 func TestDeployConfigGraph_SingleConfig(t *testing.T) {
@@ -1579,3 +1587,345 @@ func TestDeployDryRun(t *testing.T) {
 		assert.Empty(t, err)
 	})
 }
+
+// recordingSettingsClient is a settings client that records the content of each upserted settings object.
+type recordingSettingsClient struct {
+	dtclient.DummySettingsClient
+	mutex   sync.Mutex
+	content map[string]string
+}
+
+func (c *recordingSettingsClient) Upsert(ctx context.Context, obj dtclient.SettingsObject, opts dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+	c.mutex.Lock()
+	c.content[obj.Coordinate.ConfigId] = string(obj.Content)
+	c.mutex.Unlock()
+	return c.DummySettingsClient.Upsert(ctx, obj, opts)
+}
+
+func TestDeployDryRun_ResolvesReferencesToNestedValuesOfAutomations(t *testing.T) {
+	settingsClient := &recordingSettingsClient{content: map[string]string{}}
+	clientSet := client.DummyClientSet
+	clientSet.SettingsClient = settingsClient
+
+	workflow := coordinate.Coordinate{Project: "p1", Type: "workflow", ConfigId: "wf"}
+	projects := []project.Project{
+		{
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"workflow": {
+						config.Config{
+							Type:        config.AutomationType{Resource: config.Workflow},
+							Environment: "env",
+							Coordinate:  workflow,
+							Template:    template.NewInMemoryTemplate("wf", `{"title": "Workflow", "tasks": [{"id": "task-1"}]}`),
+						},
+					},
+					"builtin:setting": {
+						config.Config{
+							Type:        config.SettingsType{SchemaId: "builtin:setting"},
+							Environment: "env",
+							Coordinate:  coordinate.Coordinate{Project: "p1", Type: "builtin:setting", ConfigId: "setting"},
+							Parameters: config.Parameters{
+								config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+								"taskId":              reference.NewWithCoordinate(workflow, "tasks[0].id"),
+							},
+							Template: template.NewInMemoryTemplate("setting", `{"taskId": "{{.taskId}}"}`),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := deploy.DeployForAllEnvironments(t.Context(), projects, dynatrace.EnvironmentClients{dynatrace.EnvironmentInfo{Name: "env"}: &clientSet}, deploy.DeployConfigsOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"taskId": "task-1"}`, settingsClient.content["setting"])
+}
+
+func TestDeployDryRun_DoesNotCheckConstraintsOfLookedUpValues(t *testing.T) {
+	settingsClient := &recordingSettingsClient{content: map[string]string{}}
+	clientSet := client.DummyClientSet
+	clientSet.SettingsClient = settingsClient
+
+	projects := []project.Project{
+		{
+			Configs: project.ConfigsPerTypePerEnvironments{
+				"env": project.ConfigsPerType{
+					"builtin:setting": {
+						config.Config{
+							Type:        config.SettingsType{SchemaId: "builtin:setting"},
+							Environment: "env",
+							Coordinate:  coordinate.Coordinate{Project: "p1", Type: "builtin:setting", ConfigId: "setting"},
+							Parameters: config.Parameters{
+								config.ScopeParameter: &value.ValueParameter{Value: "environment"},
+								"zoneId":              &lookup.LookupParameter{Query: parameter.ObjectQuery{API: api.ManagementZone, Name: "Team A", Property: "id"}},
+							},
+							ParameterConstraints: map[string]config.ParameterConstraint{
+								"zoneId": {Type: config.IntType},
+							},
+							Template: template.NewInMemoryTemplate("setting", `{"zoneId": "{{.zoneId}}"}`),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := deploy.DeployForAllEnvironments(t.Context(), projects, dynatrace.EnvironmentClients{dynatrace.EnvironmentInfo{Name: "env"}: &clientSet}, deploy.DeployConfigsOptions{DryRun: true})
+	require.NoError(t, err, "the placeholder of the looked up value does not violate the constraint")
+}
+
+// blockingSettingsClient is a settings client that counts the deployments running at the same time and blocks each
+// deployment until release is closed.
+type blockingSettingsClient struct {
+	dtclient.DummySettingsClient
+	mutex     *sync.Mutex
+	active    *int
+	maxActive *int
+	calls     *int
+	started   chan<- struct{}
+	release   <-chan struct{}
+	err       error
+}
+
+func (c *blockingSettingsClient) Upsert(ctx context.Context, obj dtclient.SettingsObject, opts dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+	c.mutex.Lock()
+	*c.calls++
+	*c.active++
+	*c.maxActive = max(*c.maxActive, *c.active)
+	c.mutex.Unlock()
+
+	c.started <- struct{}{}
+	<-c.release
+
+	c.mutex.Lock()
+	*c.active--
+	c.mutex.Unlock()
+
+	if c.err != nil {
+		return dtclient.DynatraceEntity{}, c.err
+	}
+	return c.DummySettingsClient.Upsert(ctx, obj, opts)
+}
+
+// parallelDeploymentTest deploys one setting to each environment using blocking settings clients.
+type parallelDeploymentTest struct {
+	projects  []project.Project
+	clients   dynatrace.EnvironmentClients
+	maxActive int
+	calls     int
+	mutex     sync.Mutex
+	started   chan struct{}
+	release   chan struct{}
+}
+
+func newParallelDeploymentTest(t *testing.T, envNames []string, clientErr error) *parallelDeploymentTest {
+	test := &parallelDeploymentTest{
+		clients: dynatrace.EnvironmentClients{},
+		started: make(chan struct{}, len(envNames)),
+		release: make(chan struct{}),
+	}
+	active := 0
+
+	p := project.Project{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{}}
+	for _, env := range envNames {
+		p.Configs[env] = project.ConfigsPerType{
+			"builtin:test": []config.Config{
+				{
+					Template:    testutils.GenerateDummyTemplate(t),
+					Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "setting"},
+					Environment: env,
+					Type:        config.SettingsType{SchemaId: "builtin:test"},
+					Parameters: config.Parameters{
+						config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+					},
+				},
+			},
+		}
+		test.clients[dynatrace.EnvironmentInfo{Name: env}] = &client.ClientSet{SettingsClient: &blockingSettingsClient{
+			mutex:     &test.mutex,
+			active:    &active,
+			maxActive: &test.maxActive,
+			calls:     &test.calls,
+			started:   test.started,
+			release:   test.release,
+			err:       clientErr,
+		}}
+	}
+	test.projects = []project.Project{p}
+	return test
+}
+
+// deploy runs the deployment, waits until the expected number of environments are deployed to at the same time and checks
+// that no further environment is started before the running ones are released.
+func (test *parallelDeploymentTest) deploy(t *testing.T, opts deploy.DeployConfigsOptions, expectedParallel int) error {
+	t.Helper()
+
+	done := make(chan error)
+	go func() {
+		done <- deploy.DeployForAllEnvironments(t.Context(), test.projects, test.clients, opts)
+	}()
+
+	for range expectedParallel {
+		select {
+		case <-test.started:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected %d environments to be deployed in parallel", expectedParallel)
+		}
+	}
+	select {
+	case <-test.started:
+		t.Fatalf("expected no more than %d environments to be deployed in parallel", expectedParallel)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(test.release)
+	return <-done
+}
+
+func TestDeployForAllEnvironments_DeploysEnvironmentsInParallel(t *testing.T) {
+	test := newParallelDeploymentTest(t, []string{"env1", "env2", "env3"}, nil)
+
+	err := test.deploy(t, deploy.DeployConfigsOptions{MaxParallelEnvironments: 2}, 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, test.maxActive, "environments are deployed in parallel up to the limit")
+	assert.Equal(t, 3, test.calls, "all environments are deployed")
+}
+
+func TestDeployForAllEnvironments_LimitsParallelEnvironmentsFromEnvVar(t *testing.T) {
+	t.Setenv(internalenvironment.ParallelEnvironmentsEnvKey, "2")
+	test := newParallelDeploymentTest(t, []string{"env1", "env2", "env3"}, nil)
+
+	err := test.deploy(t, deploy.DeployConfigsOptions{}, 2)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, test.maxActive)
+	assert.Equal(t, 3, test.calls)
+}
+
+func TestDeployForAllEnvironments_DeploysEnvironmentsSequentiallyByDefault(t *testing.T) {
+	test := newParallelDeploymentTest(t, []string{"env1", "env2"}, nil)
+	close(test.release)
+
+	err := deploy.DeployForAllEnvironments(t.Context(), test.projects, test.clients, deploy.DeployConfigsOptions{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, test.maxActive)
+	assert.Equal(t, 2, test.calls)
+}
+
+func TestDeployForAllEnvironments_StopsStartingEnvironmentsAfterError(t *testing.T) {
+	test := newParallelDeploymentTest(t, []string{"env1", "env2", "env3"}, fmt.Errorf("deployment failed"))
+
+	err := test.deploy(t, deploy.DeployConfigsOptions{MaxParallelEnvironments: 2}, 2)
+	var envErrs errors.EnvironmentDeploymentErrors
+	require.ErrorAs(t, err, &envErrs)
+	assert.Len(t, envErrs, 2, "the running environments fail")
+	assert.Equal(t, 2, test.calls, "no further environment is started after the first error")
+}
+
+func TestDeployForAllEnvironments_ContinuesStartingEnvironmentsAfterErrorIfRequested(t *testing.T) {
+	test := newParallelDeploymentTest(t, []string{"env1", "env2", "env3"}, fmt.Errorf("deployment failed"))
+	close(test.release)
+
+	err := deploy.DeployForAllEnvironments(t.Context(), test.projects, test.clients, deploy.DeployConfigsOptions{MaxParallelEnvironments: 2, ContinueOnErr: true})
+	var envErrs errors.EnvironmentDeploymentErrors
+	require.ErrorAs(t, err, &envErrs)
+	assert.Len(t, envErrs, 3)
+	assert.Equal(t, 3, test.calls)
+}
+
+func TestDeployForAllEnvironments_ReportsDeploymentsPerEnvironmentWhenDeployingInParallel(t *testing.T) {
+	test := newParallelDeploymentTest(t, []string{"env1", "env2", "env3"}, nil)
+	close(test.release)
+
+	fs := afero.NewMemMapFs()
+	reporter := report.NewDefaultReporter(fs, "report.jsonl")
+	ctx := report.NewContextWithReporter(t.Context(), reporter)
+
+	err := deploy.DeployForAllEnvironments(ctx, test.projects, test.clients, deploy.DeployConfigsOptions{MaxParallelEnvironments: 3})
+	assert.NoError(t, err)
+	reporter.Stop()
+
+	records, err := report.ReadReportFile(fs, "report.jsonl")
+	require.NoError(t, err)
+
+	var deployedEnvironments []string
+	for _, r := range records {
+		if r.Type == report.TypeDeploy {
+			assert.Equal(t, report.StateSuccess, r.State)
+			deployedEnvironments = append(deployedEnvironments, r.Environment)
+		}
+	}
+	assert.ElementsMatch(t, []string{"env1", "env2", "env3"}, deployedEnvironments, "each deployment is reported for its own environment")
+}
+
+// syncWriter is a log spy the environments deployed in parallel can write to at the same time.
+type syncWriter struct {
+	mutex sync.Mutex
+	buf   bytes.Buffer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *syncWriter) String() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.buf.String()
+}
+
+func TestDeployForAllEnvironments_LogsEnvironmentsWhileDeployingInParallel(t *testing.T) {
+	logSpy := &syncWriter{}
+	log.PrepareLogging(t.Context(), afero.NewMemMapFs(), false, logSpy, false, false)
+
+	test := newParallelDeploymentTest(t, []string{"env1", "env2"}, nil)
+	done := make(chan error)
+	go func() {
+		done <- deploy.DeployForAllEnvironments(t.Context(), test.projects, test.clients, deploy.DeployConfigsOptions{MaxParallelEnvironments: 2})
+	}()
+
+	for range 2 {
+		select {
+		case <-test.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected both environments to be deployed in parallel")
+		}
+	}
+
+	logs := logSpy.String()
+	close(test.release)
+	require.NoError(t, <-done)
+
+	assert.Contains(t, logs, "environment.name=env1", "logs are written while the environment is deployed")
+	assert.Contains(t, logs, "environment.name=env2", "logs are written while the environment is deployed")
+}
+
+func TestDeployForAllEnvironments_RejectsMismatchingAppliedPlan(t *testing.T) {
+	setting := config.Config{
+		Template:    testutils.GenerateDummyTemplate(t),
+		Coordinate:  coordinate.Coordinate{Project: "proj", Type: "builtin:test", ConfigId: "setting"},
+		Environment: "env",
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+		Parameters: config.Parameters{
+			config.ScopeParameter: &value.ValueParameter{Value: "tenant"},
+		},
+	}
+	p := project.Project{Id: "proj", Configs: project.ConfigsPerTypePerEnvironments{
+		"env": project.ConfigsPerType{"builtin:test": []config.Config{setting}},
+	}}
+	clients := dynatrace.EnvironmentClients{
+		dynatrace.EnvironmentInfo{Name: "env"}: &client.ClientSet{SettingsClient: &dtclient.DummySettingsClient{}},
+	}
+
+	appliedPlan := plan.New()
+	appliedPlan.Add(plan.Entry{Environment: "other-env", Coordinate: setting.Coordinate, Action: plan.ActionCreate, Payload: "{}"})
+
+	err := deploy.DeployForAllEnvironments(t.Context(), []project.Project{p}, clients, deploy.DeployConfigsOptions{AppliedPlan: appliedPlan})
+	assert.ErrorIs(t, err, plan.ErrNotPlanned, "the plan is validated before deploying")
+	assert.ErrorIs(t, err, plan.ErrEnvironmentNotDeployed)
+}
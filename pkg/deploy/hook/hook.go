@@ -0,0 +1,150 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package hook runs the local commands defined as pre- and post-deploy hooks in the manifest.
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// Stage is the point of a deployment a hook runs at.
+type Stage string
+
+const (
+	PreDeploy  Stage = "preDeploy"
+	PostDeploy Stage = "postDeploy"
+)
+
+const (
+	// FileEnvKey is the environment variable holding the path of the JSON file describing the deployment.
+	FileEnvKey = "MONACO_HOOK_FILE"
+	// StageEnvKey is the environment variable holding the Stage the hook runs at.
+	StageEnvKey = "MONACO_HOOK_STAGE"
+	// EnvironmentEnvKey is the environment variable holding the name of the environment deployed to.
+	EnvironmentEnvKey = "MONACO_HOOK_ENVIRONMENT"
+)
+
+// Config is a configuration of a deployment, as passed to hooks.
+type Config struct {
+	coordinate.Coordinate
+	// EntityID is the ID of the deployed object. It is only set for post-deploy hooks, and only for
+	// configurations that were deployed successfully.
+	EntityID string `json:"entityId,omitempty"`
+}
+
+// Payload is the content of the JSON file passed to hooks.
+type Payload struct {
+	Stage       Stage  `json:"stage"`
+	Environment string `json:"environment"`
+	Group       string `json:"group"`
+	// Succeeded states whether the deployment succeeded. It is only set for post-deploy hooks.
+	Succeeded *bool `json:"succeeded,omitempty"`
+	// Configs are the configurations to deploy for pre-deploy hooks, and the deployed configurations for post-deploy hooks.
+	Configs []Config `json:"configs"`
+}
+
+// Runner runs the hooks defined for environments.
+type Runner struct {
+	// WorkingDir is the directory commands are run in. Relative command paths are resolved against it.
+	WorkingDir string
+	// Hooks holds the hooks to run per environment name.
+	Hooks map[string]manifest.Hooks
+}
+
+// Run runs all hooks defined for the environment and stage of the given payload, one after another. It stops
+// at the first hook that fails.
+func (r Runner) Run(ctx context.Context, payload Payload) error {
+	hooks := r.Hooks[payload.Environment].PreDeploy
+	if payload.Stage == PostDeploy {
+		hooks = r.Hooks[payload.Environment].PostDeploy
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	file, err := writePayload(payload)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file)
+
+	for _, h := range hooks {
+		if err := r.run(ctx, h, payload, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r Runner) run(ctx context.Context, h manifest.Hook, payload Payload, file string) error {
+	name := strings.Join(h.Command, " ")
+	log.InfoContext(ctx, "Running %s hook %q", payload.Stage, name)
+
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Dir = r.WorkingDir
+	cmd.Env = append(os.Environ(),
+		FileEnvKey+"="+file,
+		StageEnvKey+"="+string(payload.Stage),
+		EnvironmentEnvKey+"="+payload.Environment,
+	)
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := cmd.Run()
+
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line != "" {
+			log.WithFields(field.F("hook", name)).InfoContext(ctx, "%s", line)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s hook %q failed: %w", payload.Stage, name, err)
+	}
+	return nil
+}
+
+func writePayload(payload Payload) (string, error) {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal hook payload: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "monaco-hook-*.json")
+	if err != nil {
+		return "", fmt.Errorf("failed to create hook payload file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write hook payload file: %w", err)
+	}
+	return f.Name(), nil
+}
@@ -0,0 +1,99 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package hook_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func TestRunner_Run(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook commands in this test require a POSIX shell")
+	}
+
+	payload := hook.Payload{
+		Stage:       hook.PreDeploy,
+		Environment: "env",
+		Group:       "group",
+		Configs:     []hook.Config{{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}}},
+	}
+
+	t.Run("hooks get the payload file and run in the working directory", func(t *testing.T) {
+		dir := t.TempDir()
+		runner := hook.Runner{
+			WorkingDir: dir,
+			Hooks: map[string]manifest.Hooks{
+				"env": {PreDeploy: []manifest.Hook{{Command: []string{"sh", "-c", `cp "$MONACO_HOOK_FILE" payload.json && echo "$MONACO_HOOK_STAGE $MONACO_HOOK_ENVIRONMENT" > vars.txt`}}}},
+			},
+		}
+
+		require.NoError(t, runner.Run(t.Context(), payload))
+
+		data, err := os.ReadFile(filepath.Join(dir, "payload.json"))
+		require.NoError(t, err)
+		var got hook.Payload
+		require.NoError(t, json.Unmarshal(data, &got))
+		assert.Equal(t, payload, got)
+
+		vars, err := os.ReadFile(filepath.Join(dir, "vars.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "preDeploy env\n", string(vars))
+	})
+
+	t.Run("stops at the first failing hook", func(t *testing.T) {
+		dir := t.TempDir()
+		runner := hook.Runner{
+			WorkingDir: dir,
+			Hooks: map[string]manifest.Hooks{
+				"env": {PreDeploy: []manifest.Hook{
+					{Command: []string{"sh", "-c", "exit 3"}},
+					{Command: []string{"touch", "second-hook-ran"}},
+				}},
+			},
+		}
+
+		err := runner.Run(t.Context(), payload)
+		assert.ErrorContains(t, err, "preDeploy hook")
+		assert.NoFileExists(t, filepath.Join(dir, "second-hook-ran"))
+	})
+
+	t.Run("only hooks of the stage are run", func(t *testing.T) {
+		dir := t.TempDir()
+		runner := hook.Runner{
+			WorkingDir: dir,
+			Hooks: map[string]manifest.Hooks{
+				"env": {PostDeploy: []manifest.Hook{{Command: []string{"touch", "post-hook-ran"}}}},
+			},
+		}
+
+		require.NoError(t, runner.Run(t.Context(), payload))
+		assert.NoFileExists(t, filepath.Join(dir, "post-hook-ran"))
+	})
+}
@@ -0,0 +1,95 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/graph"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/dynatrace"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/hook"
+	configGraph "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// deployWithHooks deploys to the given environment, running the pre-deploy hooks before and the post-deploy hooks after
+// the deployment. If a pre-deploy hook fails, nothing is deployed. Post-deploy hooks run regardless of whether the
+// deployment succeeded.
+func deployWithHooks(ctx context.Context, hooks *hook.Runner, env dynatrace.EnvironmentInfo, clientSet *client.ClientSet, projects []project.Project, sortedConfigs []configGraph.SortedComponent) error {
+	resolvedEntities := entities.New()
+	if hooks == nil {
+		return deployEnvironment(ctx, clientSet, projects, sortedConfigs, env.Name, resolvedEntities)
+	}
+
+	pre := hook.Payload{Stage: hook.PreDeploy, Environment: env.Name, Group: env.Group, Configs: configsToDeploy(sortedConfigs)}
+	if err := hooks.Run(ctx, pre); err != nil {
+		return fmt.Errorf("aborted deployment to environment %q: %w", env.Name, err)
+	}
+
+	deployErr := deployEnvironment(ctx, clientSet, projects, sortedConfigs, env.Name, resolvedEntities)
+
+	succeeded := deployErr == nil
+	post := hook.Payload{Stage: hook.PostDeploy, Environment: env.Name, Group: env.Group, Succeeded: &succeeded, Configs: deployedConfigs(resolvedEntities)}
+	if err := hooks.Run(ctx, post); err != nil {
+		return errors.Join(deployErr, err)
+	}
+	return deployErr
+}
+
+// configsToDeploy returns all configs of the given components. It must be called before deploying them, as deploying
+// removes the configs from the components.
+func configsToDeploy(components []configGraph.SortedComponent) []hook.Config {
+	var result []hook.Config
+	for _, component := range components {
+		for _, n := range graph.NodesOf(component.Graph.Nodes()) {
+			result = append(result, hook.Config{Coordinate: n.(configGraph.ConfigNode).Config.Coordinate})
+		}
+	}
+	sortHookConfigs(result)
+	return result
+}
+
+// deployedConfigs returns all deployed configs along with the ID of the deployed object.
+func deployedConfigs(resolvedEntities *entities.EntityMap) []hook.Config {
+	var result []hook.Config
+	for c, e := range resolvedEntities.Get() {
+		if e.Skip {
+			continue
+		}
+		var id string
+		if v, ok := e.Properties[config.IdParameter]; ok {
+			id = fmt.Sprint(v)
+		}
+		result = append(result, hook.Config{Coordinate: c, EntityID: id})
+	}
+	sortHookConfigs(result)
+	return result
+}
+
+func sortHookConfigs(configs []hook.Config) {
+	slices.SortFunc(configs, func(a, b hook.Config) int {
+		return cmp.Compare(a.Coordinate.String(), b.Coordinate.String())
+	})
+}
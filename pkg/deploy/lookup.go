@@ -0,0 +1,288 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/lookup"
+)
+
+// dryRunLookupValue is returned for all lookups in dry-run mode, as there is no environment to look objects up in.
+const dryRunLookupValue = "dry-run-lookup-value"
+
+var (
+	errLookupNotFound  = errors.New("no object found")
+	errLookupAmbiguous = errors.New("more than one object found")
+)
+
+// objectLookup looks up objects and monitored entities in the environment of a ClientSet. The objects of each classic
+// API and settings schema, as well as the entities of each entity selector, are only listed once and cached afterward,
+// as many configs may look up objects of the same type.
+type objectLookup struct {
+	clientSet *client.ClientSet
+	apis      api.APIs
+
+	classicValues     listCache[dtclient.Value]
+	settingsObjects   listCache[dtclient.DownloadSettingsObject]
+	monitoredEntities listCache[string]
+}
+
+func newObjectLookup(clientSet *client.ClientSet, apis api.APIs) *objectLookup {
+	return &objectLookup{clientSet: clientSet, apis: apis}
+}
+
+// listCache caches listed values by key. Each key is locked on its own, so that concurrent lookups only wait for
+// each other if they need the same values, while lookups of other keys are not blocked by a running list call.
+type listCache[T any] struct {
+	mutex   sync.Mutex
+	entries map[string]*listCacheEntry[T]
+}
+
+type listCacheEntry[T any] struct {
+	mutex  sync.Mutex
+	loaded bool
+	values []T
+}
+
+// get returns the cached values of the key, calling list to load them if they are not cached yet. Errors are not
+// cached, so a later get of the same key calls list again.
+func (c *listCache[T]) get(key string, list func() ([]T, error)) ([]T, error) {
+	c.mutex.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*listCacheEntry[T])
+	}
+	entry, found := c.entries[key]
+	if !found {
+		entry = &listCacheEntry[T]{}
+		c.entries[key] = entry
+	}
+	c.mutex.Unlock()
+
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.loaded {
+		return entry.values, nil
+	}
+	values, err := list()
+	if err != nil {
+		return nil, err
+	}
+	entry.values, entry.loaded = values, true
+	return values, nil
+}
+
+type ctxObjectLookupKey struct{}
+
+func newContextWithObjectLookup(ctx context.Context, l *objectLookup) context.Context {
+	return context.WithValue(ctx, ctxObjectLookupKey{}, l)
+}
+
+func getObjectLookupFromContext(ctx context.Context) *objectLookup {
+	if l, ok := ctx.Value(ctxObjectLookupKey{}).(*objectLookup); ok {
+		return l
+	}
+	return nil
+}
+
+// entityLookupWithObjects is a config.EntityLookup that also looks up objects and monitored entities existing in the
+// environment.
+type entityLookupWithObjects struct {
+	config.EntityLookup
+	ctx     context.Context
+	objects *objectLookup
+}
+
+var (
+	_ parameter.ObjectLookup          = (*entityLookupWithObjects)(nil)
+	_ parameter.MonitoredEntityLookup = (*entityLookupWithObjects)(nil)
+	_ parameter.PlaceholderLookup     = (*entityLookupWithObjects)(nil)
+)
+
+// newEntityLookup returns the config.EntityLookup to resolve the parameters of configs with, which finds objects
+// existing in the environment if an objectLookup is stored in the context.
+func newEntityLookup(ctx context.Context, resolvedEntities config.EntityLookup) config.EntityLookup {
+	if l := getObjectLookupFromContext(ctx); l != nil {
+		return &entityLookupWithObjects{EntityLookup: resolvedEntities, ctx: ctx, objects: l}
+	}
+	return resolvedEntities
+}
+
+func (e *entityLookupWithObjects) LookupObject(query parameter.ObjectQuery) (any, error) {
+	if isDryRun(e.ctx) {
+		return dryRunLookupValue, nil
+	}
+	return e.objects.lookup(e.ctx, query)
+}
+
+func (e *entityLookupWithObjects) FindMonitoredEntities(entitySelector string) ([]string, error) {
+	if isDryRun(e.ctx) {
+		return []string{dryRunLookupValue}, nil
+	}
+	return e.objects.findMonitoredEntities(e.ctx, entitySelector)
+}
+
+// ReturnsPlaceholders returns true in dry-run mode, in which all lookups return dryRunLookupValue.
+func (e *entityLookupWithObjects) ReturnsPlaceholders() bool {
+	return isDryRun(e.ctx)
+}
+
+func (l *objectLookup) findMonitoredEntities(ctx context.Context, entitySelector string) ([]string, error) {
+	if l.clientSet.EntitiesClient == nil {
+		return nil, errors.New("monitored entities can not be looked up, as no entities client is available for the environment")
+	}
+
+	return l.monitoredEntities.get(entitySelector, func() ([]string, error) {
+		return l.clientSet.EntitiesClient.ListIDs(ctx, entitySelector)
+	})
+}
+
+func (l *objectLookup) lookup(ctx context.Context, query parameter.ObjectQuery) (any, error) {
+	if query.API != "" {
+		return l.lookupClassic(ctx, query)
+	}
+	return l.lookupSettings(ctx, query)
+}
+
+func (l *objectLookup) lookupClassic(ctx context.Context, query parameter.ObjectQuery) (any, error) {
+	if l.clientSet.ConfigClient == nil {
+		return nil, fmt.Errorf("objects of API %q can not be looked up, as no classic client is available for the environment", query.API)
+	}
+
+	a, found := l.apis[query.API]
+	if !found {
+		return nil, fmt.Errorf("unknown API %q", query.API)
+	}
+	if a.HasParent() {
+		return nil, fmt.Errorf("objects of API %q can not be looked up, as it belongs to a parent object", query.API)
+	}
+
+	values, err := l.classicValues.get(a.ID, func() ([]dtclient.Value, error) {
+		return l.clientSet.ConfigClient.List(ctx, a)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects of API %q: %w", a.ID, err)
+	}
+
+	var matches []dtclient.Value
+	for _, v := range values {
+		if v.Name == query.Name {
+			matches = append(matches, v)
+		}
+	}
+	if err := checkSingleMatch(len(matches), fmt.Sprintf("of API %q named %q", a.ID, query.Name)); err != nil {
+		return nil, err
+	}
+
+	if query.Property == "name" {
+		return matches[0].Name, nil
+	}
+	return matches[0].Id, nil
+}
+
+func (l *objectLookup) lookupSettings(ctx context.Context, query parameter.ObjectQuery) (any, error) {
+	if l.clientSet.SettingsClient == nil {
+		return nil, fmt.Errorf("settings objects of schema %q can not be looked up, as no settings client is available for the environment", query.Schema)
+	}
+
+	objects, err := l.settingsObjects.get(query.Schema, func() ([]dtclient.DownloadSettingsObject, error) {
+		return l.clientSet.SettingsClient.List(ctx, query.Schema, dtclient.ListSettingsOptions{})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settings objects of schema %q: %w", query.Schema, err)
+	}
+
+	var matches []dtclient.DownloadSettingsObject
+	var matchedValues []map[string]any
+	for _, o := range objects {
+		if query.Scope != "" && o.Scope != query.Scope {
+			continue
+		}
+
+		var value map[string]any
+		if err := json.Unmarshal(o.Value, &value); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal value of settings object %q: %w", o.ObjectId, err)
+		}
+		matched, err := matchesFilter(value, query.Filter)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			matches = append(matches, o)
+			matchedValues = append(matchedValues, value)
+		}
+	}
+	if err := checkSingleMatch(len(matches), fmt.Sprintf("of schema %q matching scope %q and filter %v", query.Schema, query.Scope, query.Filter)); err != nil {
+		return nil, err
+	}
+
+	switch query.Property {
+	case "id":
+		return matches[0].ObjectId, nil
+	case "scope":
+		return matches[0].Scope, nil
+	}
+
+	path, err := entities.ParsePropertyPath(strings.TrimPrefix(query.Property, lookup.SettingsValuePropertyPrefix))
+	if err != nil {
+		return nil, err
+	}
+	v, found := path.Resolve(matchedValues[0])
+	if !found {
+		return nil, fmt.Errorf("found settings object %q has no value at %q", matches[0].ObjectId, path)
+	}
+	return v, nil
+}
+
+func checkSingleMatch(count int, description string) error {
+	switch {
+	case count == 0:
+		return fmt.Errorf("%w %s", errLookupNotFound, description)
+	case count > 1:
+		return fmt.Errorf("%w %s", errLookupAmbiguous, description)
+	}
+	return nil
+}
+
+// matchesFilter returns whether the values at all property paths of the filter equal the expected values.
+func matchesFilter(value map[string]any, filter map[string]string) (bool, error) {
+	for _, p := range slices.Sorted(maps.Keys(filter)) {
+		path, err := entities.ParsePropertyPath(p)
+		if err != nil {
+			return false, err
+		}
+		v, found := path.Resolve(value)
+		if !found || fmt.Sprint(v) != filter[p] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
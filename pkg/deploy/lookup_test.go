@@ -0,0 +1,175 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+func TestMatchesFilter(t *testing.T) {
+	value := map[string]any{
+		"name":    "Default",
+		"enabled": true,
+		"rules":   map[string]any{"severity": "AVAILABILITY"},
+		"tags":    []any{map[string]any{"key": "team"}},
+	}
+
+	tests := []struct {
+		name   string
+		filter map[string]string
+		want   bool
+	}{
+		{"no filter", nil, true},
+		{"top-level values", map[string]string{"name": "Default", "enabled": "true"}, true},
+		{"nested value", map[string]string{"rules.severity": "AVAILABILITY"}, true},
+		{"list element", map[string]string{"tags[0].key": "team"}, true},
+		{"quoted property", map[string]string{`["name"]`: "Default"}, true},
+		{"other value", map[string]string{"name": "Other"}, false},
+		{"missing value", map[string]string{"rules.missing": "x"}, false},
+		{"value of non-object", map[string]string{"name.nested": "Default"}, false},
+		{"index out of range", map[string]string{"tags[1].key": "team"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchesFilter(value, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("invalid path", func(t *testing.T) {
+		_, err := matchesFilter(value, map[string]string{"rules..severity": "x"})
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckSingleMatch(t *testing.T) {
+	assert.ErrorIs(t, checkSingleMatch(0, "object"), errLookupNotFound)
+	assert.NoError(t, checkSingleMatch(1, "object"))
+	assert.ErrorIs(t, checkSingleMatch(2, "object"), errLookupAmbiguous)
+}
+
+func TestEntityLookupWithObjects_DryRun(t *testing.T) {
+	ctx := newContextWithDryRun(context.Background())
+	ctx = newContextWithObjectLookup(ctx, newObjectLookup(nil, nil))
+
+	l, ok := newEntityLookup(ctx, entities.New()).(parameter.ObjectLookup)
+	require.True(t, ok)
+
+	got, err := l.LookupObject(parameter.ObjectQuery{API: "management-zone", Name: "Team A", Property: "id"})
+	require.NoError(t, err)
+	assert.Equal(t, dryRunLookupValue, got)
+}
+
+type entitiesClientFunc func(ctx context.Context, entitySelector string) ([]string, error)
+
+func (f entitiesClientFunc) ListIDs(ctx context.Context, entitySelector string) ([]string, error) {
+	return f(ctx, entitySelector)
+}
+
+func TestObjectLookup_FindMonitoredEntitiesCachesResults(t *testing.T) {
+	calls := 0
+	clientSet := &client.ClientSet{EntitiesClient: entitiesClientFunc(func(_ context.Context, entitySelector string) ([]string, error) {
+		calls++
+		return []string{entitySelector + "-1"}, nil
+	})}
+	l := newObjectLookup(clientSet, nil)
+
+	for range 2 {
+		ids, err := l.findMonitoredEntities(context.TODO(), "type(HOST)")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"type(HOST)-1"}, ids)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestObjectLookup_FindMonitoredEntitiesDoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	clientSet := &client.ClientSet{EntitiesClient: entitiesClientFunc(func(_ context.Context, _ string) ([]string, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("failed")
+		}
+		return []string{"HOST-1"}, nil
+	})}
+	l := newObjectLookup(clientSet, nil)
+
+	_, err := l.findMonitoredEntities(context.TODO(), "type(HOST)")
+	assert.Error(t, err)
+
+	ids, err := l.findMonitoredEntities(context.TODO(), "type(HOST)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"HOST-1"}, ids)
+}
+
+func TestListCache_DoesNotBlockOtherKeys(t *testing.T) {
+	var c listCache[string]
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.get("slow", func() ([]string, error) {
+			close(started)
+			<-release
+			return []string{"slow"}, nil
+		})
+	}()
+	<-started
+
+	values, err := c.get("fast", func() ([]string, error) { return []string{"fast"}, nil })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fast"}, values)
+
+	close(release)
+	<-done
+	values, err = c.get("slow", func() ([]string, error) { return nil, errors.New("listed twice") })
+	require.NoError(t, err)
+	assert.Equal(t, []string{"slow"}, values)
+}
+
+func TestObjectLookup_FindMonitoredEntitiesWithoutClient(t *testing.T) {
+	_, err := newObjectLookup(&client.ClientSet{}, nil).findMonitoredEntities(context.TODO(), "type(HOST)")
+	assert.Error(t, err)
+}
+
+func TestObjectLookup_LookupWithoutClient(t *testing.T) {
+	l := newObjectLookup(&client.ClientSet{}, api.NewAPIs())
+
+	t.Run("classic object", func(t *testing.T) {
+		_, err := l.lookup(context.TODO(), parameter.ObjectQuery{API: api.AlertingProfile, Name: "profile", Property: "id"})
+		assert.ErrorContains(t, err, "no classic client")
+	})
+
+	t.Run("settings object", func(t *testing.T) {
+		_, err := l.lookup(context.TODO(), parameter.ObjectQuery{Schema: "builtin:alerting.profile", Property: "id"})
+		assert.ErrorContains(t, err, "no settings client")
+	})
+}
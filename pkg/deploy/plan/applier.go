@@ -0,0 +1,157 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+var (
+	// ErrNotPlanned is returned when applying a Plan to a config that is not part of it.
+	ErrNotPlanned = errors.New("config is not part of the plan")
+	// ErrNotDeployed is returned when validating a Plan containing a config that is not part of the deployment.
+	ErrNotDeployed = errors.New("planned config is not part of the deployment")
+	// ErrEnvironmentNotDeployed is returned when validating a Plan containing an environment that is not deployed to.
+	ErrEnvironmentNotDeployed = errors.New("planned environment is not part of the deployment")
+	// ErrRemoteChanged is returned when applying a Plan to a config whose remote object changed since the Plan was computed.
+	ErrRemoteChanged = errors.New("remote object changed since the plan was computed")
+)
+
+// createdIDs maps the PlaceholderID of each config created while applying a Plan to the ID of the created object.
+type createdIDs struct {
+	mutex sync.Mutex
+	ids   map[string]string
+}
+
+func (c *createdIDs) add(placeholder string, id string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.ids[placeholder] = id
+}
+
+// replace replaces all known placeholder IDs in the given payload with the IDs of the created objects.
+func (c *createdIDs) replace(payload string) string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for placeholder, id := range c.ids {
+		payload = strings.ReplaceAll(payload, placeholder, id)
+	}
+	return payload
+}
+
+// applier is a resource.Deployable that deploys the planned payload of a config, instead of the rendered one.
+type applier struct {
+	deployable resource.Deployable
+	plannable  resource.Plannable
+	plan       *Plan
+	created    *createdIDs
+}
+
+// NewApplyingDeployables returns resource.Deployables, which deploy the payloads recorded in the given Plan instead
+// of the rendered configs. Before a config is deployed, its remote object is looked up again, and the deployment
+// fails with ErrRemoteChanged if it changed since the Plan was computed. Configs planned as unchanged are not deployed.
+//
+// The returned resource.Deployables must only be used to deploy to a single environment.
+func NewApplyingDeployables(deployables resource.Deployables, plannables resource.Plannables, p *Plan) resource.Deployables {
+	created := &createdIDs{ids: make(map[string]string)}
+
+	applyingDeployables := make(resource.Deployables, len(deployables))
+	for t, deployable := range deployables {
+		applyingDeployables[t] = applier{deployable: deployable, plannable: plannables[t], plan: p, created: created}
+	}
+	return applyingDeployables
+}
+
+func (a applier) Deploy(ctx context.Context, properties parameter.Properties, _ string, c *config.Config) (entities.ResolvedEntity, error) {
+	entry, found := a.plan.Entry(c.Environment, c.Coordinate)
+	if !found {
+		return entities.ResolvedEntity{}, fmt.Errorf("failed to apply plan to config %q in environment %q: %w", c.Coordinate, c.Environment, ErrNotPlanned)
+	}
+
+	payload, err := replacePlaceholdersWithSecrets(entry.Payload, secretValues(properties))
+	if err != nil {
+		return entities.ResolvedEntity{}, fmt.Errorf("failed to apply plan to config %q in environment %q: %w", c.Coordinate, c.Environment, err)
+	}
+	payload = a.created.replace(payload)
+
+	if err := a.verifyRemote(ctx, properties, payload, c, entry); err != nil {
+		return entities.ResolvedEntity{}, err
+	}
+
+	report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeInfo, Message: fmt.Sprintf("Applied planned action: %s", entry.Action)})
+
+	if entry.Action == ActionUnchanged {
+		properties[config.IdParameter] = entry.RemoteID
+		return entities.ResolvedEntity{
+			Coordinate: c.Coordinate,
+			Properties: properties,
+		}, nil
+	}
+
+	resolved, err := a.deployable.Deploy(ctx, properties, payload, c)
+	if err != nil {
+		return entities.ResolvedEntity{}, err
+	}
+
+	if entry.Action == ActionCreate {
+		if id, ok := resolved.Properties[config.IdParameter].(string); ok {
+			a.created.add(PlaceholderID(c.Coordinate), id)
+		}
+	}
+	return resolved, nil
+}
+
+// verifyRemote ensures that the remote object of the config is still in the state the given Entry was planned against.
+func (a applier) verifyRemote(ctx context.Context, properties parameter.Properties, payload string, c *config.Config, entry Entry) error {
+	remote, found, err := a.plannable.Lookup(ctx, properties, payload, c)
+	if err != nil {
+		return fmt.Errorf("failed to look up remote object: %w", err)
+	}
+
+	if !found {
+		if entry.Action != ActionCreate {
+			return fmt.Errorf("remote object %q no longer exists: %w", entry.RemoteID, ErrRemoteChanged)
+		}
+		return nil
+	}
+
+	if entry.Action == ActionCreate {
+		return fmt.Errorf("remote object %q was created: %w", remote.ID, ErrRemoteChanged)
+	}
+
+	hash, err := Hash(remote.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to hash remote object %q: %w", remote.ID, err)
+	}
+
+	if remote.ID != entry.RemoteID || hash != entry.RemoteHash {
+		return fmt.Errorf("remote object %q was modified: %w", remote.ID, ErrRemoteChanged)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package plan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type fakeRemote struct {
+	objects  map[coordinate.Coordinate]resource.RemoteObject
+	deployed map[coordinate.Coordinate]string
+}
+
+func (f *fakeRemote) Lookup(_ context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	o, found := f.objects[c.Coordinate]
+	return o, found, nil
+}
+
+func (f *fakeRemote) Deploy(_ context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	f.deployed[c.Coordinate] = renderedConfig
+	properties[config.IdParameter] = "id-" + c.Coordinate.ConfigId
+	return entities.ResolvedEntity{Coordinate: c.Coordinate, Properties: properties}, nil
+}
+
+func newFakeRemote() *fakeRemote {
+	return &fakeRemote{objects: map[coordinate.Coordinate]resource.RemoteObject{}, deployed: map[coordinate.Coordinate]string{}}
+}
+
+func testConfig(id string) *config.Config {
+	return &config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: id},
+		Environment: "env",
+		Type:        config.SettingsType{SchemaId: "builtin:test"},
+	}
+}
+
+func TestApplyPlan(t *testing.T) {
+	remote := newFakeRemote()
+	existing, changed, created, unchanged := testConfig("existing"), testConfig("changed"), testConfig("created"), testConfig("unchanged")
+	remote.objects[existing.Coordinate] = resource.RemoteObject{ID: "existing-id", Payload: []byte(`{"a": 1}`)}
+	remote.objects[changed.Coordinate] = resource.RemoteObject{ID: "changed-id", Payload: []byte(`{"a": 1}`)}
+	remote.objects[unchanged.Coordinate] = resource.RemoteObject{ID: "unchanged-id", Payload: []byte(`{"a": 2}`)}
+
+	hashOf := func(s string) string {
+		h, err := plan.Hash([]byte(s))
+		require.NoError(t, err)
+		return h
+	}
+
+	p := plan.New()
+	p.Add(plan.Entry{Environment: "env", Coordinate: created.Coordinate, Action: plan.ActionCreate, Payload: `{"planned": true}`})
+	p.Add(plan.Entry{Environment: "env", Coordinate: existing.Coordinate, Action: plan.ActionUpdate, RemoteID: "existing-id", RemoteHash: hashOf(`{"a":1}`),
+		Payload: `{"ref": "` + plan.PlaceholderID(created.Coordinate) + `"}`})
+	p.Add(plan.Entry{Environment: "env", Coordinate: changed.Coordinate, Action: plan.ActionUpdate, RemoteID: "changed-id", RemoteHash: hashOf(`{"a":0}`), Payload: `{}`})
+	p.Add(plan.Entry{Environment: "env", Coordinate: unchanged.Coordinate, Action: plan.ActionUnchanged, RemoteID: "unchanged-id", RemoteHash: hashOf(`{"a":2}`), Payload: `{"a": 2}`})
+
+	deployables := plan.NewApplyingDeployables(
+		resource.Deployables{config.SettingsTypeID: remote},
+		resource.Plannables{config.SettingsTypeID: remote},
+		p)
+	d := deployables[config.SettingsTypeID]
+
+	t.Run("created config deploys planned payload", func(t *testing.T) {
+		resolved, err := d.Deploy(t.Context(), parameter.Properties{}, `{"rendered": true}`, created)
+		require.NoError(t, err)
+		assert.Equal(t, "id-created", resolved.Properties[config.IdParameter])
+		assert.Equal(t, `{"planned": true}`, remote.deployed[created.Coordinate])
+	})
+
+	t.Run("placeholder IDs of created configs are replaced", func(t *testing.T) {
+		_, err := d.Deploy(t.Context(), parameter.Properties{}, `{}`, existing)
+		require.NoError(t, err)
+		assert.Equal(t, `{"ref": "id-created"}`, remote.deployed[existing.Coordinate])
+	})
+
+	t.Run("changed remote object is not deployed", func(t *testing.T) {
+		_, err := d.Deploy(t.Context(), parameter.Properties{}, `{}`, changed)
+		assert.ErrorIs(t, err, plan.ErrRemoteChanged)
+		assert.NotContains(t, remote.deployed, changed.Coordinate)
+	})
+
+	t.Run("unchanged config is resolved without deployment", func(t *testing.T) {
+		resolved, err := d.Deploy(t.Context(), parameter.Properties{}, `{}`, unchanged)
+		require.NoError(t, err)
+		assert.Equal(t, "unchanged-id", resolved.Properties[config.IdParameter])
+		assert.NotContains(t, remote.deployed, unchanged.Coordinate)
+	})
+
+	t.Run("config not in plan fails", func(t *testing.T) {
+		_, err := d.Deploy(t.Context(), parameter.Properties{}, `{}`, testConfig("unknown"))
+		assert.ErrorIs(t, err, plan.ErrNotPlanned)
+	})
+
+	t.Run("remote object created since planning fails", func(t *testing.T) {
+		remote.objects[created.Coordinate] = resource.RemoteObject{ID: "id-created", Payload: []byte(`{}`)}
+		_, err := d.Deploy(t.Context(), parameter.Properties{}, `{}`, created)
+		assert.ErrorIs(t, err, plan.ErrRemoteChanged)
+	})
+}
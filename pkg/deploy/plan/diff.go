@@ -0,0 +1,171 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// Diff compares the JSON payload of a remote object with the rendered JSON payload of a config.
+// Only properties that are part of the rendered payload are compared, as remote objects usually contain additional
+// properties like IDs or metadata, which are not part of a config.
+//
+// Each returned line describes a single difference as either
+//   - "~ <path>: <remote value> -> <rendered value>" for a changed value,
+//   - "+ <path>: <rendered value>" for a value that is missing on the remote object, or
+//   - "- <path>: <remote value>" for an array element that is not part of the rendered config.
+//
+// All occurrences of the given secrets, which are escaped for use in JSON like resolved parameter values, are masked
+// in the reported values.
+func Diff(remote []byte, rendered []byte, secrets ...string) ([]string, error) {
+	var remoteValue, renderedValue any
+	if err := json.Unmarshal(remote, &remoteValue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal remote payload: %w", err)
+	}
+	if err := json.Unmarshal(rendered, &renderedValue); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rendered payload: %w", err)
+	}
+
+	return differ{secrets: newSecretReplacer(secrets)}.diff("", remoteValue, renderedValue), nil
+}
+
+// differ computes the differences between decoded JSON values. Its secrets replacer masks secrets in reported values.
+type differ struct {
+	secrets *strings.Replacer
+}
+
+// newSecretReplacer returns a replacer masking the given secrets in decoded JSON strings, or nil if there are no
+// secrets. Longer secrets are replaced first, so that secrets containing other secrets are masked as a whole.
+func newSecretReplacer(secrets []string) *strings.Replacer {
+	var values []string
+	for _, s := range secrets {
+		if unescaped, ok := template.UnescapeJSONString(s); ok && unescaped != s {
+			values = append(values, unescaped)
+		}
+		values = append(values, s)
+	}
+	values = slices.DeleteFunc(values, func(s string) bool { return s == "" })
+	if len(values) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(values, func(a, b string) int { return cmp.Compare(len(b), len(a)) })
+
+	replacements := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		replacements = append(replacements, v, secret.MaskedString(v).String())
+	}
+	return strings.NewReplacer(replacements...)
+}
+
+func (d differ) diff(path string, remote any, rendered any) []string {
+	switch renderedValue := rendered.(type) {
+	case map[string]any:
+		remoteValue, ok := remote.(map[string]any)
+		if !ok {
+			break
+		}
+
+		var diff []string
+		for _, k := range slices.Sorted(maps.Keys(renderedValue)) {
+			childPath := joinPath(path, k)
+			remoteChild, found := remoteValue[k]
+			if !found {
+				diff = append(diff, fmt.Sprintf("+ %s: %s", childPath, d.toJSON(renderedValue[k])))
+				continue
+			}
+			diff = append(diff, d.diff(childPath, remoteChild, renderedValue[k])...)
+		}
+		return diff
+
+	case []any:
+		remoteValue, ok := remote.([]any)
+		if !ok {
+			break
+		}
+
+		var diff []string
+		for i := 0; i < max(len(remoteValue), len(renderedValue)); i++ {
+			childPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(remoteValue):
+				diff = append(diff, fmt.Sprintf("+ %s: %s", childPath, d.toJSON(renderedValue[i])))
+			case i >= len(renderedValue):
+				diff = append(diff, fmt.Sprintf("- %s: %s", childPath, d.toJSON(remoteValue[i])))
+			default:
+				diff = append(diff, d.diff(childPath, remoteValue[i], renderedValue[i])...)
+			}
+		}
+		return diff
+	}
+
+	if reflect.DeepEqual(remote, rendered) {
+		return nil
+	}
+
+	if path == "" {
+		path = "."
+	}
+	return []string{fmt.Sprintf("~ %s: %s -> %s", path, d.toJSON(remote), d.toJSON(rendered))}
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// toJSON encodes the given value for reporting it, after masking all secrets in its strings.
+func (d differ) toJSON(v any) string {
+	if d.secrets != nil {
+		v = d.mask(v)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+func (d differ) mask(v any) any {
+	switch value := v.(type) {
+	case string:
+		return d.secrets.Replace(value)
+	case map[string]any:
+		masked := make(map[string]any, len(value))
+		for k, val := range value {
+			masked[k] = d.mask(val)
+		}
+		return masked
+	case []any:
+		masked := make([]any, len(value))
+		for i, val := range value {
+			masked[i] = d.mask(val)
+		}
+		return masked
+	}
+	return v
+}
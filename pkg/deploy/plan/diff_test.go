@@ -0,0 +1,109 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		remote   string
+		rendered string
+		want     []string
+	}{
+		{
+			name:     "equal payloads have no diff",
+			remote:   `{"name": "a", "enabled": true}`,
+			rendered: `{"name": "a", "enabled": true}`,
+			want:     nil,
+		},
+		{
+			name:     "remote only properties are ignored",
+			remote:   `{"id": "1234", "name": "a", "metadata": {"version": 3}}`,
+			rendered: `{"name": "a"}`,
+			want:     nil,
+		},
+		{
+			name:     "changed values are reported with their path",
+			remote:   `{"name": "a", "rules": [{"value": 1}]}`,
+			rendered: `{"name": "b", "rules": [{"value": 2}]}`,
+			want:     []string{`~ name: "a" -> "b"`, `~ rules[0].value: 1 -> 2`},
+		},
+		{
+			name:     "missing values are reported as added",
+			remote:   `{"name": "a", "rules": []}`,
+			rendered: `{"name": "a", "enabled": false, "rules": ["x"]}`,
+			want:     []string{`+ enabled: false`, `+ rules[0]: "x"`},
+		},
+		{
+			name:     "additional remote array elements are reported as removed",
+			remote:   `{"tags": ["a", "b"]}`,
+			rendered: `{"tags": ["a"]}`,
+			want:     []string{`- tags[1]: "b"`},
+		},
+		{
+			name:     "changed type is reported as changed value",
+			remote:   `{"value": {"a": 1}}`,
+			rendered: `{"value": "a"}`,
+			want:     []string{`~ value: {"a":1} -> "a"`},
+		},
+		{
+			name:     "changed root value uses root path",
+			remote:   `[1]`,
+			rendered: `"a"`,
+			want:     []string{`~ .: [1] -> "a"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := plan.Diff([]byte(tt.remote), []byte(tt.rendered))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiff_MasksSecrets(t *testing.T) {
+	remote := `{"token": "old", "header": "old", "name": "a"}`
+	rendered := `{"token": "s3\"cr\\et<&>", "header": "Bearer s3\"cr\\et<&>", "name": "b"}`
+
+	diff, err := plan.Diff([]byte(remote), []byte(rendered), `s3\"cr\\et<&>`, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`~ header: "old" -> "Bearer ****"`,
+		`~ name: "a" -> "b"`,
+		`~ token: "old" -> "****"`,
+	}, diff)
+}
+
+func TestDiff_InvalidJSON(t *testing.T) {
+	_, err := plan.Diff([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err)
+
+	_, err = plan.Diff([]byte(`{}`), []byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+// fileVersion is the version of the plan file format written by Write.
+const fileVersion = 1
+
+// file is the serialized form of a Plan.
+type file struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+}
+
+// Write writes the given Plan to a file at the given path.
+func Write(fs afero.Fs, path string, p *Plan) error {
+	b, err := json.MarshalIndent(file{Version: fileVersion, Entries: p.Entries()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, filepath.Clean(path), b, 0664); err != nil {
+		return fmt.Errorf("failed to write plan file %q: %w", path, err)
+	}
+	return nil
+}
+
+// Load reads a Plan previously written by Write from the file at the given path.
+func Load(fs afero.Fs, path string) (*Plan, error) {
+	b, err := afero.ReadFile(fs, filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file %q: %w", path, err)
+	}
+
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal plan file %q: %w", path, err)
+	}
+
+	if f.Version != fileVersion {
+		return nil, fmt.Errorf("unsupported version %d of plan file %q, expected version %d", f.Version, path, fileVersion)
+	}
+
+	p := New()
+	for _, e := range f.Entries {
+		if _, found := p.Entry(e.Environment, e.Coordinate); found {
+			return nil, fmt.Errorf("plan file %q contains config %q in environment %q more than once", path, e.Coordinate, e.Environment)
+		}
+		p.Add(e)
+	}
+	return p, nil
+}
+
+// Hash returns the hash of the given remote JSON payload. The payload is normalized first, so that the hash does not
+// depend on the formatting or order of properties returned by the API.
+func Hash(payload []byte) (string, error) {
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return "", err
+	}
+
+	normalized, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,91 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+)
+
+func TestWriteAndLoad(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	p := plan.New()
+	entry := plan.Entry{
+		Environment: "env",
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"},
+		Action:      plan.ActionUpdate,
+		RemoteID:    "1234",
+		RemoteHash:  "abcd",
+		Payload:     `{"name": "a"}`,
+		Diff:        []string{`~ name: "b" -> "a"`},
+	}
+	p.Add(entry)
+
+	require.NoError(t, plan.Write(fs, "plan.json", p))
+
+	loaded, err := plan.Load(fs, "plan.json")
+	require.NoError(t, err)
+	assert.Equal(t, []plan.Entry{entry}, loaded.Entries())
+
+	got, found := loaded.Entry("env", entry.Coordinate)
+	assert.True(t, found)
+	assert.Equal(t, entry, got)
+
+	_, found = loaded.Entry("other-env", entry.Coordinate)
+	assert.False(t, found)
+}
+
+func TestLoad_FailsOnUnknownVersion(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "plan.json", []byte(`{"version": 42, "entries": []}`), 0644))
+
+	_, err := plan.Load(fs, "plan.json")
+	assert.ErrorContains(t, err, "unsupported version 42")
+}
+
+func TestLoad_FailsOnDuplicateEntries(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	entry := `{"environment": "env", "coordinate": {"project": "p", "type": "t", "configId": "1"}, "action": "create", "payload": "{}"}`
+	require.NoError(t, afero.WriteFile(fs, "plan.json", []byte(`{"version": 1, "entries": [`+entry+`, `+entry+`]}`), 0644))
+
+	_, err := plan.Load(fs, "plan.json")
+	assert.ErrorContains(t, err, `contains config "p:t:1" in environment "env" more than once`)
+}
+
+func TestLoad_FailsOnMissingFile(t *testing.T) {
+	_, err := plan.Load(afero.NewMemMapFs(), "plan.json")
+	assert.Error(t, err)
+}
+
+func TestHash_IgnoresFormattingAndOrder(t *testing.T) {
+	a, err := plan.Hash([]byte(`{"a": 1, "b": [1, 2]}`))
+	require.NoError(t, err)
+	b, err := plan.Hash([]byte(`{ "b":[1,2],"a":1 }`))
+	require.NoError(t, err)
+	c, err := plan.Hash([]byte(`{"a": 2, "b": [1, 2]}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+}
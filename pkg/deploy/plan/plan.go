@@ -0,0 +1,163 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package plan provides the means to compute what a deployment would change on a Dynatrace environment, without
+// actually changing anything.
+package plan
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// Action describes what deploying a config would do to its remote object.
+type Action string
+
+const (
+	// ActionCreate means that no remote object exists yet, and deploying the config creates one.
+	ActionCreate Action = "create"
+	// ActionUpdate means that the remote object exists, but differs from the rendered config.
+	ActionUpdate Action = "update"
+	// ActionUnchanged means that the remote object exists and already matches the rendered config.
+	ActionUnchanged Action = "unchanged"
+	// ActionDelete means that the remote object was deployed for a config that was removed from its project since,
+	// and pruning deletes it. Such entries are no part of the deployment itself.
+	ActionDelete Action = "delete"
+)
+
+// Entry is the planned outcome of deploying a single config to an environment.
+type Entry struct {
+	// Environment is the name of the environment the config is deployed to.
+	Environment string `json:"environment"`
+	// Coordinate is the coordinate of the config.
+	Coordinate coordinate.Coordinate `json:"coordinate"`
+	// Action is what deploying the config would do.
+	Action Action `json:"action"`
+	// RemoteID is the ID of the existing remote object. It is empty for ActionCreate.
+	RemoteID string `json:"remoteId,omitempty"`
+	// RemoteHash is the hash of the remote object the plan was computed against. It is empty for ActionCreate.
+	RemoteHash string `json:"remoteHash,omitempty"`
+	// Payload is the rendered payload of the config. References to configs that are created by the same deployment
+	// contain the PlaceholderID of the referenced config, and the values of secret parameters are replaced with their
+	// SecretPlaceholder. It is empty for ActionDelete.
+	Payload string `json:"payload"`
+	// Diff lists the differences between the remote object and the rendered config. It is only set for ActionUpdate.
+	Diff []string `json:"diff,omitempty"`
+}
+
+// entryKey identifies the Entry of a config in an environment.
+type entryKey struct {
+	environment string
+	coordinate  coordinate.Coordinate
+}
+
+// Plan collects the Entry of each config of a planned deployment. It is safe for concurrent use.
+type Plan struct {
+	mutex   sync.Mutex
+	entries map[entryKey]Entry
+}
+
+// New returns a new, empty Plan.
+func New() *Plan {
+	return &Plan{entries: make(map[entryKey]Entry)}
+}
+
+// Add adds the given Entry to the Plan, replacing an existing Entry of the same config in the same environment.
+func (p *Plan) Add(e Entry) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.entries[entryKey{e.Environment, e.Coordinate}] = e
+}
+
+// Entries returns all entries of the Plan, sorted by environment and coordinate.
+func (p *Plan) Entries() []Entry {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	entries := make([]Entry, 0, len(p.entries))
+	for _, e := range p.entries {
+		entries = append(entries, e)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(a.Environment, b.Environment),
+			cmp.Compare(a.Coordinate.String(), b.Coordinate.String()),
+		)
+	})
+	return entries
+}
+
+// Entry returns the Entry of the config with the given coordinate in the given environment.
+func (p *Plan) Entry(environment string, c coordinate.Coordinate) (Entry, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	e, found := p.entries[entryKey{environment, c}]
+	return e, found
+}
+
+// Validate checks that the Plan contains exactly the given configs, which are the configs to deploy per environment.
+// It reports every config and environment that is only part of either the Plan or the deployment, so that a
+// mismatching Plan is rejected before anything is deployed. Entries with ActionDelete are not deployed and thus
+// must not match any of the given configs.
+func (p *Plan) Validate(configs map[string][]coordinate.Coordinate) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var errs []error
+	deployed := make(map[entryKey]struct{})
+	for environment, coordinates := range configs {
+		for _, c := range coordinates {
+			key := entryKey{environment, c}
+			deployed[key] = struct{}{}
+			if e, found := p.entries[key]; !found || e.Action == ActionDelete {
+				errs = append(errs, fmt.Errorf("config %q in environment %q: %w", c, environment, ErrNotPlanned))
+			}
+		}
+	}
+
+	for key, e := range p.entries {
+		if e.Action == ActionDelete {
+			continue
+		}
+		if _, found := configs[key.environment]; !found {
+			errs = append(errs, fmt.Errorf("config %q in environment %q: %w", key.coordinate, key.environment, ErrEnvironmentNotDeployed))
+			continue
+		}
+		if _, found := deployed[key]; !found {
+			errs = append(errs, fmt.Errorf("config %q in environment %q: %w", key.coordinate, key.environment, ErrNotDeployed))
+		}
+	}
+
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b error) int { return cmp.Compare(a.Error(), b.Error()) })
+		return fmt.Errorf("plan does not match the deployment: %w", multierror.New(errs...))
+	}
+	return nil
+}
+
+// PlaceholderID returns the ID used for configs which would be newly created by a deployment. As the actual ID is
+// only known after the object was created, this placeholder is used to resolve references to such configs instead.
+func PlaceholderID(c coordinate.Coordinate) string {
+	return fmt.Sprintf("monaco-planned-%s", idutils.GenerateUUIDFromCoordinate(c))
+}
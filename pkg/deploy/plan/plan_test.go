@@ -0,0 +1,107 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+)
+
+func TestPlan_EntriesAreSorted(t *testing.T) {
+	p := plan.New()
+	p.Add(plan.Entry{Environment: "b", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"}, Action: plan.ActionCreate})
+	p.Add(plan.Entry{Environment: "a", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "2"}, Action: plan.ActionUpdate})
+	p.Add(plan.Entry{Environment: "a", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"}, Action: plan.ActionUnchanged})
+
+	entries := p.Entries()
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "a", entries[0].Environment)
+	assert.Equal(t, "1", entries[0].Coordinate.ConfigId)
+	assert.Equal(t, "a", entries[1].Environment)
+	assert.Equal(t, "2", entries[1].Coordinate.ConfigId)
+	assert.Equal(t, "b", entries[2].Environment)
+}
+
+func TestPlaceholderID_IsStable(t *testing.T) {
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"}
+	assert.Equal(t, plan.PlaceholderID(c), plan.PlaceholderID(c))
+	assert.NotEqual(t, plan.PlaceholderID(c), plan.PlaceholderID(coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "2"}))
+}
+
+func TestPlan_AddReplacesEntryOfSameConfig(t *testing.T) {
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"}
+	p := plan.New()
+	p.Add(plan.Entry{Environment: "env", Coordinate: c, Action: plan.ActionCreate})
+	p.Add(plan.Entry{Environment: "env", Coordinate: c, Action: plan.ActionUpdate})
+
+	assert.Len(t, p.Entries(), 1)
+	e, found := p.Entry("env", c)
+	assert.True(t, found)
+	assert.Equal(t, plan.ActionUpdate, e.Action)
+
+	_, found = p.Entry("other-env", c)
+	assert.False(t, found)
+}
+
+func TestPlan_Validate(t *testing.T) {
+	c1 := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "1"}
+	c2 := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "2"}
+	p := plan.New()
+	p.Add(plan.Entry{Environment: "a", Coordinate: c1, Action: plan.ActionCreate})
+	p.Add(plan.Entry{Environment: "b", Coordinate: c1, Action: plan.ActionCreate})
+
+	t.Run("matching deployment", func(t *testing.T) {
+		assert.NoError(t, p.Validate(map[string][]coordinate.Coordinate{"a": {c1}, "b": {c1}}))
+	})
+
+	t.Run("config missing in the plan", func(t *testing.T) {
+		err := p.Validate(map[string][]coordinate.Coordinate{"a": {c1, c2}, "b": {c1}})
+		assert.ErrorIs(t, err, plan.ErrNotPlanned)
+		assert.ErrorContains(t, err, `config "p:t:2" in environment "a"`)
+	})
+
+	t.Run("planned config not deployed", func(t *testing.T) {
+		err := p.Validate(map[string][]coordinate.Coordinate{"a": {}, "b": {c1}})
+		assert.ErrorIs(t, err, plan.ErrNotDeployed)
+		assert.ErrorContains(t, err, `config "p:t:1" in environment "a"`)
+	})
+
+	t.Run("planned environment not deployed", func(t *testing.T) {
+		err := p.Validate(map[string][]coordinate.Coordinate{"a": {c1}})
+		assert.ErrorIs(t, err, plan.ErrEnvironmentNotDeployed)
+		assert.ErrorContains(t, err, `environment "b"`)
+	})
+
+	t.Run("planned deletions are not deployed", func(t *testing.T) {
+		withDeletion := plan.New()
+		withDeletion.Add(plan.Entry{Environment: "a", Coordinate: c1, Action: plan.ActionCreate})
+		withDeletion.Add(plan.Entry{Environment: "a", Coordinate: c2, Action: plan.ActionDelete})
+		withDeletion.Add(plan.Entry{Environment: "c", Coordinate: c2, Action: plan.ActionDelete})
+
+		assert.NoError(t, withDeletion.Validate(map[string][]coordinate.Coordinate{"a": {c1}}))
+
+		err := withDeletion.Validate(map[string][]coordinate.Coordinate{"a": {c1, c2}})
+		assert.ErrorIs(t, err, plan.ErrNotPlanned, "a deployed config must not be planned to be deleted")
+		assert.ErrorContains(t, err, `config "p:t:2" in environment "a"`)
+	})
+}
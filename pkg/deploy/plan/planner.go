@@ -0,0 +1,165 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+// planner is a resource.Deployable that does not deploy anything, but records what a deployment would do in a Plan.
+type planner struct {
+	plannable resource.Plannable
+	plan      *Plan
+}
+
+// NewDeployables returns resource.Deployables, which record what a deployment would do in the given Plan instead of
+// deploying configs. Newly created configs are resolved with their PlaceholderID, existing ones with their remote ID.
+func NewDeployables(plannables resource.Plannables, p *Plan) resource.Deployables {
+	deployables := make(resource.Deployables, len(plannables))
+	for t, plannable := range plannables {
+		deployables[t] = planner{plannable: plannable, plan: p}
+	}
+	return deployables
+}
+
+func (p planner) Deploy(ctx context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	remote, found, err := p.plannable.Lookup(ctx, properties, renderedConfig, c)
+	if err != nil {
+		return entities.ResolvedEntity{}, fmt.Errorf("failed to look up remote object: %w", err)
+	}
+
+	secrets := secretValues(properties)
+	entry := Entry{
+		Environment: c.Environment,
+		Coordinate:  c.Coordinate,
+		Action:      ActionCreate,
+		Payload:     replaceSecretsWithPlaceholders(renderedConfig, secrets),
+	}
+	id := PlaceholderID(c.Coordinate)
+
+	if found {
+		diff, err := Diff(remote.Payload, []byte(renderedConfig), slices.Collect(maps.Values(secrets))...)
+		if err != nil {
+			return entities.ResolvedEntity{}, fmt.Errorf("failed to compare remote object %q: %w", remote.ID, err)
+		}
+
+		hash, err := Hash(remote.Payload)
+		if err != nil {
+			return entities.ResolvedEntity{}, fmt.Errorf("failed to hash remote object %q: %w", remote.ID, err)
+		}
+
+		entry.RemoteID = remote.ID
+		entry.RemoteHash = hash
+		entry.Action = ActionUnchanged
+		if len(diff) > 0 {
+			entry.Action = ActionUpdate
+			entry.Diff = diff
+		}
+		id = remote.ID
+	}
+
+	p.plan.Add(entry)
+	report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeInfo, Message: fmt.Sprintf("Planned action: %s", entry.Action)})
+
+	properties[config.IdParameter] = id
+	return entities.ResolvedEntity{
+		Coordinate: c.Coordinate,
+		Properties: properties,
+	}, nil
+}
+
+// secretValues returns the actual values of all secrets contained in the given properties by their path.
+func secretValues(properties parameter.Properties) map[string]string {
+	values := make(map[string]string)
+	collectSecretValues("", map[string]any(properties), values)
+	return values
+}
+
+func collectSecretValues(path string, v any, values map[string]string) {
+	switch value := v.(type) {
+	case secret.MaskedString:
+		values[path] = value.Value()
+	case map[string]any:
+		for k, val := range value {
+			collectSecretValues(joinPath(path, k), val, values)
+		}
+	case []any:
+		for i, val := range value {
+			collectSecretValues(fmt.Sprintf("%s[%d]", path, i), val, values)
+		}
+	}
+}
+
+// SecretPlaceholder returns the placeholder that replaces the value of the secret parameter at the given path in
+// planned payloads, so that plans do not contain any secrets. The placeholders are replaced with the actual values of
+// the secrets again when the plan is applied.
+func SecretPlaceholder(path string) string {
+	return "{{monaco-secret:" + path + "}}"
+}
+
+var secretPlaceholderPattern = regexp.MustCompile(`\{\{monaco-secret:([^}]*)\}\}`)
+
+// replaceSecretsWithPlaceholders replaces all occurrences of the given secrets in the payload with their
+// SecretPlaceholder. Longer secrets are replaced first, so that secrets containing other secrets are replaced as a whole.
+func replaceSecretsWithPlaceholders(payload string, secrets map[string]string) string {
+	paths := slices.Collect(maps.Keys(secrets))
+	paths = slices.DeleteFunc(paths, func(p string) bool { return secrets[p] == "" })
+	if len(paths) == 0 {
+		return payload
+	}
+	slices.SortFunc(paths, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(secrets[b]), len(secrets[a])), cmp.Compare(a, b))
+	})
+
+	replacements := make([]string, 0, 2*len(paths))
+	for _, p := range paths {
+		replacements = append(replacements, secrets[p], SecretPlaceholder(p))
+	}
+	return strings.NewReplacer(replacements...).Replace(payload)
+}
+
+// replacePlaceholdersWithSecrets replaces each SecretPlaceholder in the payload with the actual value of the secret.
+// It fails if the payload contains a placeholder of a secret that is not given.
+func replacePlaceholdersWithSecrets(payload string, secrets map[string]string) (string, error) {
+	var missing []string
+	replaced := secretPlaceholderPattern.ReplaceAllStringFunc(payload, func(placeholder string) string {
+		path := secretPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		value, found := secrets[path]
+		if !found {
+			missing = append(missing, path)
+			return placeholder
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("planned payload references unknown secret parameters %q", missing)
+	}
+	return replaced, nil
+}
@@ -0,0 +1,129 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type fakePlannable struct {
+	remote   *resource.RemoteObject
+	deployed string
+}
+
+func (f *fakePlannable) Lookup(_ context.Context, _ parameter.Properties, _ string, _ *config.Config) (resource.RemoteObject, bool, error) {
+	if f.remote == nil {
+		return resource.RemoteObject{}, false, nil
+	}
+	return *f.remote, true, nil
+}
+
+func (f *fakePlannable) Deploy(_ context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	f.deployed = renderedConfig
+	properties[config.IdParameter] = "id"
+	return entities.ResolvedEntity{Coordinate: c.Coordinate, Properties: properties}, nil
+}
+
+// escapedSecret returns the secret as it is resolved by a secret parameter.
+func escapedSecret(t *testing.T, s string) secret.MaskedString {
+	escaped, err := template.EscapeSpecialCharactersInValue(s, template.FullStringEscapeFunction)
+	require.NoError(t, err)
+	return secret.MaskedString(escaped.(string))
+}
+
+func TestPlanner_MasksSecrets(t *testing.T) {
+	const token = `s3"cr\et<&>` + "\x01"
+	c := &config.Config{
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "c"},
+		Environment: "env",
+	}
+	// secrets are rendered into templates in their escaped form
+	escaped := string(escapedSecret(t, token))
+	rendered := `{"token": "` + escaped + `", "header": "Bearer ` + escaped + `", "name": "b"}`
+	properties := func() parameter.Properties {
+		return parameter.Properties{"token": escapedSecret(t, token), "nested": map[string]any{"name": "b"}}
+	}
+
+	t.Run("diff does not contain secrets", func(t *testing.T) {
+		p := New()
+		remote := &resource.RemoteObject{ID: "1", Payload: []byte(`{"token": "old", "header": "Bearer old", "name": "a"}`)}
+		planner := planner{plannable: &fakePlannable{remote: remote}, plan: p}
+
+		_, err := planner.Deploy(t.Context(), properties(), rendered, c)
+		require.NoError(t, err)
+
+		e, found := p.Entry("env", c.Coordinate)
+		require.True(t, found)
+		assert.Equal(t, []string{
+			`~ header: "Bearer old" -> "Bearer ****"`,
+			`~ name: "a" -> "b"`,
+			`~ token: "old" -> "****"`,
+		}, e.Diff)
+	})
+
+	t.Run("payload does not contain secrets, but they are restored when applying", func(t *testing.T) {
+		p := New()
+		plannable := &fakePlannable{}
+		_, err := planner{plannable: plannable, plan: p}.Deploy(t.Context(), properties(), rendered, c)
+		require.NoError(t, err)
+
+		e, found := p.Entry("env", c.Coordinate)
+		require.True(t, found)
+		assert.Equal(t, `{"token": "{{monaco-secret:token}}", "header": "Bearer {{monaco-secret:token}}", "name": "b"}`, e.Payload)
+
+		_, err = NewApplyingDeployables(resource.Deployables{"": plannable}, resource.Plannables{"": plannable}, p)[""].Deploy(t.Context(), properties(), "", c)
+		require.NoError(t, err)
+		assert.Equal(t, rendered, plannable.deployed)
+	})
+
+	t.Run("applying fails if secret is unknown", func(t *testing.T) {
+		p := New()
+		p.Add(Entry{Environment: "env", Coordinate: c.Coordinate, Action: ActionCreate, Payload: `{"token": "` + SecretPlaceholder("other") + `"}`})
+		plannable := &fakePlannable{}
+
+		_, err := NewApplyingDeployables(resource.Deployables{"": plannable}, resource.Plannables{"": plannable}, p)[""].Deploy(t.Context(), properties(), "", c)
+		assert.ErrorContains(t, err, "unknown secret parameters")
+		assert.Empty(t, plannable.deployed)
+	})
+}
+
+func TestReplaceSecretsWithPlaceholders(t *testing.T) {
+	secrets := map[string]string{"a": "s3cr3t", "b": "s3cr3t-long", "empty": ""}
+
+	masked := replaceSecretsWithPlaceholders(`{"x": "s3cr3t-long", "y": "Bearer s3cr3t"}`, secrets)
+	assert.Equal(t, `{"x": "{{monaco-secret:b}}", "y": "Bearer {{monaco-secret:a}}"}`, masked)
+
+	restored, err := replacePlaceholdersWithSecrets(masked, secrets)
+	require.NoError(t, err)
+	assert.Equal(t, `{"x": "s3cr3t-long", "y": "Bearer s3cr3t"}`, restored)
+
+	assert.Equal(t, `{}`, replaceSecretsWithPlaceholders(`{}`, nil))
+}
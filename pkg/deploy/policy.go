@@ -0,0 +1,107 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gonum "gonum.org/v1/gonum/graph"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+// policyApplies returns whether deployment policies shall be honored. Dry-runs and plans never change an
+// environment, so there is nothing to wait for.
+func policyApplies(ctx context.Context, policy *config.DeploymentPolicy) bool {
+	return policy != nil && !isDryRun(ctx) && getPlanFromContext(ctx) == nil
+}
+
+// deployWithPolicy calls deploy, honoring the timeout and retries of the given policy. The timeout ends the context of
+// the deployment. The retries are passed to the clients using the context, which retry each failed request that many
+// times instead of their default number of times.
+func deployWithPolicy(ctx context.Context, policy *config.DeploymentPolicy, deploy func(ctx context.Context) (entities.ResolvedEntity, error)) (entities.ResolvedEntity, error) {
+	if !policyApplies(ctx, policy) {
+		return deploy(ctx)
+	}
+
+	if policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, policy.Timeout)
+		defer cancel()
+	}
+
+	if policy.Retries != nil {
+		ctx = dtclient.NewContextWithMaxRetries(ctx, *policy.Retries)
+	}
+
+	return deploy(ctx)
+}
+
+// dependentWaits tracks when configs may be deployed, as configs they depend on were deployed with a policy defining
+// a duration to wait afterward. It is safe for concurrent use.
+type dependentWaits struct {
+	mutex   sync.Mutex
+	readyAt map[int64]time.Time
+}
+
+func newDependentWaits() *dependentWaits {
+	return &dependentWaits{readyAt: make(map[int64]time.Time)}
+}
+
+// deployed records that the given node was deployed, so that the given dependents of the node are only deployed after
+// the WaitAfter duration of its policy, if any.
+func (w *dependentWaits) deployed(ctx context.Context, n graph.ConfigNode, dependents gonum.Nodes) {
+	policy := n.Config.Deployment
+	if !policyApplies(ctx, policy) || policy.WaitAfter <= 0 {
+		return
+	}
+
+	log.InfoContext(ctx, "Waiting %s after deployment before deploying dependent configurations", policy.WaitAfter)
+	readyAt := time.Now().Add(policy.WaitAfter)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for dependents.Next() {
+		id := dependents.Node().ID()
+		if readyAt.After(w.readyAt[id]) {
+			w.readyAt[id] = readyAt
+		}
+	}
+}
+
+// wait blocks until the node with the given ID may be deployed, or the context is done. It does not block, if none of
+// the configs the node depends on defines a duration to wait.
+func (w *dependentWaits) wait(ctx context.Context, id int64) {
+	w.mutex.Lock()
+	readyAt, found := w.readyAt[id]
+	w.mutex.Unlock()
+
+	if !found {
+		return
+	}
+
+	select {
+	case <-time.After(time.Until(readyAt)):
+	case <-ctx.Done():
+	}
+}
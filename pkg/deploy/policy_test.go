@@ -0,0 +1,135 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/plan"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+func TestDeployWithPolicy(t *testing.T) {
+	errDeploy := errors.New("deployment failed")
+	deployCountingCalls := func(calls *int, ctxCheck func(ctx context.Context)) func(context.Context) (entities.ResolvedEntity, error) {
+		return func(ctx context.Context) (entities.ResolvedEntity, error) {
+			*calls++
+			ctxCheck(ctx)
+			return entities.ResolvedEntity{}, errDeploy
+		}
+	}
+
+	t.Run("a failed deployment is not retried on top of the client retries", func(t *testing.T) {
+		calls := 0
+		_, err := deployWithPolicy(t.Context(), &config.DeploymentPolicy{Retries: pointer.Pointer(3)}, deployCountingCalls(&calls, func(context.Context) {}))
+		assert.ErrorIs(t, err, errDeploy)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retries are passed to the clients", func(t *testing.T) {
+		calls := 0
+		_, err := deployWithPolicy(t.Context(), &config.DeploymentPolicy{Retries: pointer.Pointer(0)}, deployCountingCalls(&calls, func(ctx context.Context) {
+			maxRetries, found := dtclient.MaxRetriesFromContext(ctx)
+			assert.True(t, found)
+			assert.Equal(t, 0, maxRetries)
+		}))
+		assert.ErrorIs(t, err, errDeploy)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("without retries, the default retries of the clients apply", func(t *testing.T) {
+		calls := 0
+		_, err := deployWithPolicy(t.Context(), &config.DeploymentPolicy{Timeout: time.Minute}, deployCountingCalls(&calls, func(ctx context.Context) {
+			_, found := dtclient.MaxRetriesFromContext(ctx)
+			assert.False(t, found)
+		}))
+		assert.ErrorIs(t, err, errDeploy)
+	})
+
+	t.Run("deployment context ends after the timeout", func(t *testing.T) {
+		_, err := deployWithPolicy(t.Context(), &config.DeploymentPolicy{Timeout: time.Millisecond}, func(ctx context.Context) (entities.ResolvedEntity, error) {
+			<-ctx.Done()
+			return entities.ResolvedEntity{}, ctx.Err()
+		})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("policy is ignored in dry-run and plan mode", func(t *testing.T) {
+		for _, ctx := range []context.Context{newContextWithDryRun(t.Context()), newContextWithPlan(t.Context(), plan.New())} {
+			calls := 0
+			_, err := deployWithPolicy(ctx, &config.DeploymentPolicy{Retries: pointer.Pointer(3), Timeout: time.Millisecond}, deployCountingCalls(&calls, func(ctx context.Context) {
+				_, found := dtclient.MaxRetriesFromContext(ctx)
+				assert.False(t, found)
+				_, hasDeadline := ctx.Deadline()
+				assert.False(t, hasDeadline)
+			}))
+			assert.ErrorIs(t, err, errDeploy)
+		}
+	})
+}
+
+func TestDependentWaits(t *testing.T) {
+	newNode := func(id int64, policy *config.DeploymentPolicy) graph.ConfigNode {
+		return graph.ConfigNode{NodeID: id, Config: &config.Config{Deployment: policy}}
+	}
+	g := simple.NewDirectedGraph()
+	parent := newNode(1, &config.DeploymentPolicy{WaitAfter: 50 * time.Millisecond})
+	child := newNode(2, nil)
+	unrelated := newNode(3, nil)
+	g.AddNode(parent)
+	g.AddNode(child)
+	g.AddNode(unrelated)
+	g.SetEdge(g.NewEdge(parent, child))
+
+	waits := newDependentWaits()
+	waits.deployed(t.Context(), parent, g.From(parent.ID()))
+
+	start := time.Now()
+	waits.wait(t.Context(), unrelated.ID())
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "configs not depending on the deployed one do not wait")
+
+	waits.wait(t.Context(), child.ID())
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "dependents wait for the duration after the deployment")
+
+	t.Run("waiting ends with the context", func(t *testing.T) {
+		waits := newDependentWaits()
+		waits.deployed(t.Context(), newNode(1, &config.DeploymentPolicy{WaitAfter: time.Hour}), g.From(parent.ID()))
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		waits.wait(ctx, child.ID())
+	})
+
+	t.Run("nothing is waited for in dry-run mode", func(t *testing.T) {
+		waits := newDependentWaits()
+		waits.deployed(newContextWithDryRun(t.Context()), newNode(1, &config.DeploymentPolicy{WaitAfter: time.Hour}), g.From(parent.ID()))
+
+		waits.wait(t.Context(), child.ID())
+	})
+}
@@ -0,0 +1,55 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	gonum "gonum.org/v1/gonum/graph"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+// responseReferenced returns whether any of the given dependents references a value of the deployed object of n, which
+// is not one of its parameters and thus needs to be resolved from its entities.ResolvedEntity Response.
+func responseReferenced(n graph.ConfigNode, dependents gonum.Nodes) bool {
+	for dependents.Next() {
+		dependent := dependents.Node().(graph.ConfigNode)
+		for _, p := range dependent.Config.Parameters {
+			for _, ref := range p.GetReferences() {
+				if ref.Config == n.Config.Coordinate && referencesResponse(n.Config, ref.Property) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// referencesResponse returns whether the given property of the config is resolved from the response of its deployed
+// object, which is the case for nested paths and for properties that are no parameter of the config.
+func referencesResponse(c *config.Config, property string) bool {
+	path, err := entities.ParsePropertyPath(property)
+	if err != nil || len(path) != 1 || path[0].IsIndex {
+		return true
+	}
+	if path[0].Key == config.IdParameter {
+		return false
+	}
+	_, isParameter := c.Parameters[path[0].Key]
+	return !isParameter
+}
@@ -0,0 +1,88 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+)
+
+func TestReferencesResponse(t *testing.T) {
+	c := &config.Config{Parameters: config.Parameters{
+		config.NameParameter: &value.ValueParameter{Value: "name"},
+		"rules":              &value.ValueParameter{Value: []any{map[string]any{"id": "a"}}},
+	}}
+
+	tests := []struct {
+		property string
+		want     bool
+	}{
+		{config.IdParameter, false},
+		{config.NameParameter, false},
+		{"rules", false},
+		{"rules[0].id", true},
+		{"tasks[0].id", true},
+		{"title", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.property, func(t *testing.T) {
+			assert.Equal(t, tt.want, referencesResponse(c, tt.property))
+		})
+	}
+}
+
+func TestResponseReferenced(t *testing.T) {
+	referenced := graph.ConfigNode{NodeID: 1, Config: &config.Config{Coordinate: coordinate.Coordinate{Project: "p", Type: "workflow", ConfigId: "wf"}}}
+	other := coordinate.Coordinate{Project: "p", Type: "workflow", ConfigId: "other"}
+	dependent := func(id int64, ref *reference.ReferenceParameter) graph.ConfigNode {
+		return graph.ConfigNode{NodeID: id, Config: &config.Config{Parameters: config.Parameters{"ref": ref}}}
+	}
+
+	tests := []struct {
+		name       string
+		dependents []graph.ConfigNode
+		want       bool
+	}{
+		{"no dependents", nil, false},
+		{"dependent references id", []graph.ConfigNode{dependent(2, reference.NewWithCoordinate(referenced.Config.Coordinate, "id"))}, false},
+		{"dependent references nested value", []graph.ConfigNode{
+			dependent(2, reference.NewWithCoordinate(referenced.Config.Coordinate, "id")),
+			dependent(3, reference.NewWithCoordinate(referenced.Config.Coordinate, "tasks[0].id")),
+		}, true},
+		{"dependent references nested value of other config", []graph.ConfigNode{dependent(2, reference.NewWithCoordinate(other, "tasks[0].id"))}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := simple.NewDirectedGraph()
+			g.AddNode(referenced)
+			for _, d := range tt.dependents {
+				g.SetEdge(g.NewEdge(referenced, d))
+			}
+			assert.Equal(t, tt.want, responseReferenced(referenced, g.From(referenced.ID())))
+		})
+	}
+}
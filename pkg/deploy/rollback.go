@@ -0,0 +1,196 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log/field"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/delete/pointer"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+// Rollback restores the state of an environment recorded by a deployment using DeployConfigsOptions.SnapshotStore.
+// Updated objects are reset to their recorded payload, and objects created by the deployment are deleted.
+func Rollback(ctx context.Context, clientSet *client.ClientSet, entries []snapshot.Entry) error {
+	deployables := createDeployables(clientSet)
+	entriesToDelete := make(delete.DeleteEntries)
+	errCount := 0
+
+	for _, e := range entries {
+		logger := log.WithFields(field.Coordinate(e.Coordinate))
+
+		switch e.Action {
+		case snapshot.ActionUpdate:
+			if err := restore(ctx, clientSet, deployables, e); err != nil {
+				logger.WithFields(field.Error(err)).ErrorContext(ctx, "Failed to restore %q: %v", e.RemoteID, err)
+				errCount++
+				continue
+			}
+			logger.InfoContext(ctx, "Restored %q", e.RemoteID)
+
+		case snapshot.ActionCreate:
+			if e.Type.ID == config.OpenPipelineTypeID {
+				logger.WarnContext(ctx, "OpenPipeline configuration %q cannot be deleted and is kept", e.RemoteID)
+				continue
+			}
+			entriesToDelete[e.Coordinate.Type] = append(entriesToDelete[e.Coordinate.Type], toDeletePointer(e))
+
+		default:
+			logger.ErrorContext(ctx, "Unknown snapshot action %q", e.Action)
+			errCount++
+		}
+	}
+
+	if len(entriesToDelete) > 0 {
+		log.InfoContext(ctx, "Deleting newly created configurations...")
+		if err := delete.Configs(ctx, *clientSet, entriesToDelete); err != nil {
+			log.WithFields(field.Error(err)).ErrorContext(ctx, "Failed to delete all newly created configurations: %v", err)
+			errCount++
+		}
+	}
+
+	if errCount > 0 {
+		return fmt.Errorf("encountered %d errors during rollback", errCount)
+	}
+	return nil
+}
+
+// serverManagedProperties lists the properties of the remote objects of each type, which are managed by the server.
+// They are removed from a recorded payload before it is restored, the same way a download removes them.
+var serverManagedProperties = map[config.TypeID][]string{
+	config.ClassicApiTypeID:        {"id", "metadata"},
+	config.AutomationTypeID:        {"id", "modificationInfo", "lastExecution"},
+	config.BucketTypeID:            {"bucketName", "status", "version", "updatable"},
+	config.SegmentID:               {"uid", "version", "externalId"},
+	config.ServiceLevelObjectiveID: {"id", "version", "externalId"},
+}
+
+// restore resets the remote object of the given Entry to the recorded payload. The object is identified by the
+// recorded ID, so that it is restored even if the config identifies it differently by now, e.g. by a changed name.
+func restore(ctx context.Context, clientSet *client.ClientSet, deployables resource.Deployables, e snapshot.Entry) error {
+	t, err := e.Type.ConfigType()
+	if err != nil {
+		return err
+	}
+
+	payload, err := removeServerManagedProperties(t.ID(), e.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to prepare recorded payload: %w", err)
+	}
+
+	if t, ok := t.(config.ClassicApiType); ok {
+		return restoreClassic(ctx, clientSet.ConfigClient, t, e, payload)
+	}
+
+	deployable, found := deployables[t.ID()]
+	if !found {
+		return fmt.Errorf("unsupported config type %q", t.ID())
+	}
+
+	properties := parameter.Properties{}
+	if e.Name != "" {
+		properties[config.NameParameter] = e.Name
+	}
+	if e.Scope != "" {
+		properties[config.ScopeParameter] = e.Scope
+	}
+
+	c := &config.Config{
+		Coordinate:     e.Coordinate,
+		Type:           t,
+		Environment:    e.Environment,
+		OriginObjectId: e.RemoteID,
+	}
+
+	_, err = deployable.Deploy(ctx, properties, payload, c)
+	return err
+}
+
+// restoreClassic updates the classic config object with the recorded ID. Deploying would identify the object by
+// the name of the config instead, creating a duplicate if the config was renamed by the rolled back deployment.
+func restoreClassic(ctx context.Context, configClient client.ConfigClient, t config.ClassicApiType, e snapshot.Entry, payload string) error {
+	if configClient == nil {
+		return fmt.Errorf("config client unavailable")
+	}
+
+	a, found := api.NewAPIs()[t.Api]
+	if !found {
+		return fmt.Errorf("unknown API %q", t.Api)
+	}
+	if a.HasParent() {
+		a = a.ApplyParentObjectID(e.Scope)
+	}
+
+	_, err := configClient.UpdateByID(ctx, a, e.RemoteID, e.Name, []byte(payload))
+	return err
+}
+
+// removeServerManagedProperties removes the serverManagedProperties of the given type from the given payload.
+func removeServerManagedProperties(t config.TypeID, payload string) (string, error) {
+	properties, found := serverManagedProperties[t]
+	if !found {
+		return payload, nil
+	}
+
+	// numbers are kept as they are, as decoding them as float64 may lose precision
+	var o map[string]any
+	d := json.NewDecoder(strings.NewReader(payload))
+	d.UseNumber()
+	if err := d.Decode(&o); err != nil {
+		return "", err
+	}
+	maps.DeleteFunc(o, func(k string, _ any) bool { return slices.Contains(properties, k) })
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// toDeletePointer returns the pointer to delete the remote object of the given Entry. Settings objects are identified by
+// their coordinate, all other objects are identified by their ID.
+func toDeletePointer(e snapshot.Entry) pointer.DeletePointer {
+	if e.Type.ID == config.SettingsTypeID {
+		return pointer.DeletePointer{
+			Project:    e.Coordinate.Project,
+			Type:       e.Coordinate.Type,
+			Identifier: e.Coordinate.ConfigId,
+			Scope:      e.Scope,
+		}
+	}
+
+	return pointer.DeletePointer{
+		Project:        e.Coordinate.Project,
+		Type:           e.Coordinate.Type,
+		Scope:          e.Scope,
+		OriginObjectId: e.RemoteID,
+	}
+}
@@ -0,0 +1,305 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	coreautomation "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+)
+
+// restoreRecorder records the object a fake client restored.
+type restoreRecorder struct {
+	calls   int
+	id      string
+	name    string
+	payload []byte
+}
+
+func (r *restoreRecorder) record(id string, name string, payload []byte) {
+	r.calls++
+	r.id = id
+	r.name = name
+	r.payload = payload
+}
+
+type restoreConfigClient struct {
+	dtclient.DummyConfigClient
+	restoreRecorder
+	api api.API
+}
+
+func (c *restoreConfigClient) UpdateByID(_ context.Context, a api.API, id string, name string, payload []byte) (dtclient.DynatraceEntity, error) {
+	c.api = a
+	c.record(id, name, payload)
+	return dtclient.DynatraceEntity{Id: id, Name: name}, nil
+}
+
+func (c *restoreConfigClient) UpsertByName(_ context.Context, _ api.API, _ string, _ []byte) (dtclient.DynatraceEntity, error) {
+	panic("classic configs must be restored by ID")
+}
+
+type restoreSettingsClient struct {
+	dtclient.DummySettingsClient
+	restoreRecorder
+}
+
+func (c *restoreSettingsClient) Upsert(_ context.Context, obj dtclient.SettingsObject, _ dtclient.UpsertSettingsOptions) (dtclient.DynatraceEntity, error) {
+	c.record(obj.OriginObjectId, "", obj.Content)
+	return dtclient.DynatraceEntity{Id: obj.OriginObjectId}, nil
+}
+
+type restoreAutomationClient struct {
+	client.DummyAutomationClient
+	restoreRecorder
+}
+
+func (c *restoreAutomationClient) Upsert(_ context.Context, _ coreautomation.ResourceType, id string, data []byte) (coreapi.Response, error) {
+	c.record(id, "", data)
+	return coreapi.Response{StatusCode: http.StatusOK, Data: []byte(`{"id": "` + id + `"}`)}, nil
+}
+
+type restoreBucketClient struct {
+	client.DummyBucketClient
+	restoreRecorder
+}
+
+func (c *restoreBucketClient) Upsert(_ context.Context, bucketName string, data []byte) (coreapi.Response, error) {
+	c.record(bucketName, "", data)
+	return coreapi.Response{StatusCode: http.StatusOK, Data: data}, nil
+}
+
+type restoreDocumentClient struct {
+	client.DummyDocumentClient
+	restoreRecorder
+}
+
+func (c *restoreDocumentClient) Update(_ context.Context, id string, name string, _ bool, data []byte, _ documents.DocumentType) (coreapi.Response, error) {
+	c.record(id, name, data)
+	return coreapi.Response{StatusCode: http.StatusOK, Data: []byte(`{"id": "` + id + `"}`)}, nil
+}
+
+type restoreSegmentClient struct {
+	client.DummySegmentClient
+	restoreRecorder
+}
+
+func (c *restoreSegmentClient) Update(_ context.Context, id string, data []byte) (coreapi.Response, error) {
+	c.record(id, "", data)
+	return coreapi.Response{StatusCode: http.StatusOK}, nil
+}
+
+type restoreSLOClient struct {
+	client.DummyServiceLevelObjectClient
+	restoreRecorder
+}
+
+func (c *restoreSLOClient) Update(_ context.Context, id string, data []byte) (coreapi.Response, error) {
+	c.record(id, "", data)
+	return coreapi.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestRollback_RestoresUpdatedObjectsByIDWithoutServerManagedProperties(t *testing.T) {
+	tests := []struct {
+		name            string
+		entry           snapshot.Entry
+		clientSet       func() (*client.ClientSet, *restoreRecorder)
+		expectedID      string
+		expectedName    string
+		expectedPayload string
+	}{
+		{
+			name: "classic",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: api.AlertingProfile, ConfigId: "profile"},
+				Type:       snapshot.Type{ID: config.ClassicApiTypeID, Api: api.AlertingProfile},
+				RemoteID:   "profile-id",
+				Name:       "old name",
+				Payload:    `{"id": "profile-id", "metadata": {"configurationVersions": [1]}, "displayName": "old name", "severity": 12345678901234567890}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreConfigClient{}
+				return &client.ClientSet{ConfigClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "profile-id",
+			expectedName:    "old name",
+			expectedPayload: `{"displayName": "old name", "severity": 12345678901234567890}`,
+		},
+		{
+			name: "settings",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "setting"},
+				Type:       snapshot.Type{ID: config.SettingsTypeID, SchemaId: "builtin:test"},
+				RemoteID:   "object-id",
+				Scope:      "environment",
+				Payload:    `{"enabled": true}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreSettingsClient{}
+				return &client.ClientSet{SettingsClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "object-id",
+			expectedPayload: `{"enabled": true}`,
+		},
+		{
+			name: "automation",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "workflow", ConfigId: "workflow"},
+				Type:       snapshot.Type{ID: config.AutomationTypeID, Resource: string(config.Workflow)},
+				RemoteID:   "workflow-id",
+				Payload:    `{"id": "workflow-id", "title": "workflow", "modificationInfo": {"createdBy": "someone"}, "lastExecution": {"state": "SUCCESS"}}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreAutomationClient{}
+				return &client.ClientSet{AutClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "workflow-id",
+			expectedPayload: `{"title": "workflow"}`,
+		},
+		{
+			name: "bucket",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "bucket", ConfigId: "bucket"},
+				Type:       snapshot.Type{ID: config.BucketTypeID},
+				RemoteID:   "bucket_name",
+				Payload:    `{"bucketName": "bucket_name", "table": "logs", "retentionDays": 35, "status": "active", "version": 3, "updatable": true}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreBucketClient{}
+				return &client.ClientSet{BucketClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "bucket_name",
+			expectedPayload: `{"table": "logs", "retentionDays": 35}`,
+		},
+		{
+			name: "document",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "document", ConfigId: "notebook"},
+				Type:       snapshot.Type{ID: config.DocumentTypeID, Kind: string(config.NotebookKind)},
+				RemoteID:   "document-id",
+				Name:       "old name",
+				Payload:    `{"sections": []}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreDocumentClient{}
+				return &client.ClientSet{DocumentClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "document-id",
+			expectedName:    "old name",
+			expectedPayload: `{"sections": []}`,
+		},
+		{
+			name: "segment",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "segment", ConfigId: "segment"},
+				Type:       snapshot.Type{ID: config.SegmentID},
+				RemoteID:   "segment-uid",
+				Payload:    `{"uid": "segment-uid", "version": 2, "externalId": "other", "name": "segment"}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreSegmentClient{}
+				return &client.ClientSet{SegmentClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "segment-uid",
+			expectedPayload: `{"name": "segment", "externalId": "` + idutils.GenerateExternalID(coordinate.Coordinate{Project: "p", Type: "segment", ConfigId: "segment"}) + `"}`,
+		},
+		{
+			name: "slo",
+			entry: snapshot.Entry{
+				Coordinate: coordinate.Coordinate{Project: "p", Type: "slo-v2", ConfigId: "slo"},
+				Type:       snapshot.Type{ID: config.ServiceLevelObjectiveID},
+				RemoteID:   "slo-id",
+				Payload:    `{"id": "slo-id", "version": "v1", "externalId": "other", "name": "slo"}`,
+			},
+			clientSet: func() (*client.ClientSet, *restoreRecorder) {
+				c := &restoreSLOClient{}
+				return &client.ClientSet{ServiceLevelObjectiveClient: c}, &c.restoreRecorder
+			},
+			expectedID:      "slo-id",
+			expectedPayload: `{"name": "slo", "externalId": "` + idutils.GenerateExternalID(coordinate.Coordinate{Project: "p", Type: "slo-v2", ConfigId: "slo"}) + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientSet, recorder := tt.clientSet()
+			tt.entry.Environment = "env"
+			tt.entry.Action = snapshot.ActionUpdate
+
+			err := deploy.Rollback(t.Context(), clientSet, []snapshot.Entry{tt.entry})
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, recorder.calls)
+			assert.Equal(t, tt.expectedID, recorder.id)
+			assert.Equal(t, tt.expectedName, recorder.name)
+			assert.JSONEq(t, tt.expectedPayload, string(recorder.payload))
+		})
+	}
+}
+
+func TestRollback_RestoresClassicSubPathConfigsWithinTheirScope(t *testing.T) {
+	configClient := &restoreConfigClient{}
+	entry := snapshot.Entry{
+		Environment: "env",
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: api.KeyUserActionsWeb, ConfigId: "action"},
+		Type:        snapshot.Type{ID: config.ClassicApiTypeID, Api: api.KeyUserActionsWeb},
+		Action:      snapshot.ActionUpdate,
+		RemoteID:    "action-id",
+		Name:        "action",
+		Scope:       "APPLICATION-1234",
+		Payload:     `{"name": "action"}`,
+	}
+
+	err := deploy.Rollback(t.Context(), &client.ClientSet{ConfigClient: configClient}, []snapshot.Entry{entry})
+	require.NoError(t, err)
+
+	assert.Equal(t, "action-id", configClient.id)
+	assert.Contains(t, configClient.api.URLPath, "APPLICATION-1234")
+}
+
+func TestRollback_FailsForInvalidRecordedPayload(t *testing.T) {
+	entry := snapshot.Entry{
+		Environment: "env",
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "bucket", ConfigId: "bucket"},
+		Type:        snapshot.Type{ID: config.BucketTypeID},
+		Action:      snapshot.ActionUpdate,
+		RemoteID:    "bucket_name",
+		Payload:     `not json`,
+	}
+	bucketClient := &restoreBucketClient{}
+
+	err := deploy.Rollback(t.Context(), &client.ClientSet{BucketClient: bucketClient}, []snapshot.Entry{entry})
+	assert.Error(t, err)
+	assert.Zero(t, bucketClient.calls)
+}
@@ -0,0 +1,145 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy
+
+import (
+	"path/filepath"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+// ConfigSelector reports whether the given configuration shall be deployed to the given environment.
+type ConfigSelector func(environment string, c config.Config) bool
+
+// AllOf returns a ConfigSelector that selects configurations selected by all given selectors. Nil selectors are
+// ignored. If no selectors are given, nil is returned, meaning that all configurations are deployed.
+func AllOf(selectors ...ConfigSelector) ConfigSelector {
+	var nonNil []ConfigSelector
+	for _, s := range selectors {
+		if s != nil {
+			nonNil = append(nonNil, s)
+		}
+	}
+
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+
+	return func(environment string, c config.Config) bool {
+		for _, s := range nonNil {
+			if !s(environment, c) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// NewCoordinateSelector returns a ConfigSelector that selects configurations whose coordinate matches any of the
+// given patterns.
+func NewCoordinateSelector(patterns []coordinate.Pattern) ConfigSelector {
+	return func(_ string, c config.Config) bool {
+		for _, p := range patterns {
+			if p.Match(c.Coordinate) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewLabelSelector returns a ConfigSelector that selects configurations, for which the given selector matches the labels
+// of the environment they are deployed to, merged with the labels of their project. Project labels take precedence.
+func NewLabelSelector(selector manifest.LabelSelector, environments manifest.EnvironmentDefinitionsByName, projects manifest.ProjectDefinitionByProjectID) ConfigSelector {
+	return func(environment string, c config.Config) bool {
+		return selector.Matches(environments[environment].Labels, projects[c.Coordinate.Project].Labels)
+	}
+}
+
+// NewResumeSelector returns a ConfigSelector that selects all configurations, which were not deployed successfully
+// according to the given records of a previous deployment report. This includes configurations that failed or were
+// skipped, as well as configurations that were never attempted, e.g. because the previous deployment stopped early.
+//
+// Records without an environment, as written by older versions, are considered to apply to all environments.
+func NewResumeSelector(records []report.Record) ConfigSelector {
+	type key struct {
+		environment string
+		coordinate  coordinate.Coordinate
+	}
+
+	succeeded := make(map[key]bool)
+	for _, r := range records {
+		if r.Type != report.TypeDeploy || r.Config == nil {
+			continue
+		}
+		succeeded[key{environment: r.Environment, coordinate: *r.Config}] = r.State == report.StateSuccess
+	}
+
+	return func(environment string, c config.Config) bool {
+		if ok, found := succeeded[key{environment: environment, coordinate: c.Coordinate}]; found {
+			return !ok
+		}
+		return !succeeded[key{coordinate: c.Coordinate}]
+	}
+}
+
+// NewChangedFilesSelector returns a ConfigSelector that selects all configurations, which are defined in, or use a
+// template or file parameter of, any of the given changed files. This includes the additional source files of
+// configurations, like shared parameter files, forEach items files, and the files of extended configurations. The
+// paths of configurations are relative to baseDir, and both baseDir and the changed files are expected to be absolute
+// paths.
+func NewChangedFilesSelector(baseDir string, changedFiles []string) ConfigSelector {
+	changed := make(map[string]struct{}, len(changedFiles))
+	for _, f := range changedFiles {
+		changed[filepath.Clean(f)] = struct{}{}
+	}
+
+	return func(_ string, c config.Config) bool {
+		for _, f := range configFiles(c) {
+			if _, found := changed[filepath.Join(baseDir, f)]; found {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// configFiles returns the paths of all files the given configuration is loaded from.
+func configFiles(c config.Config) []string {
+	var files []string
+	if c.SourceFile != "" {
+		files = append(files, c.SourceFile)
+	}
+	files = append(files, c.AdditionalSourceFiles...)
+	if t, ok := c.Template.(*template.FileBasedTemplate); ok {
+		files = append(files, t.FilePath())
+	}
+	for _, p := range c.Parameters {
+		if f, ok := p.(*file.FileParameter); ok {
+			files = append(files, f.Path)
+		}
+	}
+	return files
+}
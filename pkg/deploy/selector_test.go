@@ -0,0 +1,187 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deploy_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/file"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
+)
+
+func TestNewResumeSelector(t *testing.T) {
+	succeeded := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "succeeded"}
+	failed := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "failed"}
+	skipped := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "skipped"}
+	retried := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "retried"}
+	legacy := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "legacy"}
+	notAttempted := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "not-attempted"}
+
+	records := []report.Record{
+		{Type: report.TypeInfo, State: report.StateInfo, Message: "Monaco version"},
+		{Type: report.TypeLoad, State: report.StateSuccess, Config: &failed},
+		{Type: report.TypeDeploy, Environment: "env1", State: report.StateSuccess, Config: &succeeded},
+		{Type: report.TypeDeploy, Environment: "env2", State: report.StateError, Config: &succeeded},
+		{Type: report.TypeDeploy, Environment: "env1", State: report.StateError, Config: &failed},
+		{Type: report.TypeDeploy, Environment: "env1", State: report.StateSkipped, Config: &skipped},
+		{Type: report.TypeDeploy, Environment: "env1", State: report.StateError, Config: &retried},
+		{Type: report.TypeDeploy, Environment: "env1", State: report.StateSuccess, Config: &retried},
+		{Type: report.TypeDeploy, State: report.StateSuccess, Config: &legacy},
+	}
+
+	selector := deploy.NewResumeSelector(records)
+	selected := func(env string, c coordinate.Coordinate) bool {
+		return selector(env, config.Config{Coordinate: c, Environment: env})
+	}
+
+	assert.False(t, selected("env1", succeeded))
+	assert.True(t, selected("env2", succeeded))
+	assert.True(t, selected("env1", failed))
+	assert.True(t, selected("env1", skipped))
+	assert.False(t, selected("env1", retried))
+	assert.False(t, selected("env1", legacy))
+	assert.False(t, selected("env2", legacy))
+	assert.True(t, selected("env1", notAttempted))
+}
+
+func TestAllOf(t *testing.T) {
+	c := config.Config{Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}}
+	yes := func(string, config.Config) bool { return true }
+	no := func(string, config.Config) bool { return false }
+
+	assert.Nil(t, deploy.AllOf())
+	assert.Nil(t, deploy.AllOf(nil, nil))
+	assert.True(t, deploy.AllOf(nil, yes)("env", c))
+	assert.True(t, deploy.AllOf(yes, yes)("env", c))
+	assert.False(t, deploy.AllOf(yes, no)("env", c))
+}
+
+func TestNewCoordinateSelector(t *testing.T) {
+	p1, err := coordinate.ParsePattern("infra:builtin:alerting.profile:*")
+	assert.NoError(t, err)
+	p2, err := coordinate.ParsePattern("*:dashboard:team-a-*")
+	assert.NoError(t, err)
+
+	selector := deploy.NewCoordinateSelector([]coordinate.Pattern{p1, p2})
+	selected := func(c coordinate.Coordinate) bool { return selector("env", config.Config{Coordinate: c}) }
+
+	assert.True(t, selected(coordinate.Coordinate{Project: "infra", Type: "builtin:alerting.profile", ConfigId: "profile"}))
+	assert.True(t, selected(coordinate.Coordinate{Project: "team", Type: "dashboard", ConfigId: "team-a-overview"}))
+	assert.False(t, selected(coordinate.Coordinate{Project: "team", Type: "dashboard", ConfigId: "team-b-overview"}))
+}
+
+func TestNewLabelSelector(t *testing.T) {
+	labelSelector, err := manifest.ParseLabelSelector("tier=prod,region in (eu,us)")
+	require.NoError(t, err)
+
+	environments := manifest.EnvironmentDefinitionsByName{
+		"prod-eu":  {Name: "prod-eu", Labels: manifest.Labels{"tier": "prod", "region": "eu"}},
+		"prod-ap":  {Name: "prod-ap", Labels: manifest.Labels{"tier": "prod", "region": "ap"}},
+		"staging":  {Name: "staging", Labels: manifest.Labels{"tier": "staging", "region": "eu"}},
+		"no-label": {Name: "no-label"},
+	}
+	projects := manifest.ProjectDefinitionByProjectID{
+		"global":  {Name: "global"},
+		"us-only": {Name: "us-only", Labels: manifest.Labels{"region": "us"}},
+	}
+
+	selector := deploy.NewLabelSelector(labelSelector, environments, projects)
+	selected := func(env, project string) bool {
+		return selector(env, config.Config{Coordinate: coordinate.Coordinate{Project: project, Type: "t", ConfigId: "c"}})
+	}
+
+	assert.True(t, selected("prod-eu", "global"))
+	assert.False(t, selected("prod-ap", "global"))
+	assert.False(t, selected("staging", "global"))
+	assert.False(t, selected("no-label", "global"))
+
+	// project labels take precedence over environment labels
+	assert.True(t, selected("prod-eu", "us-only"))
+	assert.True(t, selected("prod-ap", "us-only"))
+	assert.False(t, selected("staging", "us-only"))
+}
+
+func TestNewChangedFilesSelector(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, filepath.Join("project", "dashboard.json"), []byte("{}"), 0644))
+	tmpl, err := template.NewFileTemplate(fs, filepath.Join("project", "dashboard.json"))
+	require.NoError(t, err)
+
+	baseDir := filepath.Join(string(filepath.Separator), "repo")
+	selector := deploy.NewChangedFilesSelector(baseDir, []string{
+		filepath.Join(baseDir, "project", "changed.yaml"),
+		filepath.Join(baseDir, "project", "dashboard.json"),
+		filepath.Join(baseDir, "project", "file-param.txt"),
+		filepath.Join(baseDir, "project", "_parameters.yaml"),
+	})
+
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want bool
+	}{
+		{
+			name: "config file changed",
+			cfg:  config.Config{SourceFile: filepath.Join("project", "changed.yaml")},
+			want: true,
+		},
+		{
+			name: "template changed",
+			cfg:  config.Config{SourceFile: filepath.Join("project", "other.yaml"), Template: tmpl},
+			want: true,
+		},
+		{
+			name: "file parameter changed",
+			cfg: config.Config{
+				SourceFile: filepath.Join("project", "other.yaml"),
+				Parameters: config.Parameters{"content": &file.FileParameter{Fs: fs, Path: filepath.Join("project", "file-param.txt")}},
+			},
+			want: true,
+		},
+		{
+			name: "additional source file changed",
+			cfg: config.Config{
+				SourceFile:            filepath.Join("project", "other.yaml"),
+				Template:              template.NewInMemoryTemplate("id", "{}"),
+				AdditionalSourceFiles: []string{filepath.Join("project", "_parameters.yaml")},
+			},
+			want: true,
+		},
+		{
+			name: "nothing changed",
+			cfg:  config.Config{SourceFile: filepath.Join("project", "other.yaml"), Template: template.NewInMemoryTemplate("id", "{}")},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selector("env", tt.cfg))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+// recorder is a resource.Deployable that records the state of the remote object in a Store before deploying a config.
+type recorder struct {
+	deployable resource.Deployable
+	plannable  resource.Plannable
+	store      *Store
+}
+
+// NewDeployables returns resource.Deployables, which record the state of each remote object in the given Store before
+// deploying a config. If the remote object exists, its payload is recorded before it is updated. If it does not, the
+// ID of the newly created object is recorded after it was created.
+func NewDeployables(deployables resource.Deployables, plannables resource.Plannables, store *Store) resource.Deployables {
+	recordingDeployables := make(resource.Deployables, len(deployables))
+	for t, deployable := range deployables {
+		recordingDeployables[t] = recorder{deployable: deployable, plannable: plannables[t], store: store}
+	}
+	return recordingDeployables
+}
+
+func (r recorder) Deploy(ctx context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	t, err := NewType(c.Type)
+	if err != nil {
+		return entities.ResolvedEntity{}, fmt.Errorf("failed to snapshot remote object: %w", err)
+	}
+
+	entry := Entry{
+		Environment: c.Environment,
+		Coordinate:  c.Coordinate,
+		Type:        t,
+		Action:      ActionCreate,
+	}
+	entry.Name, _ = properties[config.NameParameter].(string)
+	entry.Scope, _ = properties[config.ScopeParameter].(string)
+
+	remote, found, err := r.plannable.Lookup(ctx, properties, renderedConfig, c)
+	if err != nil {
+		return entities.ResolvedEntity{}, fmt.Errorf("failed to look up remote object to snapshot: %w", err)
+	}
+
+	if found {
+		entry.Action = ActionUpdate
+		entry.RemoteID = remoteID(remote)
+		entry.Payload = string(remote.Payload)
+		if err := r.store.Write(entry); err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+	}
+
+	resolved, err := r.deployable.Deploy(ctx, properties, renderedConfig, c)
+	if err != nil {
+		return entities.ResolvedEntity{}, err
+	}
+
+	if !found {
+		entry.RemoteID, err = r.createdRemoteID(ctx, properties, renderedConfig, c, resolved)
+		if err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+		if err := r.store.Write(entry); err != nil {
+			return entities.ResolvedEntity{}, err
+		}
+	}
+	return resolved, nil
+}
+
+// createdRemoteID returns the ID of the remote object created by deploying the given config. The ID property of
+// settings objects may be converted to the ID of the entity they represent, so their object ID is looked up instead.
+func (r recorder) createdRemoteID(ctx context.Context, properties parameter.Properties, renderedConfig string, c *config.Config, resolved entities.ResolvedEntity) (string, error) {
+	if c.Type.ID() == config.SettingsTypeID {
+		remote, found, err := r.plannable.Lookup(ctx, properties, renderedConfig, c)
+		if err != nil {
+			return "", fmt.Errorf("failed to look up created remote object to snapshot: %w", err)
+		}
+		if found {
+			return remoteID(remote), nil
+		}
+	}
+
+	id, _ := resolved.Properties[config.IdParameter].(string)
+	return id, nil
+}
+
+// remoteID returns the ID the given remote object is identified by in its API.
+func remoteID(remote resource.RemoteObject) string {
+	if remote.ObjectID != "" {
+		return remote.ObjectID
+	}
+	return remote.ID
+}
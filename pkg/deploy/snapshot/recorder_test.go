@@ -0,0 +1,79 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package snapshot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type fakeRemote struct {
+	objects map[coordinate.Coordinate]resource.RemoteObject
+}
+
+func (f *fakeRemote) Lookup(_ context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	o, found := f.objects[c.Coordinate]
+	return o, found, nil
+}
+
+func (f *fakeRemote) Deploy(_ context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error) {
+	f.objects[c.Coordinate] = resource.RemoteObject{ID: "id-" + c.Coordinate.ConfigId, ObjectID: "object-id-" + c.Coordinate.ConfigId, Payload: []byte(renderedConfig)}
+	properties[config.IdParameter] = "id-" + c.Coordinate.ConfigId
+	return entities.ResolvedEntity{Coordinate: c.Coordinate, Properties: properties}, nil
+}
+
+func TestRecorder(t *testing.T) {
+	existing := coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "existing"}
+	created := coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "created"}
+	remote := &fakeRemote{objects: map[coordinate.Coordinate]resource.RemoteObject{
+		existing: {ID: "existing-entity-id", ObjectID: "existing-id", Payload: []byte(`{"previous": true}`)},
+	}}
+
+	store := snapshot.NewStore(afero.NewMemMapFs(), "snapshot")
+	d := snapshot.NewDeployables(
+		resource.Deployables{config.SettingsTypeID: remote},
+		resource.Plannables{config.SettingsTypeID: remote},
+		store)[config.SettingsTypeID]
+
+	settingsType := config.SettingsType{SchemaId: "builtin:test"}
+	for _, c := range []coordinate.Coordinate{existing, created} {
+		_, err := d.Deploy(t.Context(), parameter.Properties{config.NameParameter: "name", config.ScopeParameter: "environment"}, `{"new": true}`,
+			&config.Config{Coordinate: c, Environment: "env", Type: settingsType})
+		require.NoError(t, err)
+	}
+
+	entries, err := store.Load("env")
+	require.NoError(t, err)
+
+	st := snapshot.Type{ID: config.SettingsTypeID, SchemaId: "builtin:test"}
+	assert.ElementsMatch(t, []snapshot.Entry{
+		{Environment: "env", Coordinate: existing, Type: st, Action: snapshot.ActionUpdate, RemoteID: "existing-id", Name: "name", Scope: "environment", Payload: `{"previous": true}`},
+		{Environment: "env", Coordinate: created, Type: st, Action: snapshot.ActionCreate, RemoteID: "object-id-created", Name: "name", Scope: "environment"},
+	}, entries, "the object IDs of settings objects are recorded, rather than their converted IDs")
+}
@@ -0,0 +1,193 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot provides the means to record the state of remote objects before a deployment changes them, so
+// that the deployment can be rolled back later on.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// Action describes what a deployment did to a remote object.
+type Action string
+
+const (
+	// ActionCreate means that the deployment created the remote object. A rollback deletes it.
+	ActionCreate Action = "create"
+	// ActionUpdate means that the deployment updated the existing remote object. A rollback restores its previous payload.
+	ActionUpdate Action = "update"
+)
+
+// Type is the serializable form of a config.Type.
+type Type struct {
+	ID            config.TypeID `json:"id"`
+	Api           string        `json:"api,omitempty"`
+	SchemaId      string        `json:"schemaId,omitempty"`
+	SchemaVersion string        `json:"schemaVersion,omitempty"`
+	Resource      string        `json:"resource,omitempty"`
+	Kind          string        `json:"kind,omitempty"`
+	Private       bool          `json:"private,omitempty"`
+}
+
+// NewType returns the serializable form of the given config.Type.
+func NewType(t config.Type) (Type, error) {
+	switch t := t.(type) {
+	case config.ClassicApiType:
+		return Type{ID: t.ID(), Api: t.Api}, nil
+	case config.SettingsType:
+		return Type{ID: t.ID(), SchemaId: t.SchemaId, SchemaVersion: t.SchemaVersion}, nil
+	case config.AutomationType:
+		return Type{ID: t.ID(), Resource: string(t.Resource)}, nil
+	case config.DocumentType:
+		return Type{ID: t.ID(), Kind: string(t.Kind), Private: t.Private}, nil
+	case config.OpenPipelineType:
+		return Type{ID: t.ID(), Kind: t.Kind}, nil
+	case config.BucketType, config.Segment, config.ServiceLevelObjective:
+		return Type{ID: t.ID()}, nil
+	default:
+		return Type{}, fmt.Errorf("unsupported config type %q", t.ID())
+	}
+}
+
+// ConfigType returns the config.Type the Type was created from.
+func (t Type) ConfigType() (config.Type, error) {
+	switch t.ID {
+	case config.ClassicApiTypeID:
+		return config.ClassicApiType{Api: t.Api}, nil
+	case config.SettingsTypeID:
+		return config.SettingsType{SchemaId: t.SchemaId, SchemaVersion: t.SchemaVersion}, nil
+	case config.AutomationTypeID:
+		return config.AutomationType{Resource: config.AutomationResource(t.Resource)}, nil
+	case config.DocumentTypeID:
+		return config.DocumentType{Kind: config.DocumentKind(t.Kind), Private: t.Private}, nil
+	case config.OpenPipelineTypeID:
+		return config.OpenPipelineType{Kind: t.Kind}, nil
+	case config.BucketTypeID:
+		return config.BucketType{}, nil
+	case config.SegmentID:
+		return config.Segment{}, nil
+	case config.ServiceLevelObjectiveID:
+		return config.ServiceLevelObjective{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported config type %q", t.ID)
+	}
+}
+
+// Entry is the recorded state of a single remote object before a deployment changed it.
+type Entry struct {
+	// Environment is the name of the environment the config was deployed to.
+	Environment string `json:"environment"`
+	// Coordinate is the coordinate of the config.
+	Coordinate coordinate.Coordinate `json:"coordinate"`
+	// Type is the type of the config.
+	Type Type `json:"type"`
+	// Action is what the deployment did to the remote object.
+	Action Action `json:"action"`
+	// RemoteID is the ID of the remote object. For settings objects, it is their object ID.
+	RemoteID string `json:"remoteId"`
+	// Name is the name of the config, if it has one.
+	Name string `json:"name,omitempty"`
+	// Scope is the scope of the config, if it has one.
+	Scope string `json:"scope,omitempty"`
+	// Payload is the payload of the remote object before it was updated. It is empty for ActionCreate.
+	Payload string `json:"payload,omitempty"`
+}
+
+// Store persists snapshot entries in a directory, using one file per environment and coordinate.
+type Store struct {
+	fs  afero.Fs
+	dir string
+}
+
+// NewStore returns a Store that persists entries in the given directory.
+func NewStore(fs afero.Fs, dir string) *Store {
+	return &Store{fs: fs, dir: dir}
+}
+
+func (s *Store) environmentDir(environment string) string {
+	return filepath.Join(s.dir, environment)
+}
+
+// Write persists the given Entry. If an entry for the same environment and coordinate already exists, it is kept and
+// the given one is discarded, so that a snapshot directory used by several deployments always refers to the state
+// before the first of them.
+func (s *Store) Write(e Entry) error {
+	dir := s.environmentDir(e.Environment)
+	if err := s.fs.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("failed to create snapshot directory %q: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, idutils.GenerateUUIDFromCoordinate(e.Coordinate)+".json")
+	if exists, err := afero.Exists(s.fs, path); err != nil {
+		return fmt.Errorf("failed to check snapshot file %q: %w", path, err)
+	} else if exists {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot of %q: %w", e.Coordinate, err)
+	}
+
+	if err := afero.WriteFile(s.fs, path, b, 0664); err != nil {
+		return fmt.Errorf("failed to write snapshot file %q: %w", path, err)
+	}
+	return nil
+}
+
+// Load returns all entries persisted for the given environment. If no entries exist, an empty slice is returned.
+func (s *Store) Load(environment string) ([]Entry, error) {
+	dir := s.environmentDir(environment)
+	files, err := afero.ReadDir(s.fs, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Entry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot directory %q: %w", dir, err)
+	}
+
+	entries := make([]Entry, 0, len(files))
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, f.Name())
+		b, err := afero.ReadFile(s.fs, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot file %q: %w", path, err)
+		}
+
+		var e Entry
+		if err := json.Unmarshal(b, &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal snapshot file %q: %w", path, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package snapshot_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/snapshot"
+)
+
+func TestType_RoundTrip(t *testing.T) {
+	types := []config.Type{
+		config.ClassicApiType{Api: "alerting-profile"},
+		config.SettingsType{SchemaId: "builtin:alerting.profile", SchemaVersion: "1.2.3"},
+		config.AutomationType{Resource: config.Workflow},
+		config.DocumentType{Kind: config.NotebookKind, Private: true},
+		config.OpenPipelineType{Kind: "logs"},
+		config.BucketType{},
+		config.Segment{},
+		config.ServiceLevelObjective{},
+	}
+
+	for _, ct := range types {
+		t.Run(string(ct.ID()), func(t *testing.T) {
+			st, err := snapshot.NewType(ct)
+			require.NoError(t, err)
+
+			got, err := st.ConfigType()
+			require.NoError(t, err)
+			assert.Equal(t, ct, got)
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	store := snapshot.NewStore(fs, "snapshot")
+
+	first := snapshot.Entry{
+		Environment: "env",
+		Coordinate:  coordinate.Coordinate{Project: "p", Type: "builtin:test", ConfigId: "1"},
+		Type:        snapshot.Type{ID: config.SettingsTypeID, SchemaId: "builtin:test"},
+		Action:      snapshot.ActionUpdate,
+		RemoteID:    "id-1",
+		Scope:       "environment",
+		Payload:     `{"a": 1}`,
+	}
+	second := first
+	second.Payload = `{"a": 2}`
+	other := first
+	other.Coordinate.ConfigId = "2"
+	other.Action = snapshot.ActionCreate
+	other.Payload = ""
+
+	require.NoError(t, store.Write(first))
+	require.NoError(t, store.Write(second))
+	require.NoError(t, store.Write(other))
+
+	entries, err := store.Load("env")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []snapshot.Entry{first, other}, entries, "the first snapshot of an object must be kept")
+
+	entries, err = store.Load("unknown")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
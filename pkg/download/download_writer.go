@@ -33,12 +33,14 @@ import (
 )
 
 type WriterContext struct {
-	EnvironmentUrl  manifest.URLDefinition
-	ProjectToWrite  project.Project
-	Auth            manifest.Auth
-	OutputFolder    string
-	ForceOverwrite  bool
-	timestampString string
+	EnvironmentUrl manifest.URLDefinition
+	ProjectToWrite project.Project
+	Auth           manifest.Auth
+	OutputFolder   string
+	ForceOverwrite bool
+	// ExtractSharedParameters writes parameters shared by all downloaded configs to the shared parameters file of the project
+	ExtractSharedParameters bool
+	timestampString         string
 }
 
 func (c WriterContext) GetOutputFolderFilePath() string {
@@ -96,6 +98,8 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 		OutputDir:       outputFolder,
 		ManifestName:    manifestFileName,
 		ParametersSerde: config.DefaultParameterParsers,
+
+		ExtractSharedParameters: writerContext.ExtractSharedParameters,
 	}, manifest, []project.Project{writerContext.ProjectToWrite})
 
 	if len(errs) > 0 {
@@ -147,6 +147,81 @@ func (graphs ConfigGraphPerEnvironment) GetIndependentlySortedConfigs(environmen
 	return sortedComponents, nil
 }
 
+// SelectWithDependencies removes all configs from the dependency graph of the given environment, which are neither
+// selected by the given function nor a direct or transitive dependency of a selected config.
+func (graphs ConfigGraphPerEnvironment) SelectWithDependencies(environment string, selected func(c config.Config) bool) error {
+	g, err := graphs.getGraphForEnvironment(environment)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[int64]struct{})
+	var keepWithDependencies func(n graph.Node)
+	keepWithDependencies = func(n graph.Node) {
+		if _, found := keep[n.ID()]; found {
+			return
+		}
+		keep[n.ID()] = struct{}{}
+
+		dependencies := g.To(n.ID())
+		for dependencies.Next() {
+			keepWithDependencies(dependencies.Node())
+		}
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	for _, n := range nodes {
+		if selected(*n.(ConfigNode).Config) {
+			keepWithDependencies(n)
+		}
+	}
+
+	for _, n := range nodes {
+		if _, found := keep[n.ID()]; !found {
+			g.RemoveNode(n.ID())
+		}
+	}
+
+	log.Debug("Selected %d of %d configs for environment %s", len(keep), len(nodes), environment)
+	return nil
+}
+
+// SelectWithDependents removes all configs from the dependency graph of the given environment, which are neither
+// selected by the given function, nor a direct or transitive dependent of a selected config, nor a dependency of any of
+// those. This allows to deploy changed configs along with all configs that reference them.
+func (graphs ConfigGraphPerEnvironment) SelectWithDependents(environment string, selected func(c config.Config) bool) error {
+	g, err := graphs.getGraphForEnvironment(environment)
+	if err != nil {
+		return err
+	}
+
+	withDependents := make(map[coordinate.Coordinate]struct{})
+	var addWithDependents func(n graph.Node)
+	addWithDependents = func(n graph.Node) {
+		c := n.(ConfigNode).Config.Coordinate
+		if _, found := withDependents[c]; found {
+			return
+		}
+		withDependents[c] = struct{}{}
+
+		dependents := g.From(n.ID())
+		for dependents.Next() {
+			addWithDependents(dependents.Node())
+		}
+	}
+
+	for _, n := range graph.NodesOf(g.Nodes()) {
+		if selected(*n.(ConfigNode).Config) {
+			addWithDependents(n)
+		}
+	}
+
+	return graphs.SelectWithDependencies(environment, func(c config.Config) bool {
+		_, found := withDependents[c.Coordinate]
+		return found
+	})
+}
+
 func (graphs ConfigGraphPerEnvironment) getGraphForEnvironment(environment string) (*simple.DirectedGraph, error) {
 	g, ok := graphs[environment]
 	if !ok {
@@ -535,3 +535,97 @@ func TestRoots(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigGraphPerEnvironment_SelectWithDependencies(t *testing.T) {
+	environmentName := "dev"
+	ref := func(c coordinate.Coordinate) parameter.Parameter {
+		return &parameter.DummyParameter{References: []parameter.ParameterReference{{Config: c, Property: "id"}}}
+	}
+
+	a := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}
+	b := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}
+	d := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "d"}
+	e := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "e"}
+
+	// c depends on b, which depends on a. d depends on c, e is independent.
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				environmentName: {
+					"t": []config.Config{
+						{Coordinate: a, Environment: environmentName},
+						{Coordinate: b, Environment: environmentName, Parameters: config.Parameters{"a": ref(a)}},
+						{Coordinate: c, Environment: environmentName, Parameters: config.Parameters{"b": ref(b)}},
+						{Coordinate: d, Environment: environmentName, Parameters: config.Parameters{"c": ref(c)}},
+						{Coordinate: e, Environment: environmentName},
+					},
+				},
+			},
+		},
+	}
+
+	g := graph.New(projects, []string{environmentName})
+	err := g.SelectWithDependencies(environmentName, func(cfg config.Config) bool { return cfg.Coordinate == c })
+	assert.NoError(t, err)
+
+	sorted, err := g.SortConfigs(environmentName)
+	assert.NoError(t, err)
+
+	var got []coordinate.Coordinate
+	for _, cfg := range sorted {
+		got = append(got, cfg.Coordinate)
+	}
+	assert.Equal(t, []coordinate.Coordinate{a, b, c}, got)
+
+	assert.Error(t, g.SelectWithDependencies("unknown", func(config.Config) bool { return true }))
+}
+
+func TestConfigGraphPerEnvironment_SelectWithDependents(t *testing.T) {
+	environmentName := "dev"
+	ref := func(c coordinate.Coordinate) parameter.Parameter {
+		return &parameter.DummyParameter{References: []parameter.ParameterReference{{Config: c, Property: "id"}}}
+	}
+
+	a := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "a"}
+	b := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "b"}
+	c := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}
+	d := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "d"}
+	e := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "e"}
+	f := coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "f"}
+
+	// c depends on b, which depends on a. d depends on c and e, f is independent.
+	projects := []project.Project{
+		{
+			Id: "p",
+			Configs: project.ConfigsPerTypePerEnvironments{
+				environmentName: {
+					"t": []config.Config{
+						{Coordinate: a, Environment: environmentName},
+						{Coordinate: b, Environment: environmentName, Parameters: config.Parameters{"a": ref(a)}},
+						{Coordinate: c, Environment: environmentName, Parameters: config.Parameters{"b": ref(b)}},
+						{Coordinate: d, Environment: environmentName, Parameters: config.Parameters{"c": ref(c), "e": ref(e)}},
+						{Coordinate: e, Environment: environmentName},
+						{Coordinate: f, Environment: environmentName},
+					},
+				},
+			},
+		},
+	}
+
+	g := graph.New(projects, []string{environmentName})
+	err := g.SelectWithDependents(environmentName, func(cfg config.Config) bool { return cfg.Coordinate == b })
+	assert.NoError(t, err)
+
+	sorted, err := g.SortConfigs(environmentName)
+	assert.NoError(t, err)
+
+	var got []coordinate.Coordinate
+	for _, cfg := range sorted {
+		got = append(got, cfg.Coordinate)
+	}
+	assert.ElementsMatch(t, []coordinate.Coordinate{a, b, c, d, e}, got)
+
+	assert.Error(t, g.SelectWithDependents("unknown", func(config.Config) bool { return true }))
+}
@@ -26,9 +26,15 @@ const SimpleProjectType = "simple"
 const GroupProjectType = "grouping"
 
 type Project struct {
-	Name string `yaml:"name" json:"name" jsonschema:"required,description=The name of the project - if 'path' is not set the name will be used as path, otherwise this can be freely defined."`
-	Type string `yaml:"type,omitempty" json:"type" jsonschema:"enum=simple,enum=grouping,description=The type of project - either a 'simple' project folder containing configs, or a 'grouping' of projects in sub-folders."`
-	Path string `yaml:"path,omitempty" json:"path" jsonschema:"description=The file path to the project folder, relative to the manifest's location."`
+	Name  string `yaml:"name" json:"name" jsonschema:"required,description=The name of the project - if 'path' is not set the name will be used as path, otherwise this can be freely defined."`
+	Type  string `yaml:"type,omitempty" json:"type" jsonschema:"enum=simple,enum=grouping,description=The type of project - either a 'simple' project folder containing configs, or a 'grouping' of projects in sub-folders."`
+	Path  string `yaml:"path,omitempty" json:"path" jsonschema:"description=The file path to the project folder, relative to the manifest's location."`
+	Hooks *Hooks `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Commands to run before and after the configurations of this project are deployed to an environment."`
+
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty" jsonschema:"description=Arbitrary labels of the project, which can be used to select it using '--selector'. Labels of a grouping project apply to all its sub-projects."`
+
+	EnvironmentGroups []string `yaml:"environmentGroups,omitempty" json:"environmentGroups,omitempty" jsonschema:"description=The environment groups this project applies to. If neither 'environmentGroups' nor 'environments' is set, the project applies to all environments."`
+	Environments      []string `yaml:"environments,omitempty" json:"environments,omitempty" jsonschema:"description=The environments this project applies to, in addition to the environments of 'environmentGroups'. If neither 'environmentGroups' nor 'environments' is set, the project applies to all environments."`
 }
 
 type Type string
@@ -36,6 +42,8 @@ type Type string
 const (
 	TypeEnvironment Type = "environment"
 	TypeValue       Type = "value"
+	TypeFile        Type = "file"
+	TypeCommand     Type = "command"
 )
 
 // TypedValue represents a value with a Type - currently these are variables that can be either:
@@ -66,17 +74,21 @@ func (c *TypedValue) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-// AuthSecret represents a user-defined client id or client secret. It has a [Type] which is [TypeEnvironment] (default).
-// Secrets must never be provided as plain text, but always loaded from somewhere else. Currently, loading is only allowed from environment variables.
-//
-// [Name] contains the environment-variable to resolve the authSecret.
+// AuthSecret represents a user-defined client id or client secret. It has a [Type] which is [TypeEnvironment] (default),
+// [TypeFile] or [TypeCommand]. Secrets must never be provided as plain text, but always loaded from somewhere else:
+//   - [TypeEnvironment]: [Name] contains the environment-variable to resolve the authSecret.
+//   - [TypeFile]: [Path] contains the file to read the authSecret from, e.g. a mounted secret.
+//   - [TypeCommand]: [Command] is run and its output is the authSecret, e.g. a credential helper.
 //
 // This struct is meant to be reused for fields that require the same behavior.
 type AuthSecret struct {
-	// Type exists for future compatibility - AuthSecrets are always read from 'environment' variables
-	Type Type `yaml:"type" json:"type,omitempty"`
-	//Name of the environment variable to read the secret from.
-	Name string `yaml:"name" json:"name" jsonschema:"required"`
+	Type Type `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=environment,enum=file,enum=command,description=Where the secret is read from - an 'environment' variable (default), a 'file', or the output of a 'command'."`
+	// Name of the environment variable to read the secret from.
+	Name string `yaml:"name,omitempty" json:"name,omitempty" jsonschema:"description=The name of the environment variable to read the secret from. Required for type 'environment'."`
+	// Path of the file to read the secret from.
+	Path string `yaml:"path,omitempty" json:"path,omitempty" jsonschema:"description=The path of the file to read the secret from, relative to the location of the manifest or fragment defining it. Required for type 'file'."`
+	// Command printing the secret to stdout.
+	Command []string `yaml:"command,omitempty" json:"command,omitempty" jsonschema:"description=The command and its arguments printing the secret to stdout. It is run in the manifest's directory. Required for type 'command'."`
 }
 
 // OAuth defines the required information to request oAuth bearer tokens for authenticated API calls
@@ -105,6 +117,33 @@ type Environment struct {
 	URL  TypedValue `yaml:"url" json:"url" jsonschema:"required,oneof_type=string;object,description=The URL of the environment."`
 
 	Auth Auth `yaml:"auth,omitempty" json:"auth" jsonschema:"required,description=This defines all information required for authenticated access to the environment's API."`
+
+	Hooks *Hooks `yaml:"hooks,omitempty" json:"hooks,omitempty" jsonschema:"description=Commands to run before and after deploying to this environment."`
+
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty" jsonschema:"description=Arbitrary labels of the environment, which can be used to select it using '--selector'."`
+
+	Connection *Connection `yaml:"connection,omitempty" json:"connection,omitempty" jsonschema:"description=Settings of the HTTP connections to this environment. If not set, the process-wide defaults are used."`
+}
+
+// Connection defines the settings of HTTP connections to an environment.
+type Connection struct {
+	Proxy                 string            `yaml:"proxy,omitempty" json:"proxy,omitempty" jsonschema:"description=The URL of the proxy requests to this environment are sent through, e.g. 'http://proxy.example.com:3128'. If not set, the HTTPS_PROXY and NO_PROXY environment variables are used."`
+	CAFile                string            `yaml:"caFile,omitempty" json:"caFile,omitempty" jsonschema:"description=The path of a PEM encoded CA bundle, relative to the manifest's location. Its certificates are trusted in addition to the system's ones."`
+	Insecure              bool              `yaml:"insecure,omitempty" json:"insecure,omitempty" jsonschema:"description=Disables the verification of TLS certificates. Only use this for testing."`
+	Timeout               string            `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"description=The maximum duration of a single request, e.g. '30s' or '2m'. If not set, requests do not time out."`
+	MaxConcurrentRequests int               `yaml:"maxConcurrentRequests,omitempty" json:"maxConcurrentRequests,omitempty" jsonschema:"minimum=1,description=The maximum number of concurrent requests to this environment. If not set, the MONACO_CONCURRENT_REQUESTS environment variable is used."`
+	Headers               map[string]string `yaml:"headers,omitempty" json:"headers,omitempty" jsonschema:"description=Additional HTTP headers sent with every request to this environment. They take precedence over headers of the MONACO_ADDITIONAL_HTTP_HEADERS environment variable."`
+}
+
+// Hook is a local command run before or after a deployment.
+type Hook struct {
+	Command []string `yaml:"command" json:"command" jsonschema:"required,minItems=1,description=The command to run, followed by its arguments. It is run in the manifest's directory and not interpreted by a shell."`
+}
+
+// Hooks defines the commands run before and after a deployment to an environment.
+type Hooks struct {
+	PreDeploy  []Hook `yaml:"preDeploy,omitempty" json:"preDeploy,omitempty" jsonschema:"description=Commands to run before deploying to an environment. If any of them fails, the environment is not deployed to."`
+	PostDeploy []Hook `yaml:"postDeploy,omitempty" json:"postDeploy,omitempty" jsonschema:"description=Commands to run after deploying to an environment, regardless of whether the deployment succeeded."`
 }
 
 // Group defines a group of Environment
@@ -121,6 +160,21 @@ type Manifest struct {
 	EnvironmentGroups []Group `yaml:"environmentGroups" json:"environmentGroups" jsonschema:"minItems=1,description=A list of environment groups that configs in the defined 'projects' will be deployed to. Required when deploying environment configurations."`
 	// Accounts is a list of accounts that account resources in Projects will be deployed to
 	Accounts []Account `yaml:"accounts,omitempty" json:"accounts" jsonschema:"minItems=1,description=A list of of accounts that account resources defined in 'projects' will be deployed to. Required when deploying account resources."`
+	// Include is a list of manifest fragments merged into this manifest
+	Include []string `yaml:"include,omitempty" json:"include,omitempty" jsonschema:"description=A list of manifest fragment files merged into this manifest, relative to the manifest's location. Glob patterns like 'teams/*.yaml' are supported."`
+}
+
+// ManifestFragment is a part of a manifest, included by a Manifest or another ManifestFragment. Names of projects,
+// environment groups, environments and accounts must be unique across the manifest and all fragments.
+type ManifestFragment struct {
+	// Projects is a list of projects, with paths relative to the fragment's location
+	Projects []Project `yaml:"projects,omitempty" json:"projects,omitempty" jsonschema:"description=A list of projects, with paths relative to the fragment's location."`
+	// EnvironmentGroups is a list of environment groups
+	EnvironmentGroups []Group `yaml:"environmentGroups,omitempty" json:"environmentGroups,omitempty" jsonschema:"description=A list of environment groups."`
+	// Accounts is a list of accounts
+	Accounts []Account `yaml:"accounts,omitempty" json:"accounts,omitempty" jsonschema:"description=A list of accounts."`
+	// Include is a list of further manifest fragments
+	Include []string `yaml:"include,omitempty" json:"include,omitempty" jsonschema:"description=A list of further manifest fragment files, relative to the fragment's location."`
 }
 
 type Account struct {
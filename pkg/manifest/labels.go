@@ -0,0 +1,250 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	labelKeyPattern   = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_./-]*[a-zA-Z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9])?)?$`)
+	setRequirement    = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+)
+
+// Labels are arbitrary key-value pairs attached to environments and projects, which can be used to select them by a
+// [LabelSelector].
+type Labels map[string]string
+
+// ValidateLabels returns an error if any key or value of the given labels is invalid. Keys must consist of
+// alphanumeric characters, '-', '_', '.' and '/', values of alphanumeric characters, '-', '_' and '.'. Both must start
+// and end with an alphanumeric character, values may be empty.
+func ValidateLabels(labels Labels) error {
+	for k, v := range labels {
+		if err := validateLabelKey(k); err != nil {
+			return err
+		}
+		if err := validateLabelValue(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateLabelKey(key string) error {
+	if !labelKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid label key %q", key)
+	}
+	return nil
+}
+
+func validateLabelValue(key, value string) error {
+	if !labelValuePattern.MatchString(value) {
+		return fmt.Errorf("invalid value %q of label %q", value, key)
+	}
+	return nil
+}
+
+type labelOperator string
+
+const (
+	opExists       labelOperator = "exists"
+	opDoesNotExist labelOperator = "!"
+	opEquals       labelOperator = "="
+	opNotEquals    labelOperator = "!="
+	opIn           labelOperator = "in"
+	opNotIn        labelOperator = "notin"
+)
+
+type labelRequirement struct {
+	key      string
+	operator labelOperator
+	values   []string
+}
+
+func (r labelRequirement) matches(labels Labels) bool {
+	value, found := labels[r.key]
+	switch r.operator {
+	case opExists:
+		return found
+	case opDoesNotExist:
+		return !found
+	case opEquals, opIn:
+		return found && slices.Contains(r.values, value)
+	default: // opNotEquals, opNotIn
+		return !found || !slices.Contains(r.values, value)
+	}
+}
+
+// LabelSelector selects environments and projects by their labels. The zero value selects everything.
+type LabelSelector struct {
+	expression   string
+	requirements []labelRequirement
+}
+
+// ParseLabelSelector parses a comma separated list of requirements, all of which must be met by selected labels.
+// Supported requirements are
+//
+//	key                  the label is set
+//	!key                 the label is not set
+//	key=value            the label has the given value ('==' may be used as well)
+//	key!=value           the label is not set, or has a different value
+//	key in (a,b)         the label has one of the given values
+//	key notin (a,b)      the label is not set, or has none of the given values
+//
+// e.g. 'tier=prod,region in (eu,us)'. An empty string results in a selector selecting everything.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	selector := LabelSelector{expression: strings.TrimSpace(s)}
+	if selector.expression == "" {
+		return selector, nil
+	}
+
+	terms, err := splitRequirements(selector.expression)
+	if err != nil {
+		return LabelSelector{}, fmt.Errorf("invalid selector %q: %w", s, err)
+	}
+	for _, term := range terms {
+		r, err := parseRequirement(term)
+		if err != nil {
+			return LabelSelector{}, fmt.Errorf("invalid selector %q: %w", s, err)
+		}
+		selector.requirements = append(selector.requirements, r)
+	}
+	return selector, nil
+}
+
+// splitRequirements splits the selector at all commas, which are not part of a set of values in parentheses.
+func splitRequirements(s string) ([]string, error) {
+	var terms []string
+	depth, start := 0, 0
+	for i, c := range s {
+		switch c {
+		case '(':
+			depth++
+			if depth > 1 {
+				return nil, fmt.Errorf("nested '(' at position %d", i)
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unexpected ')' at position %d", i)
+			}
+		case ',':
+			if depth == 0 {
+				terms = append(terms, strings.TrimSpace(s[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("missing ')'")
+	}
+	return append(terms, strings.TrimSpace(s[start:])), nil
+}
+
+func parseRequirement(term string) (labelRequirement, error) {
+	if term == "" {
+		return labelRequirement{}, fmt.Errorf("empty requirement")
+	}
+
+	if m := setRequirement.FindStringSubmatch(term); m != nil {
+		var values []string
+		for _, v := range strings.Split(m[3], ",") {
+			values = append(values, strings.TrimSpace(v))
+		}
+		return newRequirement(m[1], labelOperator(m[2]), values)
+	}
+
+	if key, found := strings.CutPrefix(term, "!"); found {
+		return newRequirement(strings.TrimSpace(key), opDoesNotExist, nil)
+	}
+
+	for _, op := range []string{"!=", "==", "="} {
+		if key, value, found := strings.Cut(term, op); found {
+			operator := labelOperator(op)
+			if op == "==" {
+				operator = opEquals
+			}
+			return newRequirement(strings.TrimSpace(key), operator, []string{strings.TrimSpace(value)})
+		}
+	}
+
+	return newRequirement(term, opExists, nil)
+}
+
+func newRequirement(key string, operator labelOperator, values []string) (labelRequirement, error) {
+	if err := validateLabelKey(key); err != nil {
+		return labelRequirement{}, err
+	}
+	for _, v := range values {
+		if err := validateLabelValue(key, v); err != nil {
+			return labelRequirement{}, err
+		}
+	}
+	return labelRequirement{key: key, operator: operator, values: values}, nil
+}
+
+// IsEmpty returns whether the selector selects everything.
+func (s LabelSelector) IsEmpty() bool {
+	return len(s.requirements) == 0
+}
+
+// String returns the selector in the form it is parsed from.
+func (s LabelSelector) String() string {
+	return s.expression
+}
+
+// Matches reports whether the labels meet all requirements of the selector. If multiple label sets are given, they are
+// merged, with labels of later sets taking precedence.
+func (s LabelSelector) Matches(labels ...Labels) bool {
+	l := mergeLabels(labels...)
+	for _, r := range s.requirements {
+		if !r.matches(l) {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeLabels(labels ...Labels) Labels {
+	merged := Labels{}
+	for _, l := range labels {
+		for k, v := range l {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// SelectedProjects returns the names of all projects whose labels, merged with the labels of any of the selected
+// environments the project applies to, match the selector. Project labels take precedence over environment labels.
+func (s LabelSelector) SelectedProjects(m Manifest) []string {
+	var result []string
+	for name, p := range m.Projects {
+		for _, env := range m.Environments.SelectedEnvironments {
+			if p.AppliesTo(env.Name, env.Group) && s.Matches(env.Labels, p.Labels) {
+				result = append(result, name)
+				break
+			}
+		}
+	}
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,122 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func TestLabelSelector_Matches(t *testing.T) {
+	labels := manifest.Labels{"tier": "prod", "region": "eu", "team": "a"}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{"", true},
+		{"tier", true},
+		{"!tier", false},
+		{"owner", false},
+		{"!owner", true},
+		{"tier=prod", true},
+		{"tier==prod", true},
+		{"tier = staging", false},
+		{"tier!=staging", true},
+		{"owner!=someone", true},
+		{"region in (eu,us)", true},
+		{"region in (us, ap)", false},
+		{"owner in (someone)", false},
+		{"region notin (us,ap)", true},
+		{"region notin (eu)", false},
+		{"owner notin (someone)", true},
+		{"tier=prod,region in (eu,us)", true},
+		{"tier=prod, region in (eu,us), !owner", true},
+		{"tier=prod,region in (us,ap)", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			s, err := manifest.ParseLabelSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Matches(labels))
+		})
+	}
+}
+
+func TestLabelSelector_MatchesMergedLabels(t *testing.T) {
+	s, err := manifest.ParseLabelSelector("tier=prod,region=us")
+	require.NoError(t, err)
+
+	env := manifest.Labels{"tier": "prod", "region": "eu"}
+	assert.False(t, s.Matches(env))
+	assert.True(t, s.Matches(env, manifest.Labels{"region": "us"}), "later labels take precedence")
+	assert.False(t, s.Matches(manifest.Labels{"region": "us"}, env))
+	assert.False(t, s.Matches())
+}
+
+func TestParseLabelSelector_Errors(t *testing.T) {
+	for _, selector := range []string{
+		"tier=prod,",
+		",tier",
+		"region in (eu,us",
+		"region in eu,us)",
+		"region in ((eu))",
+		"-tier",
+		"tier=pr od",
+		"!",
+	} {
+		t.Run(selector, func(t *testing.T) {
+			_, err := manifest.ParseLabelSelector(selector)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, manifest.ValidateLabels(nil))
+	assert.NoError(t, manifest.ValidateLabels(manifest.Labels{"tier": "prod", "example.com/team": "team_a-1", "empty": ""}))
+	assert.Error(t, manifest.ValidateLabels(manifest.Labels{"": "prod"}))
+	assert.Error(t, manifest.ValidateLabels(manifest.Labels{"tier": "prod env"}))
+	assert.Error(t, manifest.ValidateLabels(manifest.Labels{"-tier": "prod"}))
+}
+
+func TestLabelSelector_SelectedProjects(t *testing.T) {
+	s, err := manifest.ParseLabelSelector("region=us")
+	require.NoError(t, err)
+
+	m := manifest.Manifest{
+		Projects: manifest.ProjectDefinitionByProjectID{
+			"global":  {Name: "global"},
+			"us-only": {Name: "us-only", Labels: manifest.Labels{"region": "us"}},
+			"eu-only": {Name: "eu-only", Labels: manifest.Labels{"region": "eu"}},
+		},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"us-1": {Name: "us-1", Labels: manifest.Labels{"region": "us"}},
+				"eu-1": {Name: "eu-1", Labels: manifest.Labels{"region": "eu"}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"global", "us-only"}, s.SelectedProjects(m))
+}
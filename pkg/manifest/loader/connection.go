@@ -0,0 +1,119 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+var supportedProxySchemes = []string{"http", "https", "socks5"}
+
+// parseConnection parses the connection settings of an environment. The CA file is read relative to the manifest,
+// unless resolving secrets is disabled.
+func parseConnection(context *Context, c *persistence.Connection) (manifest.Connection, error) {
+	if c == nil {
+		return manifest.Connection{}, nil
+	}
+
+	result := manifest.Connection{
+		ProxyURL:              c.Proxy,
+		CAFile:                c.CAFile,
+		Insecure:              c.Insecure,
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		Headers:               c.Headers,
+	}
+
+	if c.Proxy != "" {
+		if err := validateProxyURL(c.Proxy); err != nil {
+			return manifest.Connection{}, err
+		}
+	}
+
+	if c.Timeout != "" {
+		timeout, err := time.ParseDuration(c.Timeout)
+		if err != nil || timeout <= 0 {
+			return manifest.Connection{}, fmt.Errorf("invalid timeout %q: must be a positive duration like '30s' or '2m'", c.Timeout)
+		}
+		result.Timeout = timeout
+	}
+
+	if c.MaxConcurrentRequests < 0 {
+		return manifest.Connection{}, errors.New("'maxConcurrentRequests' must be positive")
+	}
+
+	for name := range c.Headers {
+		if name == "" || strings.ContainsAny(name, " \t\r\n:") {
+			return manifest.Connection{}, fmt.Errorf("invalid header name %q", name)
+		}
+	}
+
+	if c.CAFile != "" {
+		certificates, err := readCAFile(context, c.CAFile)
+		if err != nil {
+			return manifest.Connection{}, err
+		}
+		result.CACertificates = certificates
+	}
+
+	return result, nil
+}
+
+func validateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %w", proxy, err)
+	}
+	if !slices.Contains(supportedProxySchemes, u.Scheme) || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: expected a URL like 'http://proxy.example.com:3128' with one of the schemes %q", proxy, supportedProxySchemes)
+	}
+	return nil
+}
+
+func readCAFile(context *Context, caFile string) ([]byte, error) {
+	if context.Opts.DoNotResolveEnvVars {
+		log.Debug("Skipped reading CA file %s based on loader options", caFile)
+		return nil, nil
+	}
+
+	path := filepath.FromSlash(caFile)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(context.ManifestPath), path)
+	}
+
+	content, err := afero.ReadFile(context.Fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file %q: %w", caFile, err)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("CA file %q does not contain any PEM encoded certificate", caFile)
+	}
+	return content, nil
+}
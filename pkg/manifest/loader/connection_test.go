@@ -0,0 +1,213 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// newCertificatePEM returns a self-signed certificate in PEM encoding.
+func newCertificatePEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test CA"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func connectionManifest(connection string) string {
+	return `
+manifestVersion: 1.0
+projects:
+- name: project
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url: {value: https://example.com}
+    auth: {token: {name: TOKEN}}
+    connection:
+` + connection
+}
+
+func TestLoad_Connection(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	fs := afero.NewMemMapFs()
+	ca := newCertificatePEM(t)
+	writeManifestFiles(t, fs, map[string]string{
+		"config/manifest.yaml": connectionManifest(`
+      proxy: http://proxy.example.com:3128
+      caFile: certs/ca.pem
+      insecure: true
+      timeout: 45s
+      maxConcurrentRequests: 3
+      headers:
+        X-Custom: value
+`),
+		"config/certs/ca.pem": string(ca),
+	})
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "config/manifest.yaml"})
+	require.Empty(t, errs)
+
+	assert.Equal(t, manifest.Connection{
+		ProxyURL:              "http://proxy.example.com:3128",
+		CAFile:                "certs/ca.pem",
+		CACertificates:        ca,
+		Insecure:              true,
+		Timeout:               45 * time.Second,
+		MaxConcurrentRequests: 3,
+		Headers:               map[string]string{"X-Custom": "value"},
+	}, got.Environments.SelectedEnvironments["env"].Connection)
+}
+
+func TestLoad_ConnectionIsOptional(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	fs := afero.NewMemMapFs()
+	writeManifestFiles(t, fs, map[string]string{"manifest.yaml": connectionManifest("      {}\n")})
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+	require.Empty(t, errs)
+	assert.False(t, got.Environments.SelectedEnvironments["env"].Connection.RequiresTransport())
+}
+
+func TestLoad_CAFileIsNotReadIfEnvVarsAreNotResolved(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeManifestFiles(t, fs, map[string]string{"manifest.yaml": connectionManifest("      caFile: missing.pem\n")})
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}})
+	require.Empty(t, errs)
+	assert.Equal(t, "missing.pem", got.Environments.SelectedEnvironments["env"].Connection.CAFile)
+	assert.Empty(t, got.Environments.SelectedEnvironments["env"].Connection.CACertificates)
+}
+
+func TestLoad_InvalidConnection(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	tests := []struct {
+		name       string
+		connection string
+		wantErr    string
+	}{
+		{
+			name:       "unsupported proxy scheme",
+			connection: "      proxy: ftp://proxy.example.com\n",
+			wantErr:    `invalid proxy URL "ftp://proxy.example.com"`,
+		},
+		{
+			name:       "proxy without host",
+			connection: "      proxy: proxy.example.com\n",
+			wantErr:    `invalid proxy URL "proxy.example.com"`,
+		},
+		{
+			name:       "malformed timeout",
+			connection: "      timeout: 30\n",
+			wantErr:    `invalid timeout "30"`,
+		},
+		{
+			name:       "negative timeout",
+			connection: "      timeout: -1s\n",
+			wantErr:    `invalid timeout "-1s"`,
+		},
+		{
+			name:       "negative max concurrent requests",
+			connection: "      maxConcurrentRequests: -1\n",
+			wantErr:    "'maxConcurrentRequests' must be positive",
+		},
+		{
+			name:       "invalid header name",
+			connection: "      headers: {\"X Custom\": value}\n",
+			wantErr:    `invalid header name "X Custom"`,
+		},
+		{
+			name:       "missing CA file",
+			connection: "      caFile: missing.pem\n",
+			wantErr:    `failed to read CA file "missing.pem"`,
+		},
+		{
+			name:       "CA file without certificates",
+			connection: "      caFile: invalid.pem\n",
+			wantErr:    `CA file "invalid.pem" does not contain any PEM encoded certificate`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			writeManifestFiles(t, fs, map[string]string{
+				"manifest.yaml": connectionManifest(tt.connection),
+				"invalid.pem":   "not a certificate",
+			})
+
+			_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+			require.Len(t, errs, 1)
+			assert.ErrorContains(t, errs[0], "invalid connection")
+			assert.ErrorContains(t, errs[0], tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_CAFileOfIncludedFragmentIsRelativeToFragment(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	fs := afero.NewMemMapFs()
+	ca := newCertificatePEM(t)
+	writeManifestFiles(t, fs, map[string]string{
+		"root/manifest.yaml": `
+manifestVersion: 1.0
+projects: [{name: base}]
+include: [shared/prod.yaml]
+`,
+		"root/shared/prod.yaml": `
+environmentGroups: [{name: prod, environments: [{name: prod-1, url: {value: https://prod-1}, auth: {token: {name: TOKEN}}, connection: {caFile: ca.pem}}]}]
+`,
+		"root/shared/ca.pem": string(ca),
+	})
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "root/manifest.yaml"})
+	require.Empty(t, errs)
+
+	connection := got.Environments.SelectedEnvironments["prod-1"].Connection
+	assert.Equal(t, "shared/ca.pem", connection.CAFile)
+	assert.Equal(t, ca, connection.CACertificates)
+}
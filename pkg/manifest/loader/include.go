@@ -0,0 +1,234 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// includeResolver merges manifest fragments into a manifest, keeping track of which file defined which name to
+// detect duplicates across files.
+type includeResolver struct {
+	fs          afero.Fs
+	manifestDir string
+	included    map[string]struct{}
+
+	projects     map[string]string
+	groups       map[string]string
+	environments map[string]string
+	accounts     map[string]string
+}
+
+// resolveIncludes merges all fragments transitively included by the manifest at the given path into the manifest.
+// Project, secret file and CA file paths of fragments are rewritten to be relative to the manifest. Names of projects, environment groups,
+// environments and accounts defined in more than one file are reported as errors. Duplicates within a single file are
+// left to the validation of the merged manifest.
+func resolveIncludes(fs afero.Fs, manifestPath string, m *persistence.Manifest) []error {
+	if len(m.Include) == 0 {
+		return nil
+	}
+
+	manifestPath = filepath.Clean(manifestPath)
+	r := &includeResolver{
+		fs:           fs,
+		manifestDir:  filepath.Dir(manifestPath),
+		included:     map[string]struct{}{manifestPath: {}},
+		projects:     make(map[string]string),
+		groups:       make(map[string]string),
+		environments: make(map[string]string),
+		accounts:     make(map[string]string),
+	}
+
+	root := persistence.ManifestFragment{Projects: m.Projects, EnvironmentGroups: m.EnvironmentGroups, Accounts: m.Accounts}
+	m.Projects, m.EnvironmentGroups, m.Accounts = nil, nil, nil
+	errs := r.merge(manifestPath, root, m)
+
+	for _, include := range m.Include {
+		errs = append(errs, r.include(manifestPath, include, m)...)
+	}
+	return errs
+}
+
+// include merges the fragments matching the given pattern, relative to the including file, into the manifest.
+func (r *includeResolver) include(includingFile string, pattern string, m *persistence.Manifest) []error {
+	fullPattern := filepath.Join(filepath.Dir(includingFile), filepath.FromSlash(pattern))
+
+	paths, err := afero.Glob(r.fs, fullPattern)
+	if err != nil {
+		return []error{newManifestLoaderError(includingFile, fmt.Sprintf("invalid include %q: %s", pattern, err))}
+	}
+	if len(paths) == 0 {
+		return []error{newManifestLoaderError(includingFile, fmt.Sprintf("include %q does not match any file", pattern))}
+	}
+	sort.Strings(paths)
+
+	var errs []error
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if _, found := r.included[path]; found {
+			errs = append(errs, newManifestLoaderError(includingFile, fmt.Sprintf("manifest fragment %q is included more than once", path)))
+			continue
+		}
+		r.included[path] = struct{}{}
+
+		fragment, err := r.readFragment(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		errs = append(errs, r.merge(path, fragment, m)...)
+		for _, nested := range fragment.Include {
+			errs = append(errs, r.include(path, nested, m)...)
+		}
+	}
+	return errs
+}
+
+func (r *includeResolver) readFragment(path string) (persistence.ManifestFragment, error) {
+	if !files.IsYamlFileExtension(path) {
+		return persistence.ManifestFragment{}, newManifestLoaderError(path, "manifest fragment is not a yaml")
+	}
+
+	data, err := afero.ReadFile(r.fs, path)
+	if err != nil {
+		return persistence.ManifestFragment{}, newManifestLoaderError(path, fmt.Sprintf("error while reading the manifest fragment: %s", err))
+	}
+
+	var fragment persistence.ManifestFragment
+	if err := yaml.UnmarshalStrict(data, &fragment); err != nil {
+		return persistence.ManifestFragment{}, newManifestLoaderError(path, fmt.Sprintf("error during parsing the manifest fragment: %s", err))
+	}
+	return fragment, nil
+}
+
+// merge appends everything the fragment defined in the given file contains to the manifest.
+func (r *includeResolver) merge(file string, fragment persistence.ManifestFragment, m *persistence.Manifest) []error {
+	var errs []error
+
+	for _, p := range fragment.Projects {
+		if err := r.register(r.projects, "project", p.Name, file); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.Projects = append(m.Projects, r.rebaseProject(file, p))
+	}
+
+	for _, g := range fragment.EnvironmentGroups {
+		if err := r.register(r.groups, "environment group", g.Name, file); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range g.Environments {
+			if err := r.register(r.environments, "environment", e.Name, file); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		m.EnvironmentGroups = append(m.EnvironmentGroups, r.rebaseGroup(file, g))
+	}
+
+	for _, a := range fragment.Accounts {
+		if err := r.register(r.accounts, "account", a.Name, file); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		a.OAuth = r.rebaseOAuth(file, a.OAuth)
+		m.Accounts = append(m.Accounts, a)
+	}
+
+	return errs
+}
+
+// register records that the given file defines the given name, and returns an error if another file defined it already.
+func (r *includeResolver) register(definedIn map[string]string, kind string, name string, file string) error {
+	if other, found := definedIn[name]; found && other != file {
+		return newManifestLoaderError(file, fmt.Sprintf("duplicated %s name %q, already defined in %q", kind, name, other))
+	}
+	definedIn[name] = file
+	return nil
+}
+
+// rebaseProject makes the path of a project defined in the given file relative to the manifest.
+func (r *includeResolver) rebaseProject(file string, p persistence.Project) persistence.Project {
+	path := p.Path
+	if path == "" {
+		path = p.Name
+	}
+	if rebased := r.rebase(file, path); rebased != path {
+		p.Path = rebased
+	}
+	return p
+}
+
+// rebaseGroup makes the paths of secret files and CA files of the environments of a group defined in the given file
+// relative to the manifest.
+func (r *includeResolver) rebaseGroup(file string, g persistence.Group) persistence.Group {
+	environments := make([]persistence.Environment, len(g.Environments))
+	for i, e := range g.Environments {
+		e.Auth.ApiToken = r.rebaseAuthSecret(file, e.Auth.ApiToken)
+		e.Auth.PlatformToken = r.rebaseAuthSecret(file, e.Auth.PlatformToken)
+		if e.Auth.OAuth != nil {
+			oAuth := r.rebaseOAuth(file, *e.Auth.OAuth)
+			e.Auth.OAuth = &oAuth
+		}
+		if e.Connection != nil && e.Connection.CAFile != "" && !filepath.IsAbs(filepath.FromSlash(e.Connection.CAFile)) {
+			connection := *e.Connection
+			connection.CAFile = r.rebase(file, connection.CAFile)
+			e.Connection = &connection
+		}
+		environments[i] = e
+	}
+	g.Environments = environments
+	return g
+}
+
+func (r *includeResolver) rebaseOAuth(file string, o persistence.OAuth) persistence.OAuth {
+	o.ClientID = *r.rebaseAuthSecret(file, &o.ClientID)
+	o.ClientSecret = *r.rebaseAuthSecret(file, &o.ClientSecret)
+	return o
+}
+
+func (r *includeResolver) rebaseAuthSecret(file string, s *persistence.AuthSecret) *persistence.AuthSecret {
+	if s == nil || s.Type != persistence.TypeFile || s.Path == "" || filepath.IsAbs(filepath.FromSlash(s.Path)) {
+		return s
+	}
+	rebased := *s
+	rebased.Path = r.rebase(file, s.Path)
+	return &rebased
+}
+
+// rebase makes the given path, which is relative to the given file, relative to the manifest.
+func (r *includeResolver) rebase(file string, path string) string {
+	fragmentDir := filepath.Dir(file)
+	if fragmentDir == r.manifestDir {
+		return path
+	}
+
+	rel, err := filepath.Rel(r.manifestDir, fragmentDir)
+	if err != nil {
+		return path
+	}
+	return filepath.ToSlash(filepath.Join(rel, filepath.FromSlash(path)))
+}
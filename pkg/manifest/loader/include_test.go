@@ -0,0 +1,136 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+func writeManifestFiles(t *testing.T, fs afero.Fs, files map[string]string) {
+	for path, content := range files {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+}
+
+func TestLoad_MergesIncludedFragments(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	fs := afero.NewMemMapFs()
+	writeManifestFiles(t, fs, map[string]string{
+		"root/manifest.yaml": `
+manifestVersion: 1.0
+projects: [{name: base}]
+environmentGroups: [{name: dev, environments: [{name: dev-1, url: {value: https://dev-1}, auth: {token: {name: TOKEN}}}]}]
+include: [teams/*.yaml]
+`,
+		"root/teams/team-a.yaml": `
+projects: [{name: team-a-project}, {name: team-a-other, path: configs/other}]
+include: [../shared/prod.yaml]
+`,
+		"root/teams/team-b.yaml": `
+projects: [{name: team-b-project}]
+`,
+		"root/shared/prod.yaml": `
+environmentGroups: [{name: prod, environments: [{name: prod-1, url: {value: https://prod-1}, auth: {token: {type: file, path: prod-token}}}]}]
+`,
+		"root/shared/prod-token": "prod token",
+	})
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "root/manifest.yaml"})
+	require.Empty(t, errs)
+
+	assert.Equal(t, manifest.ProjectDefinitionByProjectID{
+		"base":           {Name: "base", Path: "base"},
+		"team-a-project": {Name: "team-a-project", Path: "teams/team-a-project"},
+		"team-a-other":   {Name: "team-a-other", Path: "teams/configs/other"},
+		"team-b-project": {Name: "team-b-project", Path: "teams/team-b-project"},
+	}, got.Projects)
+	assert.Equal(t, map[string]struct{}{"dev": {}, "prod": {}}, got.Environments.AllGroupNames)
+	require.Contains(t, got.Environments.SelectedEnvironments, "prod-1")
+	prodToken := got.Environments.SelectedEnvironments["prod-1"].Auth.ApiToken
+	assert.Equal(t, "shared/prod-token", prodToken.Path, "secret file paths are relative to the fragment")
+	assert.Equal(t, "prod token", prodToken.Value.Value())
+}
+
+func TestLoad_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		errsContain []string
+	}{
+		{
+			name: "duplicate project across files",
+			files: map[string]string{
+				"manifest.yaml": "manifestVersion: 1.0\nprojects: [{name: a}]\ninclude: [fragment.yaml]",
+				"fragment.yaml": "projects: [{name: a}]",
+			},
+			errsContain: []string{`duplicated project name "a", already defined in "manifest.yaml"`},
+		},
+		{
+			name: "duplicate environment across files",
+			files: map[string]string{
+				"manifest.yaml": "manifestVersion: 1.0\nprojects: [{name: a}]\nenvironmentGroups: [{name: g1, environments: [{name: e, url: {value: u}, auth: {token: {name: T}}}]}]\ninclude: [fragment.yaml]",
+				"fragment.yaml": "environmentGroups: [{name: g2, environments: [{name: e, url: {value: u}, auth: {token: {name: T}}}]}]",
+			},
+			errsContain: []string{`duplicated environment name "e", already defined in "manifest.yaml"`},
+		},
+		{
+			name: "missing fragment",
+			files: map[string]string{
+				"manifest.yaml": "manifestVersion: 1.0\nprojects: [{name: a}]\ninclude: [missing.yaml]",
+			},
+			errsContain: []string{`include "missing.yaml" does not match any file`},
+		},
+		{
+			name: "fragment included twice",
+			files: map[string]string{
+				"manifest.yaml": "manifestVersion: 1.0\nprojects: [{name: a}]\ninclude: [fragment.yaml]",
+				"fragment.yaml": "projects: [{name: b}]\ninclude: [manifest.yaml]",
+			},
+			errsContain: []string{`manifest fragment "manifest.yaml" is included more than once`},
+		},
+		{
+			name: "unknown field in fragment",
+			files: map[string]string{
+				"manifest.yaml": "manifestVersion: 1.0\nprojects: [{name: a}]\ninclude: [fragment.yaml]",
+				"fragment.yaml": "manifestVersion: 1.0",
+			},
+			errsContain: []string{"error during parsing the manifest fragment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("T", "token")
+			fs := afero.NewMemMapFs()
+			writeManifestFiles(t, fs, tt.files)
+
+			_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+			require.Len(t, errs, len(tt.errsContain))
+			for i, err := range errs {
+				assert.ErrorContains(t, err, tt.errsContain[i])
+			}
+		})
+	}
+}
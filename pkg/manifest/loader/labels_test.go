@@ -0,0 +1,163 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+const labeledManifest = `
+manifestVersion: 1.0
+projects:
+- name: global
+  labels: {owner: platform}
+- name: us-apps
+  labels: {region: us}
+- name: teams
+  type: grouping
+  path: teams
+  labels: {owner: teams}
+environmentGroups:
+- name: prod
+  environments:
+  - name: prod-eu
+    url: {value: https://prod-eu}
+    auth: {token: {name: TOKEN}}
+    labels: {tier: prod, region: eu}
+  - name: prod-ap
+    url: {value: https://prod-ap}
+    auth: {token: {name: UNSET_TOKEN}}
+    labels: {tier: prod, region: ap}
+- name: staging
+  environments:
+  - name: staging-eu
+    url: {value: https://staging-eu}
+    auth: {token: {name: TOKEN}}
+    labels: {tier: staging, region: eu}
+`
+
+func newLabeledManifestFs(t *testing.T) afero.Fs {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, fs.MkdirAll("teams/a", 0755))
+	writeManifestFiles(t, fs, map[string]string{"manifest.yaml": labeledManifest})
+	return fs
+}
+
+func TestLoad_Labels(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	fs := newLabeledManifestFs(t)
+
+	got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Selector: mustParseSelector(t, "region=eu")})
+	require.Empty(t, errs)
+
+	assert.Equal(t, manifest.Labels{"owner": "platform"}, got.Projects["global"].Labels)
+	assert.Equal(t, manifest.Labels{"owner": "teams"}, got.Projects["teams.a"].Labels, "sub-projects inherit the labels of their grouping project")
+	assert.Equal(t, manifest.Labels{"tier": "staging", "region": "eu"}, got.Environments.SelectedEnvironments["staging-eu"].Labels)
+}
+
+func TestLoad_Selector(t *testing.T) {
+	t.Setenv("TOKEN", "mock token")
+
+	tests := []struct {
+		name         string
+		selector     string
+		environments []string
+		want         []string
+	}{
+		{
+			name:     "selects environments by their labels",
+			selector: "tier=prod,region in (eu,ch)",
+			want:     []string{"prod-eu"},
+		},
+		{
+			name:     "selects environments by the labels of any project",
+			selector: "tier=prod,region=us",
+			want:     []string{"prod-ap", "prod-eu"},
+		},
+		{
+			name:     "selects environments by project labels only",
+			selector: "owner=teams",
+			want:     []string{"prod-ap", "prod-eu", "staging-eu"},
+		},
+		{
+			name:         "restricts environments given by name",
+			selector:     "tier=staging",
+			environments: []string{"prod-eu", "staging-eu"},
+			want:         []string{"staging-eu"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newLabeledManifestFs(t)
+
+			got, errs := Load(&Context{
+				Fs:           fs,
+				ManifestPath: "manifest.yaml",
+				Environments: tt.environments,
+				Selector:     mustParseSelector(t, tt.selector),
+				Opts:         Options{DoNotResolveEnvVars: true},
+			})
+			require.Empty(t, errs)
+			assert.ElementsMatch(t, tt.want, got.Environments.SelectedEnvironments.Names())
+		})
+	}
+
+	t.Run("secrets of environments not selected are not resolved", func(t *testing.T) {
+		fs := newLabeledManifestFs(t)
+
+		got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Selector: mustParseSelector(t, "region=eu")})
+		require.Empty(t, errs)
+		assert.ElementsMatch(t, []string{"prod-eu", "staging-eu"}, got.Environments.SelectedEnvironments.Names())
+	})
+
+	t.Run("fails if no environment matches", func(t *testing.T) {
+		fs := newLabeledManifestFs(t)
+
+		_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Selector: mustParseSelector(t, "tier=dev"), Opts: Options{DoNotResolveEnvVars: true}})
+		require.Len(t, errs, 1)
+		assert.ErrorContains(t, errs[0], `no environment matches selector "tier=dev"`)
+	})
+}
+
+func TestLoad_InvalidLabels(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeManifestFiles(t, fs, map[string]string{"manifest.yaml": `
+manifestVersion: 1.0
+projects: [{name: project, labels: {"-invalid": value}}]
+environmentGroups: [{name: group, environments: [{name: env, url: {value: https://env}, auth: {token: {name: TOKEN}}, labels: {tier: "in valid"}}]}]
+`})
+
+	_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}})
+	require.Len(t, errs, 2)
+	assert.ErrorContains(t, errs[0], `invalid label key "-invalid"`)
+	assert.ErrorContains(t, errs[1], `invalid value "in valid" of label "tier"`)
+}
+
+func mustParseSelector(t *testing.T, s string) manifest.LabelSelector {
+	selector, err := manifest.ParseLabelSelector(s)
+	require.NoError(t, err)
+	return selector
+}
@@ -17,6 +17,7 @@
 package loader
 
 import (
+	stdcontext "context"
 	"errors"
 	"fmt"
 	"os"
@@ -60,6 +61,13 @@ type Context struct {
 	// If Groups contains items that do not match any environment in the specified manifest file, the loading errors.
 	Groups []string
 
+	// Selector is a filter on the labels of environments and projects. An environment is only loaded if the selector
+	// matches its labels merged with the labels of any project, or its own labels if no projects are defined.
+	// If both Selector and Environments or Groups are specified, only environments matching both are loaded.
+	//
+	// If Selector matches no environment, the loading errors.
+	Selector manifest.LabelSelector
+
 	// Opts are Options holding optional configuration for Load
 	Opts Options
 }
@@ -142,7 +150,7 @@ func (e ProjectLoaderError) Error() string {
 }
 
 func Load(context *Context) (manifest.Manifest, []error) {
-	log.WithFields(field.F("manifestPath", context.ManifestPath)).Info("Loading manifest %q. Restrictions: groups=%q, environments=%q", context.ManifestPath, context.Groups, context.Environments)
+	log.WithFields(field.F("manifestPath", context.ManifestPath)).Info("Loading manifest %q. Restrictions: groups=%q, environments=%q, selector=%q", context.ManifestPath, context.Groups, context.Environments, context.Selector)
 
 	manifestYAML, err := readManifestYAML(context)
 	if err != nil {
@@ -154,6 +162,10 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		return manifest.Manifest{}, []error{newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid manifest definition: %s", err))}
 	}
 
+	if errs := resolveIncludes(context.Fs, context.ManifestPath, &manifestYAML); len(errs) > 0 {
+		return manifest.Manifest{}, errs
+	}
+
 	if context.Opts.RequireEnvironmentGroups && len(manifestYAML.EnvironmentGroups) == 0 {
 		return manifest.Manifest{}, []error{newManifestLoaderError(context.ManifestPath, "'environmentGroups' are required, but not defined")}
 	}
@@ -189,10 +201,12 @@ func Load(context *Context) (manifest.Manifest, []error) {
 	var environments manifest.Environments
 	if len(manifestYAML.EnvironmentGroups) > 0 {
 		var manifestErrors []error
-		if environments, manifestErrors = parseEnvironments(context, manifestYAML.EnvironmentGroups); len(manifestErrors) > 0 {
+		if environments, manifestErrors = parseEnvironments(context, manifestYAML.EnvironmentGroups, projectDefinitions); len(manifestErrors) > 0 {
 			errs = append(errs, manifestErrors...)
 		} else if len(environments.AllEnvironmentNames) == 0 {
 			errs = append(errs, newManifestLoaderError(context.ManifestPath, "no environments defined in manifest"))
+		} else {
+			errs = append(errs, validateProjectEnvironments(context.ManifestPath, manifestYAML.Projects, environments)...)
 		}
 	}
 
@@ -259,10 +273,19 @@ func validateAuthInput(a persistence.Auth) error {
 }
 
 func parseAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
-	if s.Type != persistence.TypeEnvironment && s.Type != "" {
-		return manifest.AuthSecret{}, errors.New("type must be 'environment'")
+	switch s.Type {
+	case persistence.TypeEnvironment, "":
+		return parseEnvironmentAuthSecret(context, s)
+	case persistence.TypeFile:
+		return parseFileAuthSecret(context, s)
+	case persistence.TypeCommand:
+		return parseCommandAuthSecret(context, s)
+	default:
+		return manifest.AuthSecret{}, errors.New("type must be 'environment', 'file' or 'command'")
 	}
+}
 
+func parseEnvironmentAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
 	if s.Name == "" {
 		return manifest.AuthSecret{}, errors.New("no name given or empty")
 	}
@@ -287,6 +310,66 @@ func parseAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.Auth
 	return manifest.AuthSecret{Name: s.Name, Value: secret.MaskedString(v)}, nil
 }
 
+func parseFileAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if s.Path == "" {
+		return manifest.AuthSecret{}, errors.New("no path given or empty")
+	}
+
+	result := manifest.AuthSecret{Type: manifest.FileAuthSecretType, Name: s.Name, Path: s.Path}
+	if context.Opts.DoNotResolveEnvVars {
+		log.Debug("Skipped reading secret file %s based on loader options", s.Path)
+		result.Value = secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF FILE: %s", s.Path))
+		return result, nil
+	}
+
+	path := filepath.FromSlash(s.Path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(context.ManifestPath), path)
+	}
+
+	content, err := afero.ReadFile(context.Fs, path)
+	if err != nil {
+		return manifest.AuthSecret{}, fmt.Errorf("failed to read secret file %q: %w", s.Path, err)
+	}
+
+	v := trimTrailingNewline(string(content))
+	if v == "" {
+		return manifest.AuthSecret{}, fmt.Errorf("secret file %q is empty", s.Path)
+	}
+
+	result.Value = secret.MaskedString(v)
+	return result, nil
+}
+
+func parseCommandAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if len(s.Command) == 0 || s.Command[0] == "" {
+		return manifest.AuthSecret{}, errors.New("no command given or empty")
+	}
+
+	result := manifest.AuthSecret{Type: manifest.CommandAuthSecretType, Name: s.Name, Command: s.Command}
+	if context.Opts.DoNotResolveEnvVars {
+		log.Debug("Skipped running secret command %s based on loader options", s.Command[0])
+		result.Value = secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF COMMAND: %s", s.Command[0]))
+		return result, nil
+	}
+
+	v, err := secret.RunCommand(stdcontext.Background(), secret.Command{Args: s.Command, Dir: filepath.Dir(context.ManifestPath)})
+	if err != nil {
+		return manifest.AuthSecret{}, fmt.Errorf("secret %w", err)
+	}
+
+	if v == "" {
+		return manifest.AuthSecret{}, fmt.Errorf("secret command %q printed an empty value", s.Command[0])
+	}
+
+	result.Value = secret.MaskedString(v)
+	return result, nil
+}
+
+func trimTrailingNewline(s string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(s, "\n"), "\r")
+}
+
 func parseOAuth(context *Context, a *persistence.OAuth) (*manifest.OAuth, error) {
 	clientID, err := parseAuthSecret(context, &a.ClientID)
 	if err != nil {
@@ -370,7 +453,7 @@ func validateVersion(m persistence.Manifest) error {
 	return nil
 }
 
-func parseEnvironments(context *Context, groups []persistence.Group) (manifest.Environments, []error) { // nolint:gocognit
+func parseEnvironments(context *Context, groups []persistence.Group, projects map[string]manifest.ProjectDefinition) (manifest.Environments, []error) { // nolint:gocognit
 	var errors []error
 	selectedEnvironments := make(map[string]manifest.EnvironmentDefinition)
 
@@ -401,12 +484,22 @@ func parseEnvironments(context *Context, groups []persistence.Group) (manifest.E
 			}
 			allEnvironmentNames[env.Name] = struct{}{}
 
+			if err := manifest.ValidateLabels(env.Labels); err != nil {
+				errors = append(errors, newManifestEnvironmentLoaderError(context.ManifestPath, group.Name, env.Name, err.Error()))
+				continue
+			}
+
 			// skip loading if environments is not empty, the environments does not contain the env name, or the group should not be included
 			if shouldSkipEnv(context, group, env) {
 				log.WithFields(field.F("manifestPath", context.ManifestPath)).Debug("skipping loading of environment %q", env.Name)
 				continue
 			}
 
+			if !matchesSelector(context.Selector, env, group.Name, projects) {
+				log.WithFields(field.F("manifestPath", context.ManifestPath)).Debug("skipping loading of environment %q not matching selector %q", env.Name, context.Selector)
+				continue
+			}
+
 			parsedEnv, configErrors := parseSingleEnvironment(context, env, group.Name)
 
 			if configErrors != nil {
@@ -431,6 +524,10 @@ func parseEnvironments(context *Context, groups []persistence.Group) (manifest.E
 		}
 	}
 
+	if errors == nil && !context.Selector.IsEmpty() && len(selectedEnvironments) == 0 {
+		errors = append(errors, newManifestLoaderError(context.ManifestPath, fmt.Sprintf("no environment matches selector %q", context.Selector)))
+	}
+
 	if errors != nil {
 		return manifest.Environments{}, errors
 	}
@@ -459,6 +556,39 @@ func shouldSkipEnv(context *Context, group persistence.Group, env persistence.En
 	return true
 }
 
+// matchesSelector reports whether the selector matches the labels of the environment merged with the labels of any
+// of the given projects applying to the environment.
+func matchesSelector(selector manifest.LabelSelector, env persistence.Environment, group string, projects map[string]manifest.ProjectDefinition) bool {
+	if len(projects) == 0 {
+		return selector.Matches(env.Labels)
+	}
+	for _, p := range projects {
+		if p.AppliesTo(env.Name, group) && selector.Matches(env.Labels, p.Labels) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateProjectEnvironments returns an error for each environment group and environment a project is restricted to,
+// which is not defined in the manifest.
+func validateProjectEnvironments(manifestPath string, projects []persistence.Project, environments manifest.Environments) []error {
+	var errs []error
+	for _, p := range projects {
+		for _, g := range p.EnvironmentGroups {
+			if _, found := environments.AllGroupNames[g]; !found {
+				errs = append(errs, newManifestProjectLoaderError(manifestPath, p.Name, fmt.Sprintf("unknown environment group %q", g)))
+			}
+		}
+		for _, e := range p.Environments {
+			if _, found := environments.AllEnvironmentNames[e]; !found {
+				errs = append(errs, newManifestProjectLoaderError(manifestPath, p.Name, fmt.Sprintf("unknown environment %q", e)))
+			}
+		}
+	}
+	return errs
+}
+
 func parseSingleEnvironment(context *Context, config persistence.Environment, group string) (manifest.EnvironmentDefinition, []error) {
 	var errs []error
 
@@ -472,18 +602,60 @@ func parseSingleEnvironment(context *Context, config persistence.Environment, gr
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	hooks, err := parseHooks(config.Hooks)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
+	}
+
+	connection, err := parseConnection(context, config.Connection)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("invalid connection: %s", err)))
+	}
+
 	if len(errs) > 0 {
 		return manifest.EnvironmentDefinition{}, errs
 	}
 
 	return manifest.EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:       config.Name,
+		URL:        urlDef,
+		Auth:       a,
+		Group:      group,
+		Hooks:      hooks,
+		Labels:     config.Labels,
+		Connection: connection,
 	}, nil
 }
 
+func parseHooks(h *persistence.Hooks) (manifest.Hooks, error) {
+	if h == nil {
+		return manifest.Hooks{}, nil
+	}
+
+	preDeploy, err := parseHookList("preDeploy", h.PreDeploy)
+	if err != nil {
+		return manifest.Hooks{}, err
+	}
+
+	postDeploy, err := parseHookList("postDeploy", h.PostDeploy)
+	if err != nil {
+		return manifest.Hooks{}, err
+	}
+
+	return manifest.Hooks{PreDeploy: preDeploy, PostDeploy: postDeploy}, nil
+}
+
+func parseHookList(stage string, hooks []persistence.Hook) ([]manifest.Hook, error) {
+	var result []manifest.Hook
+	for i, h := range hooks {
+		if len(h.Command) == 0 || h.Command[0] == "" {
+			return nil, fmt.Errorf("%s hook %d: 'command' is required", stage, i+1)
+		}
+		result = append(result, manifest.Hook{Command: h.Command})
+	}
+	return result, nil
+}
+
 func parseURLDefinition(context *Context, u persistence.TypedValue) (manifest.URLDefinition, error) {
 
 	// Depending on the type, the url.value either contains the env var name or the direct value of the url
@@ -591,15 +763,34 @@ func parseProjectDefinition(context *projectLoaderContext, project persistence.P
 		return nil, []error{newManifestProjectLoaderError(context.manifestPath, project.Name, "project name is required")}
 	}
 
+	hooks, err := parseHooks(project.Hooks)
+	if err != nil {
+		return nil, []error{newManifestProjectLoaderError(context.manifestPath, project.Name, err.Error())}
+	}
+
+	if err := manifest.ValidateLabels(project.Labels); err != nil {
+		return nil, []error{newManifestProjectLoaderError(context.manifestPath, project.Name, err.Error())}
+	}
+
+	var definitions []manifest.ProjectDefinition
+	var errs []error
 	switch projectType {
 	case persistence.SimpleProjectType:
-		return parseSimpleProjectDefinition(context, project)
+		definitions, errs = parseSimpleProjectDefinition(context, project)
 	case persistence.GroupProjectType:
-		return parseGroupingProjectDefinition(context, project)
+		definitions, errs = parseGroupingProjectDefinition(context, project)
 	default:
 		return nil, []error{newManifestProjectLoaderError(context.manifestPath, project.Name,
 			fmt.Sprintf("invalid project type `%s`", projectType))}
 	}
+
+	for i := range definitions {
+		definitions[i].Hooks = hooks
+		definitions[i].Labels = project.Labels
+		definitions[i].EnvironmentGroups = project.EnvironmentGroups
+		definitions[i].Environments = project.Environments
+	}
+	return definitions, errs
 }
 
 func parseSimpleProjectDefinition(context *projectLoaderContext, project persistence.Project) ([]manifest.ProjectDefinition, []error) {
@@ -23,6 +23,7 @@ import (
 	"math"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -31,6 +32,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
 	monacoVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
@@ -862,6 +864,76 @@ environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {t
 				Accounts: map[string]manifest.Account{},
 			},
 		},
+		{
+			name: "Hooks are loaded for environments and projects",
+			manifestContent: `
+manifestVersion: 1.0
+projects:
+  - name: a
+    path: p
+    hooks:
+      postDeploy: [{command: [./warm-caches.sh]}]
+environmentGroups:
+  - name: b
+    environments:
+      - name: c
+        url: {value: d}
+        auth: {token: {name: e}}
+        hooks:
+          preDeploy: [{command: [./notify.sh, start]}]
+          postDeploy: [{command: [./notify.sh, end]}]
+`,
+			errsContain: []string{},
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {
+						Name: "a",
+						Path: "p",
+						Hooks: manifest.Hooks{
+							PostDeploy: []manifest.Hook{{Command: []string{"./warm-caches.sh"}}},
+						},
+					},
+				},
+				Environments: manifest.Environments{
+					SelectedEnvironments: map[string]manifest.EnvironmentDefinition{
+						"c": {
+							Name: "c",
+							URL: manifest.URLDefinition{
+								Type:  manifest.ValueURLType,
+								Value: "d",
+							},
+							Group: "b",
+							Auth: manifest.Auth{
+								ApiToken: &manifest.AuthSecret{
+									Name:  "e",
+									Value: "mock token",
+								},
+							},
+							Hooks: manifest.Hooks{
+								PreDeploy:  []manifest.Hook{{Command: []string{"./notify.sh", "start"}}},
+								PostDeploy: []manifest.Hook{{Command: []string{"./notify.sh", "end"}}},
+							},
+						},
+					},
+					AllEnvironmentNames: map[string]struct{}{
+						"c": {},
+					},
+					AllGroupNames: map[string]struct{}{
+						"b": {},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
+		{
+			name: "Hook without command",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, hooks: {preDeploy: [{command: []}]}}]}]
+`,
+			errsContain: []string{"preDeploy hook 1: 'command' is required"},
+		},
 		{
 			name: "Everything good with multiple environments in multiple groups",
 			manifestContent: `
@@ -2134,6 +2206,66 @@ func TestLoadManifest_WithPlatformTokenSupport(t *testing.T) {
 	})
 }
 
+func TestParseAuthSecret_FileAndCommand(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "dir/secrets/token", []byte("file-token\n"), 0600))
+	require.NoError(t, afero.WriteFile(fs, "dir/secrets/empty", []byte(""), 0600))
+	context := &Context{Fs: fs, ManifestPath: "dir/manifest.yaml"}
+
+	t.Run("reads secret from file relative to manifest", func(t *testing.T) {
+		got, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/token"})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.AuthSecret{Type: manifest.FileAuthSecretType, Path: "secrets/token", Value: "file-token"}, got)
+	})
+
+	t.Run("returns error for missing path, missing file or empty file", func(t *testing.T) {
+		_, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile})
+		assert.ErrorContains(t, err, "no path given or empty")
+
+		_, err = parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/missing"})
+		assert.ErrorContains(t, err, `failed to read secret file "secrets/missing"`)
+
+		_, err = parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/empty"})
+		assert.ErrorContains(t, err, `secret file "secrets/empty" is empty`)
+	})
+
+	t.Run("reads secret from command output", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("helper command in this test requires a POSIX shell")
+		}
+
+		got, err := parseAuthSecret(&Context{ManifestPath: "manifest.yaml"}, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"sh", "-c", "echo command-token"}})
+		require.NoError(t, err)
+		assert.Equal(t, secret.MaskedString("command-token"), got.Value)
+		assert.Equal(t, manifest.CommandAuthSecretType, got.Type)
+	})
+
+	t.Run("returns error without output if command fails", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("helper command in this test requires a POSIX shell")
+		}
+
+		_, err := parseAuthSecret(&Context{ManifestPath: "manifest.yaml"}, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"sh", "-c", "echo s3cr3t; echo denied >&2; exit 1"}})
+		assert.ErrorContains(t, err, "denied")
+		assert.NotContains(t, err.Error(), "s3cr3t")
+	})
+
+	t.Run("returns error for missing command", func(t *testing.T) {
+		_, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeCommand})
+		assert.ErrorContains(t, err, "no command given or empty")
+	})
+
+	t.Run("files and commands are not resolved if 'DoNotResolveEnvVars' option is set", func(t *testing.T) {
+		skipContext := &Context{Fs: fs, ManifestPath: "dir/manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}}
+
+		_, err := parseAuthSecret(skipContext, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/missing"})
+		assert.NoError(t, err)
+
+		_, err = parseAuthSecret(skipContext, &persistence.AuthSecret{Type: persistence.TypeCommand, Command: []string{"does-not-exist"}})
+		assert.NoError(t, err)
+	})
+}
+
 func TestEnvVarResolutionCanBeDeactivated(t *testing.T) {
 	e := persistence.Environment{
 		Name: "TEST ENV",
@@ -2223,3 +2355,63 @@ projects: [{name: a, path: p}]
 		})
 	}
 }
+
+func TestLoad_ProjectEnvironments(t *testing.T) {
+	const environmentGroups = `
+environmentGroups:
+- name: dev
+  environments: [{name: dev-1, url: {value: https://dev-1}, auth: {token: {name: TOKEN}}}]
+- name: prod
+  environments: [{name: prod-1, url: {value: https://prod-1}, auth: {token: {name: TOKEN}}}]
+`
+
+	t.Run("restrictions are loaded", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		writeManifestFiles(t, fs, map[string]string{"manifest.yaml": `
+manifestVersion: 1.0
+projects:
+- name: global
+- name: prod-only
+  environmentGroups: [prod]
+  environments: [dev-1]
+` + environmentGroups})
+
+		got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}})
+		require.Empty(t, errs)
+		assert.Equal(t, manifest.ProjectDefinitionByProjectID{
+			"global":    {Name: "global", Path: "global"},
+			"prod-only": {Name: "prod-only", Path: "prod-only", EnvironmentGroups: []string{"prod"}, Environments: []string{"dev-1"}},
+		}, got.Projects)
+	})
+
+	t.Run("unknown groups and environments fail", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		writeManifestFiles(t, fs, map[string]string{"manifest.yaml": `
+manifestVersion: 1.0
+projects:
+- name: prod-only
+  environmentGroups: [production]
+  environments: [prod-2]
+` + environmentGroups})
+
+		_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{DoNotResolveEnvVars: true}})
+		require.Len(t, errs, 2)
+		assert.ErrorContains(t, errs[0], `unknown environment group "production"`)
+		assert.ErrorContains(t, errs[1], `unknown environment "prod-2"`)
+	})
+
+	t.Run("selector only considers projects applying to an environment", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		writeManifestFiles(t, fs, map[string]string{"manifest.yaml": `
+manifestVersion: 1.0
+projects:
+- name: prod-only
+  environmentGroups: [prod]
+  labels: {critical: "true"}
+` + environmentGroups})
+
+		got, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Selector: mustParseSelector(t, "critical"), Opts: Options{DoNotResolveEnvVars: true}})
+		require.Empty(t, errs)
+		assert.Equal(t, []string{"prod-1"}, got.Environments.SelectedEnvironments.Names())
+	})
+}
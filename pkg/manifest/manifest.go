@@ -19,6 +19,8 @@ package manifest
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
@@ -31,6 +33,22 @@ type ProjectDefinition struct {
 	Name  string
 	Group string
 	Path  string
+	// Hooks are run before and after the project is deployed to an environment
+	Hooks Hooks
+	// Labels are used to select the project by a LabelSelector
+	Labels Labels
+	// EnvironmentGroups and Environments restrict the environments the project applies to. If both are empty, the
+	// project applies to all environments.
+	EnvironmentGroups []string
+	Environments      []string
+}
+
+// AppliesTo returns whether the project applies to the given environment of the given group.
+func (p ProjectDefinition) AppliesTo(environment, group string) bool {
+	if len(p.EnvironmentGroups) == 0 && len(p.Environments) == 0 {
+		return true
+	}
+	return slices.Contains(p.EnvironmentGroups, group) || slices.Contains(p.Environments, environment)
 }
 
 func (p ProjectDefinition) String() string {
@@ -70,12 +88,68 @@ type EnvironmentDefinition struct {
 	Group string
 	URL   URLDefinition
 	Auth  Auth
+	// Hooks are run before and after deploying to the environment
+	Hooks Hooks
+	// Labels are used to select the environment by a LabelSelector
+	Labels Labels
+	// Connection holds the settings of HTTP connections to the environment
+	Connection Connection
 }
 
 func (e EnvironmentDefinition) HasPlatformCredentials() bool {
 	return e.Auth.HasPlatformCredentials()
 }
 
+// Connection holds the settings of HTTP connections to an environment. Zero values mean that the process-wide defaults
+// are used.
+type Connection struct {
+	// ProxyURL is the URL of the proxy all requests are sent through. If empty, the proxy environment variables are used.
+	ProxyURL string
+	// CAFile is the path of a PEM encoded CA bundle, relative to the manifest. Its certificates are trusted in addition
+	// to the system's ones.
+	CAFile string
+	// CACertificates holds the content of CAFile. It is not read if resolving secrets is disabled during loading.
+	CACertificates []byte
+	// Insecure disables the verification of TLS certificates
+	Insecure bool
+	// Timeout is the maximum duration of a single request, including reading its response
+	Timeout time.Duration
+	// MaxConcurrentRequests limits the number of concurrent requests, instead of MONACO_CONCURRENT_REQUESTS
+	MaxConcurrentRequests int
+	// Headers are sent with every request. They take precedence over headers of MONACO_ADDITIONAL_HTTP_HEADERS.
+	Headers map[string]string
+}
+
+// RequiresTransport returns whether the connection requires an HTTP transport different from the default one.
+func (c Connection) RequiresTransport() bool {
+	return c.ProxyURL != "" || len(c.CACertificates) > 0 || c.Insecure || c.Timeout > 0
+}
+
+// Hook is a local command run before or after a deployment to an environment.
+type Hook struct {
+	// Command is the command to run, followed by its arguments
+	Command []string
+}
+
+// Hooks holds the hooks run before and after a deployment to an environment.
+type Hooks struct {
+	PreDeploy  []Hook
+	PostDeploy []Hook
+}
+
+// IsEmpty returns whether no hooks are defined.
+func (h Hooks) IsEmpty() bool {
+	return len(h.PreDeploy) == 0 && len(h.PostDeploy) == 0
+}
+
+// Append returns hooks running the given hooks after the ones of h.
+func (h Hooks) Append(other Hooks) Hooks {
+	return Hooks{
+		PreDeploy:  append(slices.Clone(h.PreDeploy), other.PreDeploy...),
+		PostDeploy: append(slices.Clone(h.PostDeploy), other.PostDeploy...),
+	}
+}
+
 // URLType describes from where the url is loaded.
 // Possible values are [EnvironmentURLType] and [ValueURLType].
 // [ValueURLType] is the default value.
@@ -102,12 +176,34 @@ type URLDefinition struct {
 	Value string
 }
 
+// AuthSecretType defines where the value of an AuthSecret is read from.
+type AuthSecretType string
+
+const (
+	// EnvironmentAuthSecretType secrets are read from the environment variable [AuthSecret.Name]. It is the default if
+	// no type is set.
+	EnvironmentAuthSecretType AuthSecretType = "environment"
+	// FileAuthSecretType secrets are read from the file at [AuthSecret.Path].
+	FileAuthSecretType AuthSecretType = "file"
+	// CommandAuthSecretType secrets are read from the stdout of [AuthSecret.Command].
+	CommandAuthSecretType AuthSecretType = "command"
+)
+
 // AuthSecret contains a resolved secret value. It is used for the API token, ClientID, and ClientSecret.
 type AuthSecret struct {
+	// Type defines where the secret is read from. An empty type is equal to [EnvironmentAuthSecretType].
+	Type AuthSecretType
+
 	// Name is the name of the environment-variable of the token.
 	// It is used in download to store the name of the OAuth token in the new created manifest.
 	Name string
 
+	// Path is the file the secret is read from, if [AuthSecret.Type] is [FileAuthSecretType].
+	Path string
+
+	// Command is the command printing the secret, if [AuthSecret.Type] is [CommandAuthSecretType].
+	Command []string
+
 	// Value holds the actual token value for the given [AuthSecret.Name].
 	Value secret.MaskedString
 }
@@ -408,3 +408,14 @@ environmentGroups:
 		})
 	}
 }
+
+func TestProjectDefinition_AppliesTo(t *testing.T) {
+	unrestricted := manifest.ProjectDefinition{Name: "p"}
+	assert.True(t, unrestricted.AppliesTo("dev-1", "dev"))
+
+	restricted := manifest.ProjectDefinition{Name: "p", EnvironmentGroups: []string{"prod"}, Environments: []string{"staging-1"}}
+	assert.True(t, restricted.AppliesTo("prod-1", "prod"))
+	assert.True(t, restricted.AppliesTo("staging-1", "staging"))
+	assert.False(t, restricted.AppliesTo("staging-2", "staging"))
+	assert.False(t, restricted.AppliesTo("dev-1", "dev"))
+}
@@ -109,14 +109,24 @@ func toWriteableProjects(projects map[string]manifest.ProjectDefinition) (result
 			groupName, groupPath := extractGroupedProjectDetails(projectDefinition)
 
 			groups[groupName] = persistence.Project{
-				Name: groupName,
-				Path: groupPath,
-				Type: persistence.GroupProjectType,
+				Name:              groupName,
+				Path:              groupPath,
+				Type:              persistence.GroupProjectType,
+				Hooks:             toWriteableHooks(projectDefinition.Hooks),
+				Labels:            projectDefinition.Labels,
+				EnvironmentGroups: projectDefinition.EnvironmentGroups,
+				Environments:      projectDefinition.Environments,
 			}
 			continue
 		}
 
-		p := persistence.Project{Name: projectDefinition.Name}
+		p := persistence.Project{
+			Name:              projectDefinition.Name,
+			Hooks:             toWriteableHooks(projectDefinition.Hooks),
+			Labels:            projectDefinition.Labels,
+			EnvironmentGroups: projectDefinition.EnvironmentGroups,
+			Environments:      projectDefinition.Environments,
+		}
 
 		if projectDefinition.Name != projectDefinition.Path {
 			p.Path = projectDefinition.Path
@@ -151,9 +161,12 @@ func toWriteableEnvironmentGroups(environments manifest.Environments) (result []
 
 	for name, env := range environments.SelectedEnvironments {
 		e := persistence.Environment{
-			Name: name,
-			URL:  toWriteableURL(env.URL),
-			Auth: getAuth(env),
+			Name:       name,
+			URL:        toWriteableURL(env.URL),
+			Auth:       getAuth(env),
+			Hooks:      toWriteableHooks(env.Hooks),
+			Labels:     env.Labels,
+			Connection: toWriteableConnection(env.Connection),
 		}
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
@@ -166,6 +179,24 @@ func toWriteableEnvironmentGroups(environments manifest.Environments) (result []
 	return result
 }
 
+func toWriteableConnection(c manifest.Connection) *persistence.Connection {
+	if c.ProxyURL == "" && c.CAFile == "" && !c.Insecure && c.Timeout == 0 && c.MaxConcurrentRequests == 0 && len(c.Headers) == 0 {
+		return nil
+	}
+
+	result := &persistence.Connection{
+		Proxy:                 c.ProxyURL,
+		CAFile:                c.CAFile,
+		Insecure:              c.Insecure,
+		MaxConcurrentRequests: c.MaxConcurrentRequests,
+		Headers:               c.Headers,
+	}
+	if c.Timeout > 0 {
+		result.Timeout = c.Timeout.String()
+	}
+	return result
+}
+
 func getAuth(env manifest.EnvironmentDefinition) persistence.Auth {
 	return persistence.Auth{
 		ApiToken:      getAuthSecret(env.Auth.ApiToken),
@@ -192,7 +223,19 @@ func getAuthSecret(secret *manifest.AuthSecret) *persistence.AuthSecret {
 		return nil
 	}
 
-	return &persistence.AuthSecret{
+	s := toWriteableAuthSecret(*secret)
+	return &s
+}
+
+func toWriteableAuthSecret(secret manifest.AuthSecret) persistence.AuthSecret {
+	switch secret.Type {
+	case manifest.FileAuthSecretType:
+		return persistence.AuthSecret{Type: persistence.TypeFile, Name: secret.Name, Path: secret.Path}
+	case manifest.CommandAuthSecretType:
+		return persistence.AuthSecret{Type: persistence.TypeCommand, Name: secret.Name, Command: secret.Command}
+	}
+
+	return persistence.AuthSecret{
 		Type: persistence.TypeEnvironment,
 		Name: secret.Name,
 	}
@@ -210,14 +253,8 @@ func getOAuthCredentials(a *manifest.OAuth) *persistence.OAuth {
 	}
 
 	return &persistence.OAuth{
-		ClientID: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientID.Name,
-		},
-		ClientSecret: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientSecret.Name,
-		},
+		ClientID:      toWriteableAuthSecret(a.ClientID),
+		ClientSecret:  toWriteableAuthSecret(a.ClientSecret),
 		TokenEndpoint: te,
 	}
 }
@@ -233,14 +270,8 @@ func toWriteableAccounts(accounts map[string]manifest.Account) []persistence.Acc
 		}
 
 		oauth := persistence.OAuth{
-			ClientID: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientID.Name,
-			},
-			ClientSecret: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientSecret.Name,
-			},
+			ClientID:     toWriteableAuthSecret(account.OAuth.ClientID),
+			ClientSecret: toWriteableAuthSecret(account.OAuth.ClientSecret),
 		}
 		if account.OAuth.TokenEndpoint != nil {
 			url := toWriteableURL(*account.OAuth.TokenEndpoint)
@@ -256,3 +287,22 @@ func toWriteableAccounts(accounts map[string]manifest.Account) []persistence.Acc
 	}
 	return out
 }
+
+func toWriteableHooks(hooks manifest.Hooks) *persistence.Hooks {
+	if hooks.IsEmpty() {
+		return nil
+	}
+
+	toWriteable := func(hooks []manifest.Hook) []persistence.Hook {
+		var result []persistence.Hook
+		for _, h := range hooks {
+			result = append(result, persistence.Hook{Command: h.Command})
+		}
+		return result
+	}
+
+	return &persistence.Hooks{
+		PreDeploy:  toWriteable(hooks.PreDeploy),
+		PostDeploy: toWriteable(hooks.PostDeploy),
+	}
+}
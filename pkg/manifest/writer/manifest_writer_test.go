@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/afero"
@@ -138,6 +139,33 @@ func Test_toWriteableProjects(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "writes_labels",
+			givenProjects: map[string]manifest.ProjectDefinition{
+				"project_a": {
+					Name:   "a",
+					Path:   "a",
+					Labels: manifest.Labels{"tier": "prod"},
+				},
+				"project_b": {
+					Name:   "projects.b",
+					Path:   "projects/b",
+					Labels: manifest.Labels{"region": "eu"},
+				},
+			},
+			wantResult: []persistence.Project{
+				{
+					Name:   "a",
+					Labels: map[string]string{"tier": "prod"},
+				},
+				{
+					Name:   "projects",
+					Path:   "projects",
+					Type:   "grouping",
+					Labels: map[string]string{"region": "eu"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -441,11 +469,41 @@ func Test_toWritableToken(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func Test_toWritableFileAndCommandToken(t *testing.T) {
+	got := getAuthSecret(&manifest.AuthSecret{Type: manifest.FileAuthSecretType, Path: "secrets/token", Value: "secret"})
+	assert.Equal(t, &persistence.AuthSecret{Type: "file", Path: "secrets/token"}, got)
+
+	got = getAuthSecret(&manifest.AuthSecret{Type: manifest.CommandAuthSecretType, Command: []string{"pass", "show", "token"}, Value: "secret"})
+	assert.Equal(t, &persistence.AuthSecret{Type: "command", Command: []string{"pass", "show", "token"}}, got)
+}
+
 func Test_toWritableNilToken(t *testing.T) {
 	got := getAuthSecret(nil)
 	assert.Nil(t, got)
 }
 
+func Test_toWriteableConnection(t *testing.T) {
+	got := toWriteableConnection(manifest.Connection{
+		ProxyURL:              "http://proxy:3128",
+		CAFile:                "certs/ca.pem",
+		CACertificates:        []byte("certificate"),
+		Insecure:              true,
+		Timeout:               90 * time.Second,
+		MaxConcurrentRequests: 4,
+		Headers:               map[string]string{"X-Custom": "value"},
+	})
+	assert.Equal(t, &persistence.Connection{
+		Proxy:                 "http://proxy:3128",
+		CAFile:                "certs/ca.pem",
+		Insecure:              true,
+		Timeout:               "1m30s",
+		MaxConcurrentRequests: 4,
+		Headers:               map[string]string{"X-Custom": "value"},
+	}, got)
+
+	assert.Nil(t, toWriteableConnection(manifest.Connection{}), "connection is omitted if nothing is set")
+}
+
 func Test_toWriteableAccounts(t *testing.T) {
 
 	tests := []struct {
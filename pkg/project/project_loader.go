@@ -17,6 +17,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"slices"
 
 	"github.com/spf13/afero"
@@ -107,7 +108,7 @@ func LoadProjects(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderC
 			continue
 		}
 
-		project, loadProjectErrs := loadProject(ctx, workingDirFs, loaderContext, projectDefinition, loaderContext.Manifest.Environments)
+		project, loadProjectErrs := loadProject(ctx, workingDirFs, loaderContext, projectDefinition, environmentsOfProject(projectDefinition, loaderContext.Manifest.Environments))
 
 		if len(loadProjectErrs) > 0 {
 			errs = append(errs, loadProjectErrs...)
@@ -166,6 +167,19 @@ func getProjectNamesToLoad(allProjectsDefinitions manifest.ProjectDefinitionByPr
 	return projectNamesToLoad, errs
 }
 
+// environmentsOfProject returns the given environments, with the selected environments restricted to the ones the
+// project applies to. Configurations of the project are not loaded for any other environment.
+func environmentsOfProject(projectDefinition manifest.ProjectDefinition, environments manifest.Environments) manifest.Environments {
+	selected := make(manifest.EnvironmentDefinitionsByName, len(environments.SelectedEnvironments))
+	for name, env := range environments.SelectedEnvironments {
+		if projectDefinition.AppliesTo(name, env.Group) {
+			selected[name] = env
+		}
+	}
+	environments.SelectedEnvironments = selected
+	return environments
+}
+
 func loadProject(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderContext, projectDefinition manifest.ProjectDefinition, environments manifest.Environments) (Project, []error) {
 	if exists, err := afero.Exists(fs, projectDefinition.Path); err != nil {
 		formattedErr := fmt.Errorf("failed to load project `%s` (%s): %w", projectDefinition.Name, projectDefinition.Path, err)
@@ -308,12 +322,25 @@ func loadConfigsOfProject(ctx context.Context, fs afero.Fs, loadingContext Proje
 		return nil, []error{fmt.Errorf("failed to walk files: %w", err)}
 	}
 
+	sharedParameters, err := loader.LoadSharedParameters(fs, projectDefinition.Path)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to load shared parameters of project %q: %w", projectDefinition.Name, err)}
+	}
+	sharedParametersFile := filepath.Join(projectDefinition.Path, loader.SharedParametersFileName)
+
 	var configs []config.Config
 	var errs []error
 
 	loaderContext := newLoaderContext(loadingContext, projectDefinition, environments)
+	loaderContext.SharedParameters = sharedParameters
+	loaderContext.ConfigDefinitions = loader.NewConfigDefinitionIndex(fs, projectPaths(loadingContext.Manifest.Projects))
+	loaderContext.ForEachFiles = loader.FindForEachFiles(fs, configFiles)
 
 	for _, file := range configFiles {
+		if filepath.Clean(file) == sharedParametersFile {
+			continue
+		}
+
 		log.WithFields(field.F("file", file)).DebugContext(ctx, "Loading configuration file %s", file)
 		loadedConfigs, configErrs := loader.LoadConfigFile(ctx, fs, loaderContext, file)
 
@@ -335,6 +362,14 @@ func newLoaderContext(loadingContext ProjectLoaderContext, projectDefinition man
 	}
 }
 
+func projectPaths(projects manifest.ProjectDefinitionByProjectID) map[string]string {
+	paths := make(map[string]string, len(projects))
+	for name, p := range projects {
+		paths[name] = p.Path
+	}
+	return paths
+}
+
 func findDuplicatedConfigIdentifiers(ctx context.Context, configs []config.Config, configErrorMap map[coordinate.Coordinate]struct{}) []error {
 	var errs []error
 	coordinates := make(map[string]struct{})
@@ -20,7 +20,9 @@ import (
 	"bytes"
 	"fmt"
 	"io/fs"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/spf13/afero"
@@ -1651,8 +1653,197 @@ func TestLoadProjects_GroupOverrideWithUndefinedGroupProducesWarning(t *testing.
 	assert.Contains(t, logSpy.String(), "group override references unknown group 'prod'")
 }
 
+func TestLoadProjects_InheritsSharedParameters(t *testing.T) {
+	sharedParameters := []byte(`parameters:
+  owner: team-a
+  tags: common
+  profile: [alerting-profile, profile, id]
+groupOverrides:
+- group: prod
+  parameters:
+    owner: team-prod
+environmentOverrides:
+- environment: prod-eu
+  parameters:
+    tags: eu
+`)
+	profileConfig := []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n  type:\n    api: alerting-profile")
+	boardConfig := []byte(`configs:
+- id: board
+  config:
+    name: Test Dashboard
+    template: board.json
+    parameters:
+      tags: dashboard
+  type:
+    api: dashboard`)
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/alerting-profile", testDirectoryFileMode))
+	require.NoError(t, testFs.MkdirAll("project/dashboard", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/_parameters.yaml", sharedParameters, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", profileConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.yaml", boardConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{}"), testFileFileMode))
+
+	loaderContext := getFullProjectLoaderContext([]string{"alerting-profile", "dashboard"}, []string{"project"}, []string{"dev", "prod-eu"})
+	prodEU := loaderContext.Manifest.Environments.SelectedEnvironments["prod-eu"]
+	prodEU.Group = "prod"
+	loaderContext.Manifest.Environments.SelectedEnvironments["prod-eu"] = prodEU
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+	require.Empty(t, gotErrs)
+	require.Len(t, got, 1)
+
+	devBoard := findConfig(t, got[0], "dev", "dashboard", 0)
+	assert.Equal(t, value.New("team-a"), devBoard.Parameters["owner"])
+	assert.Equal(t, value.New("dashboard"), devBoard.Parameters["tags"], "parameters of the config take precedence")
+	assert.Equal(t, reference.New("project", "alerting-profile", "profile", "id"), devBoard.Parameters["profile"])
+
+	prodBoard := findConfig(t, got[0], "prod-eu", "dashboard", 0)
+	assert.Equal(t, value.New("team-prod"), prodBoard.Parameters["owner"])
+	assert.Equal(t, value.New("dashboard"), prodBoard.Parameters["tags"], "parameters of the config take precedence over shared overrides")
+
+	prodProfile := findConfig(t, got[0], "prod-eu", "alerting-profile", 0)
+	assert.Equal(t, value.New("team-prod"), prodProfile.Parameters["owner"])
+	assert.Equal(t, value.New("eu"), prodProfile.Parameters["tags"])
+	assert.Contains(t, prodProfile.AdditionalSourceFiles, filepath.Join("project", "_parameters.yaml"))
+}
+
+func TestLoadProjects_ReturnsErrorForInvalidSharedParameters(t *testing.T) {
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/_parameters.yaml", []byte("params:\n  owner: team-a"), testFileFileMode))
+
+	_, gotErrs := LoadProjects(t.Context(), testFs, getSimpleProjectLoaderContext([]string{"project"}), nil)
+
+	require.Len(t, gotErrs, 1)
+	assert.ErrorContains(t, gotErrs[0], "failed to load shared parameters of project")
+}
+
+func TestLoadProjects_ExpandsForEachConfigs(t *testing.T) {
+	profileConfig := []byte(`configs:
+- id: profile
+  config:
+    name: Test Profile
+    template: profile.json
+  type:
+    api: alerting-profile
+  forEach:
+    key: team
+    file: teams.yaml`)
+	teams := []byte("- team: a\n  owner: alice\n- team: b\n  owner: bob")
+	boardConfig := []byte(`configs:
+- id: board
+  config:
+    name: Test Dashboard
+    template: board.json
+    parameters:
+      profile: [alerting-profile, profile-b, id]
+  type:
+    api: dashboard`)
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/alerting-profile", testDirectoryFileMode))
+	require.NoError(t, testFs.MkdirAll("project/dashboard", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", profileConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/teams.yaml", teams, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.yaml", boardConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{}"), testFileFileMode))
+
+	loaderContext := getFullProjectLoaderContext([]string{"alerting-profile", "dashboard"}, []string{"project"}, []string{"dev"})
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+	require.Empty(t, gotErrs)
+	require.Len(t, got, 1)
+
+	profiles := findConfigs(t, got[0], "dev", "alerting-profile")
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "profile-a", profiles[0].Coordinate.ConfigId)
+	assert.Equal(t, value.New("alice"), profiles[0].Parameters["owner"])
+	assert.Equal(t, "profile-b", profiles[1].Coordinate.ConfigId)
+	assert.Equal(t, value.New("bob"), profiles[1].Parameters["owner"])
+
+	board := findConfig(t, got[0], "dev", "dashboard", 0)
+	assert.Equal(t, reference.New("project", "alerting-profile", "profile-b", "id"), board.Parameters["profile"])
+}
+
+func TestLoadProjects_ReturnsErrorForListFileNotReferencedByForEach(t *testing.T) {
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/alerting-profile", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/teams.yaml", []byte("- team: a\n  owner: alice"), testFileFileMode))
+
+	_, gotErrs := LoadProjects(t.Context(), testFs, getFullProjectLoaderContext([]string{"alerting-profile"}, []string{"project"}, []string{"dev"}), nil)
+
+	require.Len(t, gotErrs, 1)
+	assert.ErrorContains(t, gotErrs[0], "teams.yaml")
+}
+
+func TestLoadProjects_ResolvesExtends(t *testing.T) {
+	baseConfig := []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n    parameters:\n      owner: team-a\n  type:\n    api: alerting-profile")
+	childConfig := []byte("configs:\n- id: child-profile\n  config:\n    name: Child Profile\n  extends: profile")
+
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/alerting-profile", testDirectoryFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.yaml", baseConfig, testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/profile.json", []byte("{}"), testFileFileMode))
+	require.NoError(t, afero.WriteFile(testFs, "project/alerting-profile/child.yaml", childConfig, testFileFileMode))
+
+	loaderContext := getFullProjectLoaderContext([]string{"alerting-profile"}, []string{"project"}, []string{"dev"})
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+	require.Empty(t, gotErrs)
+	require.Len(t, got, 1)
+
+	profiles := findConfigs(t, got[0], "dev", "alerting-profile")
+	require.Len(t, profiles, 2)
+	child := profiles[slices.IndexFunc(profiles, func(c config.Config) bool { return c.Coordinate.ConfigId == "child-profile" })]
+	assert.Equal(t, value.New("Child Profile"), child.Parameters[config.NameParameter])
+	assert.Equal(t, value.New("team-a"), child.Parameters["owner"])
+}
+
 type propResolver func(coordinate.Coordinate, string) (any, bool)
 
 func (p propResolver) GetResolvedProperty(coordinate coordinate.Coordinate, propertyName string) (any, bool) {
 	return p(coordinate, propertyName)
 }
+
+func TestLoadProjects_LoadsProjectsOnlyForEnvironmentsTheyApplyTo(t *testing.T) {
+	profileConfig := []byte("configs:\n- id: profile\n  config:\n    name: Test Profile\n    template: profile.json\n  type:\n    api: alerting-profile")
+
+	testFs := testutils.TempFs(t)
+	for _, p := range []string{"global", "prod-only", "prod-group"} {
+		require.NoError(t, testFs.MkdirAll(p+"/alerting-profile", testDirectoryFileMode))
+		require.NoError(t, afero.WriteFile(testFs, p+"/alerting-profile/profile.yaml", profileConfig, testFileFileMode))
+		require.NoError(t, afero.WriteFile(testFs, p+"/alerting-profile/profile.json", []byte("{}"), testFileFileMode))
+	}
+
+	loaderContext := getFullProjectLoaderContext([]string{"alerting-profile"}, []string{"global", "prod-only", "prod-group"}, []string{"dev", "prod"})
+	prodEnv := loaderContext.Manifest.Environments.SelectedEnvironments["prod"]
+	prodEnv.Group = "production"
+	loaderContext.Manifest.Environments.SelectedEnvironments["prod"] = prodEnv
+	loaderContext.Manifest.Environments.AllGroupNames = map[string]struct{}{"production": {}}
+
+	prodOnly := loaderContext.Manifest.Projects["prod-only"]
+	prodOnly.Environments = []string{"prod"}
+	loaderContext.Manifest.Projects["prod-only"] = prodOnly
+	prodGroup := loaderContext.Manifest.Projects["prod-group"]
+	prodGroup.EnvironmentGroups = []string{"production"}
+	loaderContext.Manifest.Projects["prod-group"] = prodGroup
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+	require.Empty(t, gotErrs)
+	require.Len(t, got, 3)
+
+	environmentsPerProject := make(map[string][]string)
+	for _, p := range got {
+		for env := range p.Configs {
+			environmentsPerProject[p.Id] = append(environmentsPerProject[p.Id], env)
+		}
+	}
+	assert.ElementsMatch(t, []string{"dev", "prod"}, environmentsPerProject["global"])
+	assert.Equal(t, []string{"prod"}, environmentsPerProject["prod-only"])
+	assert.Equal(t, []string{"prod"}, environmentsPerProject["prod-group"])
+}
@@ -65,6 +65,9 @@ type Record struct {
 	// Time is the time associated with the Record.
 	Time JSONTime `json:"time"`
 
+	// Environment optionally provides the name of the environment a config was deployed to.
+	Environment string `json:"environment,omitempty"`
+
 	// Config provides the config ID, project and type of the config associated with the Record.
 	Config *coordinate.Coordinate `json:"config,omitempty"`
 
@@ -107,7 +110,7 @@ func (t *JSONTime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-// ReadReportFile reads a report file and returns a slice of records or an error. It is intended for use in testing.
+// ReadReportFile reads a report file and returns a slice of records or an error.
 func ReadReportFile(fs afero.Fs, filename string) ([]Record, error) {
 	f, err := fs.Open(filename)
 	if err != nil {
@@ -123,7 +126,7 @@ func ReadReportFile(fs afero.Fs, filename string) ([]Record, error) {
 		}
 		records = append(records, r)
 	}
-	if s.Err() != nil {
+	if err := s.Err(); err != nil {
 		return nil, err
 	}
 	return records, nil
@@ -179,13 +179,18 @@ func (d *defaultReporter) updateSummaryFromRecord(r Record) {
 
 // ReportDeployment reports the result of deploying a config.
 func (d *defaultReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
+	d.reportDeployment("", config, state, details, err)
+}
+
+func (d *defaultReporter) reportDeployment(environment string, config coordinate.Coordinate, state RecordState, details []Detail, err error) {
 	record := Record{
-		Type:    TypeDeploy,
-		Time:    JSONTime(d.clockFunc()),
-		Config:  &config,
-		State:   state,
-		Details: details,
-		Error:   convertErrorToString(err),
+		Type:        TypeDeploy,
+		Time:        JSONTime(d.clockFunc()),
+		Environment: environment,
+		Config:      &config,
+		State:       state,
+		Details:     details,
+		Error:       convertErrorToString(err),
 	}
 
 	d.updateSummaryFromRecord(record)
@@ -253,6 +258,30 @@ func (d *defaultReporter) Stop() {
 	d.wg.Wait()
 }
 
+// environmentReporter is a Reporter that adds the name of an environment to all deployment records.
+type environmentReporter struct {
+	*defaultReporter
+	environment string
+}
+
+// ForEnvironment returns a Reporter that adds the given environment name to the deployment records it reports.
+// All other events are reported unchanged by the given Reporter.
+func ForEnvironment(r Reporter, environment string) Reporter {
+	switch r := r.(type) {
+	case *defaultReporter:
+		return &environmentReporter{defaultReporter: r, environment: environment}
+	case *environmentReporter:
+		return &environmentReporter{defaultReporter: r.defaultReporter, environment: environment}
+	default:
+		return r
+	}
+}
+
+// ReportDeployment reports the result of deploying a config to the environment of the Reporter.
+func (e *environmentReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
+	e.reportDeployment(e.environment, config, state, details, err)
+}
+
 type discardReporter struct{}
 
 func (*discardReporter) ReportDeployment(config coordinate.Coordinate, state RecordState, details []Detail, err error) {
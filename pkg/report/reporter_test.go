@@ -88,3 +88,32 @@ func TestReporter_ContextWithDefaultReporterCollectsEvents(t *testing.T) {
 	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard3"}, State: "SKIPPED", Details: []report.Detail{{Type: report.DetailTypeInfo, Message: "skipped"}}, Error: ""}, true)
 	matcher.ContainsRecord(t, records, report.Record{Type: "DEPLOY", Time: report.JSONTime(testTime), Config: &coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard4"}, State: "EXCLUDED", Details: nil, Error: ""}, true)
 }
+
+func TestReporter_ForEnvironmentAddsEnvironmentToDeploymentRecords(t *testing.T) {
+	reportFilename := "test_report.jsonl"
+	fs := testutils.TempFs(t)
+
+	r := report.NewDefaultReporter(fs, reportFilename)
+	envReporter := report.ForEnvironment(r, "env1")
+
+	envReporter.ReportInfo("startup")
+	envReporter.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard1"}, report.StateSuccess, nil, nil)
+	report.ForEnvironment(envReporter, "env2").ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard1"}, report.StateError, nil, errors.New("an error"))
+	r.ReportDeployment(coordinate.Coordinate{Project: "test", Type: "dashboard", ConfigId: "my-dashboard2"}, report.StateSuccess, nil, nil)
+	r.Stop()
+
+	assert.Contains(t, envReporter.GetSummary(), "Deployments success: 2")
+
+	records, err := report.ReadReportFile(fs, reportFilename)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Empty(t, records[0].Environment)
+	assert.Equal(t, "env1", records[1].Environment)
+	assert.Equal(t, "env2", records[2].Environment)
+	assert.Empty(t, records[3].Environment)
+}
+
+func TestReporter_ForEnvironmentWithDiscardReporter(t *testing.T) {
+	r := report.GetReporterFromContextOrDiscard(t.Context())
+	assert.Equal(t, r, report.ForEnvironment(r, "env1"))
+}
@@ -18,6 +18,7 @@ package automation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -82,6 +83,12 @@ func (d DeployAPI) Deploy(ctx context.Context, properties parameter.Properties,
 		Properties: properties,
 		Skip:       false,
 	}
+
+	// the response contains values generated by the API, e.g. IDs of workflow tasks, that other configs may reference
+	var response any
+	if err := json.Unmarshal(resp.Data, &response); err == nil {
+		resolved.Response = response
+	}
 	return resolved, nil
 
 }
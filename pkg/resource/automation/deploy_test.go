@@ -167,6 +167,7 @@ func TestDeployAutomation(t *testing.T) {
 	resolvedEntity, errs := automation.NewDeployAPI(client).Deploy(t.Context(), parameter.Properties{}, "{}", conf)
 	assert.NotNil(t, resolvedEntity)
 	assert.Equal(t, "config-id", resolvedEntity.Properties[config.IdParameter])
+	assert.Equal(t, map[string]any{"id": "config-id"}, resolvedEntity.Response)
 	assert.False(t, resolvedEntity.Skip)
 	assert.Empty(t, errs)
 }
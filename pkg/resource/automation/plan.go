@@ -0,0 +1,70 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package automation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/automationutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	Get(ctx context.Context, resourceType automation.ResourceType, id string) (api.Response, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source}
+}
+
+// Lookup finds the automation object a config would be deployed to, using the same ID as Deploy.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	t, ok := c.Type.(config.AutomationType)
+	if !ok {
+		return resource.RemoteObject{}, false, fmt.Errorf("config was not of expected type %q, but %q", config.AutomationType{}.ID(), c.Type.ID())
+	}
+
+	id := c.OriginObjectId
+	if id == "" {
+		id = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+	}
+
+	resourceType, err := automationutils.ClientResourceTypeFromConfigType(t.Resource)
+	if err != nil {
+		return resource.RemoteObject{}, false, err
+	}
+
+	resp, err := p.source.Get(ctx, resourceType, id)
+	if err != nil {
+		if api.IsNotFoundError(err) {
+			return resource.RemoteObject{}, false, nil
+		}
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to get automation object of type %s with id %s: %w", t.Resource, id, err)
+	}
+
+	return resource.RemoteObject{ID: id, Payload: resp.Data}, true, nil
+}
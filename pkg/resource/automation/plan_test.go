@@ -0,0 +1,104 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package automation_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	automationClient "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/automation"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/automation"
+)
+
+type planSourceStub func(resourceType automationClient.ResourceType, id string) (api.Response, error)
+
+func (s planSourceStub) Get(_ context.Context, resourceType automationClient.ResourceType, id string) (api.Response, error) {
+	return s(resourceType, id)
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	workflow := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "my-workflow"},
+		Type:       config.AutomationType{Resource: config.Workflow},
+	}
+	generatedID := idutils.GenerateUUIDFromCoordinate(workflow.Coordinate)
+
+	t.Run("found by generated ID", func(t *testing.T) {
+		source := planSourceStub(func(resourceType automationClient.ResourceType, id string) (api.Response, error) {
+			assert.Equal(t, automationClient.Workflows, resourceType)
+			assert.Equal(t, generatedID, id)
+			return api.Response{Data: []byte(`{"id": "x"}`)}, nil
+		})
+
+		got, found, err := automation.NewPlanAPI(source).Lookup(t.Context(), nil, "", workflow)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: generatedID, Payload: []byte(`{"id": "x"}`)}, got)
+	})
+
+	t.Run("found by origin object ID", func(t *testing.T) {
+		c := *workflow
+		c.OriginObjectId = "origin-id"
+		source := planSourceStub(func(_ automationClient.ResourceType, id string) (api.Response, error) {
+			assert.Equal(t, "origin-id", id)
+			return api.Response{Data: []byte("{}")}, nil
+		})
+
+		got, found, err := automation.NewPlanAPI(source).Lookup(t.Context(), nil, "", &c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "origin-id", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		source := planSourceStub(func(automationClient.ResourceType, string) (api.Response, error) {
+			return api.Response{}, api.APIError{StatusCode: http.StatusNotFound}
+		})
+
+		_, found, err := automation.NewPlanAPI(source).Lookup(t.Context(), nil, "", workflow)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub(func(automationClient.ResourceType, string) (api.Response, error) {
+			return api.Response{}, errors.New("connection error")
+		})
+
+		_, found, err := automation.NewPlanAPI(source).Lookup(t.Context(), nil, "", workflow)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("wrong config type", func(t *testing.T) {
+		c := &config.Config{Type: config.ClassicApiType{Api: "dashboard"}}
+		_, _, err := automation.NewPlanAPI(planSourceStub(nil)).Lookup(t.Context(), nil, "", c)
+		assert.Error(t, err)
+	})
+}
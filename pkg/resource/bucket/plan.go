@@ -0,0 +1,58 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	Get(ctx context.Context, bucketName string) (api.Response, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source}
+}
+
+// Lookup finds the bucket a config would be deployed to by its bucket name.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	bucketName := c.OriginObjectId
+	if bucketName == "" {
+		bucketName = idutils.GenerateBucketName(c.Coordinate)
+	}
+
+	resp, err := p.source.Get(ctx, bucketName)
+	if err != nil {
+		if api.IsNotFoundError(err) {
+			return resource.RemoteObject{}, false, nil
+		}
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to get bucket '%s': %w", bucketName, err)
+	}
+
+	return resource.RemoteObject{ID: bucketName, Payload: resp.Data}, true, nil
+}
@@ -0,0 +1,96 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucket_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/bucket"
+)
+
+type planSourceStub func(bucketName string) (api.Response, error)
+
+func (s planSourceStub) Get(_ context.Context, bucketName string) (api.Response, error) {
+	return s(bucketName)
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "bucket", ConfigId: "my-bucket"},
+		Type:       config.BucketType{},
+	}
+	bucketName := idutils.GenerateBucketName(c.Coordinate)
+
+	t.Run("found", func(t *testing.T) {
+		source := planSourceStub(func(name string) (api.Response, error) {
+			assert.Equal(t, bucketName, name)
+			return api.Response{Data: []byte(`{"bucketName": "b"}`)}, nil
+		})
+
+		got, found, err := bucket.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: bucketName, Payload: []byte(`{"bucketName": "b"}`)}, got)
+	})
+
+	t.Run("found by origin object ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin_bucket"
+		source := planSourceStub(func(name string) (api.Response, error) {
+			assert.Equal(t, "origin_bucket", name)
+			return api.Response{Data: []byte("{}")}, nil
+		})
+
+		got, found, err := bucket.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "origin_bucket", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		source := planSourceStub(func(string) (api.Response, error) {
+			return api.Response{}, api.APIError{StatusCode: http.StatusNotFound}
+		})
+
+		_, found, err := bucket.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub(func(string) (api.Response, error) {
+			return api.Response{}, errors.New("connection error")
+		})
+
+		_, found, err := bucket.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,117 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classic
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/extract"
+)
+
+type PlanSource interface {
+	Get(ctx context.Context, a api.API, id string) ([]byte, error)
+	ExistsWithName(ctx context.Context, a api.API, name string) (bool, string, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+	apis   api.APIs
+}
+
+func NewPlanAPI(source PlanSource, apis api.APIs) *PlanAPI {
+	return &PlanAPI{source, apis}
+}
+
+// Lookup finds the classic config object a config would be deployed to, using the same identification rules as Deploy.
+func (p PlanAPI) Lookup(ctx context.Context, properties parameter.Properties, _ string, conf *config.Config) (resource.RemoteObject, bool, error) {
+	// create new context to carry logger
+	ctx = logr.NewContextWithSlogLogger(ctx, slog.Default())
+
+	t, ok := conf.Type.(config.ClassicApiType)
+	if !ok {
+		return resource.RemoteObject{}, false, fmt.Errorf("config was not of expected type '%s', but '%s'", config.ClassicApiTypeID, conf.Type.ID())
+	}
+
+	apiToDeploy, found := p.apis[t.Api]
+	if !found {
+		return resource.RemoteObject{}, false, fmt.Errorf("unknown API '%s'. this is most likely a bug", t.Api)
+	}
+
+	if apiToDeploy.HasParent() {
+		scope, err := extract.Scope(properties)
+		if err != nil {
+			return resource.RemoteObject{}, false, fmt.Errorf("failed to extract scope for config '%s': %w", conf.Type.ID(), err)
+		}
+		apiToDeploy = apiToDeploy.ApplyParentObjectID(scope)
+	}
+
+	configName := ""
+	var err error
+	if t.Api != api.DashboardShareSettings {
+		configName, err = extract.ConfigName(conf, properties)
+		if err != nil {
+			return resource.RemoteObject{}, false, err
+		}
+	}
+
+	if apiToDeploy.SingleConfiguration {
+		return p.get(ctx, apiToDeploy, "", configName)
+	}
+
+	if apiToDeploy.NonUniqueName {
+		entityUUID := conf.Coordinate.ConfigId
+		if !idutils.IsUUID(entityUUID) && !idutils.IsMeId(entityUUID) {
+			entityUUID = idutils.GenerateUUIDFromConfigId(conf.Coordinate.Project, entityUUID)
+		}
+
+		if obj, found, err := p.get(ctx, apiToDeploy, entityUUID, entityUUID); err != nil || found {
+			return obj, found, err
+		}
+	}
+
+	exists, id, err := p.source.ExistsWithName(ctx, apiToDeploy, configName)
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to look up config named '%s': %w", configName, err)
+	}
+	if !exists {
+		return resource.RemoteObject{}, false, nil
+	}
+
+	return p.get(ctx, apiToDeploy, id, id)
+}
+
+func (p PlanAPI) get(ctx context.Context, a api.API, id string, resolvedID string) (resource.RemoteObject, bool, error) {
+	payload, err := p.source.Get(ctx, a, id)
+	if err != nil {
+		if coreapi.IsNotFoundError(err) {
+			return resource.RemoteObject{}, false, nil
+		}
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to get config '%s' of API '%s': %w", id, a.ID, err)
+	}
+
+	return resource.RemoteObject{ID: resolvedID, Payload: payload}, true, nil
+}
@@ -0,0 +1,146 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package classic_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreapi "github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/classic"
+)
+
+type planSourceStub struct {
+	getStub            func(a api.API, id string) ([]byte, error)
+	existsWithNameStub func(a api.API, name string) (bool, string, error)
+}
+
+func (s planSourceStub) Get(_ context.Context, a api.API, id string) ([]byte, error) {
+	return s.getStub(a, id)
+}
+
+func (s planSourceStub) ExistsWithName(_ context.Context, a api.API, name string) (bool, string, error) {
+	return s.existsWithNameStub(a, name)
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "dashboard", ConfigId: "my-dashboard"},
+		Type:       config.ClassicApiType{Api: "dashboard"},
+	}
+	properties := parameter.Properties{config.NameParameter: "My Dashboard"}
+	get := func(_ api.API, id string) ([]byte, error) {
+		return []byte(`{"id": "` + id + `"}`), nil
+	}
+
+	t.Run("found by name", func(t *testing.T) {
+		source := planSourceStub{
+			existsWithNameStub: func(_ api.API, name string) (bool, string, error) {
+				assert.Equal(t, "My Dashboard", name)
+				return true, "dashboard-id", nil
+			},
+			getStub: get,
+		}
+
+		got, found, err := classic.NewPlanAPI(source, testApiMap).Lookup(t.Context(), properties, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "dashboard-id", Payload: []byte(`{"id": "dashboard-id"}`)}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		source := planSourceStub{existsWithNameStub: func(api.API, string) (bool, string, error) { return false, "", nil }}
+
+		_, found, err := classic.NewPlanAPI(source, testApiMap).Lookup(t.Context(), properties, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("object deleted between lookup by name and get", func(t *testing.T) {
+		source := planSourceStub{
+			existsWithNameStub: func(api.API, string) (bool, string, error) { return true, "dashboard-id", nil },
+			getStub: func(api.API, string) ([]byte, error) {
+				return nil, coreapi.APIError{StatusCode: http.StatusNotFound}
+			},
+		}
+
+		_, found, err := classic.NewPlanAPI(source, testApiMap).Lookup(t.Context(), properties, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("non-unique name APIs are looked up by the generated ID first", func(t *testing.T) {
+		apis := api.APIs{"dashboard": api.API{ID: "dashboard", URLPath: "dashboard", NonUniqueName: true}}
+		generatedID := idutils.GenerateUUIDFromConfigId(c.Coordinate.Project, c.Coordinate.ConfigId)
+		source := planSourceStub{getStub: func(_ api.API, id string) ([]byte, error) {
+			assert.Equal(t, generatedID, id)
+			return get(api.API{}, id)
+		}}
+
+		got, found, err := classic.NewPlanAPI(source, apis).Lookup(t.Context(), properties, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, generatedID, got.ID)
+	})
+
+	t.Run("single configuration APIs are always found", func(t *testing.T) {
+		apis := api.APIs{"dashboard": api.API{ID: "dashboard", URLPath: "dashboard", SingleConfiguration: true}}
+		source := planSourceStub{getStub: func(_ api.API, id string) ([]byte, error) {
+			assert.Empty(t, id)
+			return []byte("{}"), nil
+		}}
+
+		got, found, err := classic.NewPlanAPI(source, apis).Lookup(t.Context(), properties, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "My Dashboard", got.ID)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub{existsWithNameStub: func(api.API, string) (bool, string, error) {
+			return false, "", errors.New("connection error")
+		}}
+
+		_, found, err := classic.NewPlanAPI(source, testApiMap).Lookup(t.Context(), properties, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		_, _, err := classic.NewPlanAPI(planSourceStub{}, testApiMap).Lookup(t.Context(), parameter.Properties{}, "", c)
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown API", func(t *testing.T) {
+		unknown := &config.Config{Coordinate: c.Coordinate, Type: config.ClassicApiType{Api: "unknown"}}
+		_, _, err := classic.NewPlanAPI(planSourceStub{}, testApiMap).Lookup(t.Context(), properties, "", unknown)
+		assert.Error(t, err)
+	})
+}
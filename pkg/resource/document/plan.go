@@ -0,0 +1,85 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	List(ctx context.Context, filter string) (documents.ListResponse, error)
+	Get(ctx context.Context, id string) (documents.Response, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source}
+}
+
+// Lookup finds the document a config would be deployed to, either by its origin object ID or its external ID.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	// create new context to carry logger
+	ctx = logr.NewContextWithSlogLogger(ctx, slog.Default())
+
+	if c.OriginObjectId != "" {
+		if obj, found, err := p.get(ctx, c.OriginObjectId); err != nil || found {
+			return obj, found, err
+		}
+	}
+
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+	listResponse, err := p.source.List(ctx, fmt.Sprintf("externalId=='%s'", externalID))
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("error finding document with externalId='%s': %w", externalID, err)
+	}
+
+	if len(listResponse.Responses) > 1 {
+		return resource.RemoteObject{}, false, fmt.Errorf("multiple documents found with externalId='%s'", externalID)
+	}
+
+	if len(listResponse.Responses) == 0 {
+		return resource.RemoteObject{}, false, nil
+	}
+
+	return p.get(ctx, listResponse.Responses[0].ID)
+}
+
+func (p PlanAPI) get(ctx context.Context, id string) (resource.RemoteObject, bool, error) {
+	resp, err := p.source.Get(ctx, id)
+	if err != nil {
+		if api.IsNotFoundError(err) {
+			return resource.RemoteObject{}, false, nil
+		}
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to get document '%s': %w", id, err)
+	}
+
+	return resource.RemoteObject{ID: resp.ID, Payload: resp.Data}, true, nil
+}
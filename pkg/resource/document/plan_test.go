@@ -0,0 +1,129 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package document_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/documents"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/document"
+)
+
+type planSourceStub struct {
+	listStub func(filter string) (documents.ListResponse, error)
+	getStub  func(id string) (documents.Response, error)
+}
+
+func (s planSourceStub) List(_ context.Context, filter string) (documents.ListResponse, error) {
+	return s.listStub(filter)
+}
+
+func (s planSourceStub) Get(_ context.Context, id string) (documents.Response, error) {
+	return s.getStub(id)
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "document", ConfigId: "my-dashboard"},
+		Type:       config.DocumentType{Kind: config.DashboardKind},
+	}
+	expectedFilter := "externalId=='" + idutils.GenerateExternalID(c.Coordinate) + "'"
+	listing := func(ids ...string) func(string) (documents.ListResponse, error) {
+		return func(filter string) (documents.ListResponse, error) {
+			assert.Equal(t, expectedFilter, filter)
+			resp := documents.ListResponse{}
+			for _, id := range ids {
+				resp.Responses = append(resp.Responses, documents.Response{Metadata: documents.Metadata{ID: id}})
+			}
+			return resp, nil
+		}
+	}
+	get := func(id string) (documents.Response, error) {
+		return documents.Response{Metadata: documents.Metadata{ID: id}, Data: []byte(`{"id": "` + id + `"}`)}, nil
+	}
+	notFound := func(string) (documents.Response, error) {
+		return documents.Response{}, api.APIError{StatusCode: http.StatusNotFound}
+	}
+
+	t.Run("found by external ID", func(t *testing.T) {
+		got, found, err := document.NewPlanAPI(planSourceStub{listStub: listing("document-id"), getStub: get}).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "document-id", Payload: []byte(`{"id": "document-id"}`)}, got)
+	})
+
+	t.Run("found by origin object ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+
+		got, found, err := document.NewPlanAPI(planSourceStub{getStub: get}).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "origin-id", got.ID)
+	})
+
+	t.Run("falls back to external ID if origin object does not exist", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := planSourceStub{listStub: listing("document-id"), getStub: func(id string) (documents.Response, error) {
+			if id == "origin-id" {
+				return notFound(id)
+			}
+			return get(id)
+		}}
+
+		got, found, err := document.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "document-id", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, found, err := document.NewPlanAPI(planSourceStub{listStub: listing()}).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("multiple documents with the same external ID", func(t *testing.T) {
+		_, found, err := document.NewPlanAPI(planSourceStub{listStub: listing("a", "b")}).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "multiple documents found")
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub{listStub: func(string) (documents.ListResponse, error) {
+			return documents.ListResponse{}, errors.New("connection error")
+		}}
+
+		_, found, err := document.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+}
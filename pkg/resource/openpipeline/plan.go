@@ -0,0 +1,72 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openpipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/templatetools"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	GetAll(ctx context.Context) ([]openpipeline.Response, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source}
+}
+
+// Lookup finds the openpipeline configuration of the config's kind. As these always exist, a deployment is always an update.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	//create new context to carry logger
+	ctx = logr.NewContextWithSlogLogger(ctx, slog.Default())
+
+	t, ok := c.Type.(config.OpenPipelineType)
+	if !ok {
+		return resource.RemoteObject{}, false, fmt.Errorf("expected openpipeline config type but found %v", c.Type)
+	}
+
+	all, err := p.source.GetAll(ctx)
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to get openpipeline objects: %w", err)
+	}
+
+	for _, response := range all {
+		jsonObj, err := templatetools.NewJSONObject(response.Data)
+		if err != nil {
+			return resource.RemoteObject{}, false, fmt.Errorf("failed to unmarshal payload: %w", err)
+		}
+
+		if id, ok := jsonObj.Get("id").(string); ok && id == t.Kind {
+			return resource.RemoteObject{ID: t.Kind, Payload: response.Data}, true, nil
+		}
+	}
+
+	return resource.RemoteObject{}, false, nil
+}
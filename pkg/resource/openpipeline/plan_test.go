@@ -0,0 +1,88 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package openpipeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreOpenpipeline "github.com/dynatrace/dynatrace-configuration-as-code-core/clients/openpipeline"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/openpipeline"
+)
+
+type planSourceStub func() ([]coreOpenpipeline.Response, error)
+
+func (s planSourceStub) GetAll(context.Context) ([]coreOpenpipeline.Response, error) {
+	return s()
+}
+
+func listing(payloads ...string) planSourceStub {
+	return func() ([]coreOpenpipeline.Response, error) {
+		var responses []coreOpenpipeline.Response
+		for _, p := range payloads {
+			responses = append(responses, coreOpenpipeline.Response{Data: []byte(p)})
+		}
+		return responses, nil
+	}
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "openpipeline", ConfigId: "logs"},
+		Type:       config.OpenPipelineType{Kind: "logs"},
+	}
+
+	t.Run("found by kind", func(t *testing.T) {
+		got, found, err := openpipeline.NewPlanAPI(listing(`{"id": "events"}`, `{"id": "logs"}`)).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "logs", Payload: []byte(`{"id": "logs"}`)}, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, found, err := openpipeline.NewPlanAPI(listing(`{"id": "events"}`)).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub(func() ([]coreOpenpipeline.Response, error) { return nil, errors.New("connection error") })
+
+		_, found, err := openpipeline.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, _, err := openpipeline.NewPlanAPI(listing("not json")).Lookup(t.Context(), nil, "", c)
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong config type", func(t *testing.T) {
+		_, _, err := openpipeline.NewPlanAPI(listing()).Lookup(t.Context(), nil, "", &config.Config{Type: config.Segment{}})
+		assert.Error(t, err)
+	})
+}
@@ -32,6 +32,27 @@ type Deployable interface {
 	Deploy(ctx context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (entities.ResolvedEntity, error)
 }
 
+type Plannables = map[config.TypeID]Plannable
+
+// RemoteObject is an object found on a Dynatrace environment.
+type RemoteObject struct {
+	// ID is the ID of the remote object, as it would be stored in the 'id' property after a deployment.
+	ID string
+
+	// ObjectID is the ID the remote object is identified by in its API. It is only set if it may differ from ID, like
+	// for settings objects, whose ID may be converted to the ID of the entity they represent.
+	ObjectID string
+
+	// Payload is the JSON payload of the remote object.
+	Payload []byte
+}
+
+type Plannable interface {
+	// Lookup returns the remote object a given resource would be deployed to without modifying the environment.
+	// If no such object exists, and a deployment would thus create a new one, false is returned.
+	Lookup(ctx context.Context, properties parameter.Properties, renderedConfig string, c *config.Config) (RemoteObject, bool, error)
+}
+
 type Downloadable interface {
 
 	// Download returns downloaded project.ConfigsPerType, and an error, if something went wrong during the download.
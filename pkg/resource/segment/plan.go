@@ -0,0 +1,78 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package segment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	Get(ctx context.Context, id string) (api.Response, error)
+	GetAll(ctx context.Context) ([]api.Response, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source: source}
+}
+
+// Lookup finds the segment a config would be deployed to, either by its origin object ID or its external ID.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	ctx = logr.NewContextWithSlogLogger(ctx, slog.Default())
+
+	if c.OriginObjectId != "" {
+		resp, err := p.source.Get(ctx, c.OriginObjectId)
+		if err == nil {
+			return resource.RemoteObject{ID: c.OriginObjectId, Payload: resp.Data}, true, nil
+		}
+
+		if !api.IsNotFoundError(err) {
+			return resource.RemoteObject{}, false, fmt.Errorf("failed to get segment %s: %w", c.OriginObjectId, err)
+		}
+	}
+
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+	all, err := p.source.GetAll(ctx)
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to GET segments: %w", err)
+	}
+
+	for _, segmentResponse := range all {
+		responseData, err := getJsonResponseFromSegmentsResponse(segmentResponse)
+		if err != nil {
+			return resource.RemoteObject{}, false, err
+		}
+		if responseData.ExternalId == externalID {
+			return resource.RemoteObject{ID: responseData.UID, Payload: segmentResponse.Data}, true, nil
+		}
+	}
+
+	return resource.RemoteObject{}, false, nil
+}
@@ -0,0 +1,120 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package segment_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/segment"
+)
+
+type planSourceStub struct {
+	getStub    func(id string) (api.Response, error)
+	getAllStub func() ([]api.Response, error)
+}
+
+func (s planSourceStub) Get(_ context.Context, id string) (api.Response, error) {
+	return s.getStub(id)
+}
+
+func (s planSourceStub) GetAll(context.Context) ([]api.Response, error) {
+	return s.getAllStub()
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "segment", ConfigId: "my-segment"},
+		Type:       config.Segment{},
+	}
+	byExternalID := `{"uid": "segment-uid", "externalId": "` + idutils.GenerateExternalID(c.Coordinate) + `"}`
+	all := func() ([]api.Response, error) {
+		return []api.Response{{Data: []byte(`{"uid": "other", "externalId": "other"}`)}, {Data: []byte(byExternalID)}}, nil
+	}
+	notFound := func(string) (api.Response, error) {
+		return api.Response{}, api.APIError{StatusCode: http.StatusNotFound}
+	}
+
+	t.Run("found by external ID", func(t *testing.T) {
+		got, found, err := segment.NewPlanAPI(planSourceStub{getAllStub: all}).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "segment-uid", Payload: []byte(byExternalID)}, got)
+	})
+
+	t.Run("found by origin object ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := planSourceStub{getStub: func(id string) (api.Response, error) {
+			assert.Equal(t, "origin-id", id)
+			return api.Response{Data: []byte("{}")}, nil
+		}}
+
+		got, found, err := segment.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "origin-id", Payload: []byte("{}")}, got)
+	})
+
+	t.Run("falls back to external ID if origin object does not exist", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+
+		got, found, err := segment.NewPlanAPI(planSourceStub{getStub: notFound, getAllStub: all}).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "segment-uid", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		source := planSourceStub{getAllStub: func() ([]api.Response, error) { return nil, nil }}
+
+		_, found, err := segment.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error on get", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := planSourceStub{getStub: func(string) (api.Response, error) { return api.Response{}, errors.New("connection error") }}
+
+		_, found, err := segment.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("client error on list", func(t *testing.T) {
+		source := planSourceStub{getAllStub: func() ([]api.Response, error) { return nil, errors.New("connection error") }}
+
+		_, found, err := segment.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,82 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	List(ctx context.Context, schema string, options dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error)
+}
+
+type PlanAPI struct {
+	source PlanSource
+}
+
+func NewPlanAPI(source PlanSource) *PlanAPI {
+	return &PlanAPI{source}
+}
+
+// Lookup finds the settings object a config would be deployed to, either by its monaco external ID or its origin object ID.
+// Objects only matching by unique schema properties are not considered.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	t, ok := c.Type.(config.SettingsType)
+	if !ok {
+		return resource.RemoteObject{}, false, fmt.Errorf("config was not of expected type %q, but %q", config.SettingsTypeID, c.Type.ID())
+	}
+
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate)
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("unable to generate external id: %w", err)
+	}
+
+	objects, err := p.source.List(ctx, t.SchemaId, dtclient.ListSettingsOptions{
+		Filter: func(o dtclient.DownloadSettingsObject) bool {
+			return o.ExternalId == externalID || (c.OriginObjectId != "" && o.ObjectId == c.OriginObjectId)
+		},
+	})
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to list settings objects of schema %q: %w", t.SchemaId, err)
+	}
+
+	if len(objects) == 0 {
+		return resource.RemoteObject{}, false, nil
+	}
+
+	// if both an object with the origin object ID and one with the external ID exist, Deploy updates the latter
+	match := objects[0]
+	for _, o := range objects {
+		if o.ExternalId == externalID {
+			match = o
+		}
+	}
+
+	id, err := getEntityID(c, dtclient.DynatraceEntity{Id: match.ObjectId})
+	if err != nil {
+		return resource.RemoteObject{}, false, err
+	}
+
+	return resource.RemoteObject{ID: id, ObjectID: match.ObjectId, Payload: match.Value}, true, nil
+}
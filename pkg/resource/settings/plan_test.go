@@ -0,0 +1,121 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/settings"
+)
+
+// planSourceStub returns the objects matching the filter of the list options, like the settings client does.
+type planSourceStub struct {
+	objects []dtclient.DownloadSettingsObject
+	err     error
+}
+
+func (s planSourceStub) List(_ context.Context, _ string, options dtclient.ListSettingsOptions) ([]dtclient.DownloadSettingsObject, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var result []dtclient.DownloadSettingsObject
+	for _, o := range s.objects {
+		if options.Filter == nil || options.Filter(o) {
+			result = append(result, o)
+		}
+	}
+	return result, nil
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "profile"},
+		Type:       config.SettingsType{SchemaId: "builtin:alerting.profile"},
+	}
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(c.Coordinate)
+	require.NoError(t, err)
+
+	t.Run("found by external ID", func(t *testing.T) {
+		source := planSourceStub{objects: []dtclient.DownloadSettingsObject{
+			{ObjectId: "other", ExternalId: "other-external-id", Value: []byte("{}")},
+			{ObjectId: "object-id", ExternalId: externalID, Value: []byte(`{"name": "profile"}`)},
+		}}
+
+		got, found, err := settings.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "object-id", ObjectID: "object-id", Payload: []byte(`{"name": "profile"}`)}, got)
+	})
+
+	t.Run("found by origin object ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := planSourceStub{objects: []dtclient.DownloadSettingsObject{{ObjectId: "origin-id", Value: []byte("{}")}}}
+
+		got, found, err := settings.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "origin-id", got.ID)
+	})
+
+	t.Run("external ID takes precedence over origin object ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := planSourceStub{objects: []dtclient.DownloadSettingsObject{
+			{ObjectId: "origin-id"},
+			{ObjectId: "object-id", ExternalId: externalID},
+		}}
+
+		got, found, err := settings.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "object-id", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		source := planSourceStub{objects: []dtclient.DownloadSettingsObject{{ObjectId: "other", ExternalId: "other-external-id"}}}
+
+		_, found, err := settings.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub{err: errors.New("connection error")}
+
+		_, found, err := settings.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("wrong config type", func(t *testing.T) {
+		_, _, err := settings.NewPlanAPI(planSourceStub{}).Lookup(t.Context(), nil, "", &config.Config{Type: config.ClassicApiType{Api: "dashboard"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,76 @@
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-logr/logr"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+)
+
+type PlanSource interface {
+	List(ctx context.Context) (api.PagedListResponse, error)
+}
+
+type PlanAPI struct {
+	sloSource PlanSource
+}
+
+func NewPlanAPI(sloSource PlanSource) *PlanAPI {
+	return &PlanAPI{sloSource}
+}
+
+// Lookup finds the SLO a config would be deployed to, either by its origin object ID or its external ID.
+func (p PlanAPI) Lookup(ctx context.Context, _ parameter.Properties, _ string, c *config.Config) (resource.RemoteObject, bool, error) {
+	ctx = logr.NewContextWithSlogLogger(ctx, slog.Default())
+
+	apiResponse, err := p.sloSource.List(ctx)
+	if err != nil {
+		return resource.RemoteObject{}, false, fmt.Errorf("failed to list slos: %w", err)
+	}
+
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+	var match *resource.RemoteObject
+	for _, raw := range apiResponse.All() {
+		res := sloResponse{}
+		if err := json.Unmarshal(raw, &res); err != nil {
+			return resource.RemoteObject{}, false, err
+		}
+
+		// an object with the origin object ID takes precedence, as Deploy tries to update it first
+		if c.OriginObjectId != "" && res.ID == c.OriginObjectId {
+			return resource.RemoteObject{ID: res.ID, Payload: raw}, true, nil
+		}
+		if res.ExternalID == externalID {
+			match = &resource.RemoteObject{ID: res.ID, Payload: raw}
+		}
+	}
+
+	if match == nil {
+		return resource.RemoteObject{}, false, nil
+	}
+	return *match, true, nil
+}
@@ -0,0 +1,101 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2025 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slo_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/resource/slo"
+)
+
+type planSourceStub func() (api.PagedListResponse, error)
+
+func (s planSourceStub) List(context.Context) (api.PagedListResponse, error) {
+	return s()
+}
+
+func listing(objects ...string) planSourceStub {
+	return func() (api.PagedListResponse, error) {
+		list := api.ListResponse{}
+		for _, o := range objects {
+			list.Objects = append(list.Objects, []byte(o))
+		}
+		return api.PagedListResponse{list}, nil
+	}
+}
+
+func TestPlanAPI_Lookup(t *testing.T) {
+	c := &config.Config{
+		Coordinate: coordinate.Coordinate{Project: "project", Type: "slo-v2", ConfigId: "my-slo"},
+		Type:       config.ServiceLevelObjective{},
+	}
+	externalID := idutils.GenerateExternalID(c.Coordinate)
+	byExternalID := `{"id": "slo-id", "externalId": "` + externalID + `"}`
+
+	t.Run("found by external ID", func(t *testing.T) {
+		source := listing(`{"id": "other", "externalId": "other"}`, byExternalID)
+
+		got, found, err := slo.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, resource.RemoteObject{ID: "slo-id", Payload: []byte(byExternalID)}, got)
+	})
+
+	t.Run("origin object ID takes precedence over external ID", func(t *testing.T) {
+		withOrigin := *c
+		withOrigin.OriginObjectId = "origin-id"
+		source := listing(byExternalID, `{"id": "origin-id"}`)
+
+		got, found, err := slo.NewPlanAPI(source).Lookup(t.Context(), nil, "", &withOrigin)
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, "origin-id", got.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, found, err := slo.NewPlanAPI(listing(`{"id": "other", "externalId": "other"}`)).Lookup(t.Context(), nil, "", c)
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		source := planSourceStub(func() (api.PagedListResponse, error) {
+			return nil, errors.New("connection error")
+		})
+
+		_, found, err := slo.NewPlanAPI(source).Lookup(t.Context(), nil, "", c)
+		assert.ErrorContains(t, err, "connection error")
+		assert.False(t, found)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, _, err := slo.NewPlanAPI(listing("not json")).Lookup(t.Context(), nil, "", c)
+		assert.Error(t, err)
+	})
+}
@@ -35,6 +35,8 @@ type WriterContext struct {
 	OutputDir          string
 	ManifestName       string
 	ParametersSerde    map[string]parameter.ParameterSerDe
+	// ExtractSharedParameters writes parameters shared by all configs of a project to its shared parameters file
+	ExtractSharedParameters bool
 }
 
 func WriteToDisk(context *WriterContext, manifestToWrite manifest.Manifest, projects []project.Project) []error {
@@ -83,6 +85,8 @@ func writeProjects(context *WriterContext, projectDefinitions manifest.ProjectDe
 			OutputFolder:    context.OutputDir,
 			ProjectFolder:   definition.Path,
 			ParametersSerde: context.ParametersSerde,
+
+			ExtractSharedParameters: context.ExtractSharedParameters,
 		}, configs)
 
 		errors = append(errors, errs...)